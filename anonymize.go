@@ -0,0 +1,157 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
+	"strings"
+)
+
+// AnonymizeMask は -anonymize でスニペットのヒット部分以外の文字を置き換える文字です
+const AnonymizeMask = '*'
+
+// AnonymizeJIS90 は -anonymize で付ける分類のうち、ASCII・半角カタカナ・JIS X 0208 の文字を表します
+// (それ以外は -audit jis2004 と同じ plane1|level3|level4|outside)
+const AnonymizeJIS90 = "jis90"
+
+// anonymizePath はパスを SHA-256 の先頭16桁に置き換えます。同じパスは常に同じ値になるため、
+// 匿名化したレポート同士でもファイルの対応は取れます。標準入力や空のパスはそのまま返します。
+func anonymizePath(path string) string {
+	if path == "" || path == StdinPath {
+		return path
+	}
+	sum := sha256.Sum256([]byte(path))
+	return hex.EncodeToString(sum[:])[:16]
+}
+
+// matchClass はヒット部分の文字の分類を出現順に重複なくカンマ区切りで返します
+func matchClass(match string) string {
+	var classes []string
+	for _, r := range match {
+		class := classifyJIS2004(r)
+		if class == "" {
+			class = AnonymizeJIS90
+		}
+		if !slices.Contains(classes, class) {
+			classes = append(classes, class)
+		}
+	}
+	return strings.Join(classes, ",")
+}
+
+// anonymizeSnippet はヒット部分以外の文字を AnonymizeMask で伏せたスニペットを返します。
+// 文字数は変えず、info のヒット位置(バイト単位)は伏せた後のスニペットでの位置に直します。
+// 元の行の内容が残る16進表示・変換結果は取り除きます。
+func anonymizeSnippet(snippet string, info SnippetInfo) (string, SnippetInfo) {
+	keep := func(i int) bool {
+		if i >= info.MatchStart && i < info.MatchEnd {
+			return true
+		}
+		for _, m := range info.Matches {
+			if i >= m.Start && i < m.End {
+				return true
+			}
+		}
+		return false
+	}
+
+	var sb strings.Builder
+	pos := make([]int, len(snippet)+1) // 元のバイト位置から伏せた後のバイト位置
+	for i, r := range snippet {
+		pos[i] = sb.Len()
+		if keep(i) {
+			sb.WriteRune(r)
+		} else {
+			sb.WriteRune(AnonymizeMask)
+		}
+	}
+	pos[len(snippet)] = sb.Len()
+
+	info.Class = matchClass(snippet[info.MatchStart:info.MatchEnd])
+	info.MatchStart, info.MatchEnd = pos[info.MatchStart], pos[info.MatchEnd]
+	if info.Matches != nil {
+		matches := make([]MatchSpan, len(info.Matches))
+		for i, m := range info.Matches {
+			matches[i] = MatchSpan{Query: m.Query, Start: pos[m.Start], End: pos[m.End]}
+		}
+		info.Matches = matches
+	}
+	info.Path = anonymizePath(info.Path)
+	info.Raw = nil
+	info.Converted = ""
+	return sb.String(), info
+}
+
+// AnonymizeResults は社外(ベンダーのサポート窓口等)へ渡せるよう、結果を匿名化したコピーを返します(-anonymize)。
+// ファイルのパスはハッシュ値に置き換え、スニペットはヒットした文字とその分類のみを残して伏せます。
+// paths が指定されていれば、揃えたパスをハッシュ化します(作業ディレクトリによらず同じ値になる)。
+// ファイルの所有者は出力しません。
+func AnonymizeResults(results map[string]*SearchResult, paths *PathResolver) map[string]*SearchResult {
+	hash := func(path string) string { return anonymizePath(paths.Resolve(path)) }
+	anonymized := make(map[string]*SearchResult, len(results))
+	for q, res := range results {
+		a := *res
+		a.First = anonymizeOccurrence(res.First, hash)
+		a.Last = anonymizeOccurrence(res.Last, hash)
+		if res.Files != nil {
+			a.Files = make([]FileCount, len(res.Files))
+			for i, f := range res.Files {
+				a.Files[i] = FileCount{Path: hash(f.Path), Count: f.Count}
+			}
+		}
+		if res.Resized != nil {
+			a.Resized = make([]SizeChange, len(res.Resized))
+			for i, c := range res.Resized {
+				c.Path = hash(c.Path)
+				a.Resized[i] = c
+			}
+		}
+		a.Snippets = make([]string, len(res.Snippets))
+		a.Infos = make([]SnippetInfo, len(res.Infos))
+		for i, snippet := range res.Snippets {
+			info := SnippetInfo{MatchStart: len(snippet), MatchEnd: len(snippet)} // 付随情報がなければ全体を伏せる
+			if i < len(res.Infos) {
+				info = res.Infos[i]
+				info.Path = paths.Resolve(info.Path)
+			}
+			a.Snippets[i], info = anonymizeSnippet(snippet, info)
+			if i < len(res.Infos) {
+				a.Infos[i] = info
+			}
+		}
+		anonymized[q] = &a
+	}
+	return anonymized
+}
+
+// AnonymizeOutput は出力方法のうち入力ファイルの情報・入力パスを匿名化したコピーを返します。
+// パスは AnonymizeResults で揃えてからハッシュ化するため、返す Paths は nil です。
+func AnonymizeOutput(opts OutputOptions) OutputOptions {
+	if opts.File != nil {
+		file := *opts.File
+		file.Path = anonymizePath(opts.Paths.Resolve(file.Path))
+		file.Ownership = nil
+		opts.File = &file
+	}
+	opts.InputPath = anonymizePath(opts.Paths.Resolve(opts.InputPath))
+	opts.Paths = nil
+	return opts
+}
+
+// anonymizeOccurrence はヒット位置のパスを hash で置き換えたコピーを返します
+func anonymizeOccurrence(o *Occurrence, hash func(string) string) *Occurrence {
+	if o == nil {
+		return nil
+	}
+	a := *o
+	a.Path = hash(o.Path)
+	return &a
+}
+
+// classAnnotation は匿名化したスニペットに付ける分類の注記(例: " [level3]")を返します
+func classAnnotation(res *SearchResult, i int) string {
+	if i >= len(res.Infos) || res.Infos[i].Class == "" {
+		return ""
+	}
+	return " [" + res.Infos[i].Class + "]"
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestAnonymizeSnippet(t *testing.T) {
+	snippet := "森鷗外と髙橋"
+	info := SnippetInfo{
+		Path:       "secret/a.txt",
+		Raw:        []byte(snippet),
+		Converted:  "森?外と?橋",
+		MatchStart: len("森"),
+		MatchEnd:   len("森鷗"),
+		Matches:    []MatchSpan{{Query: "鷗", Start: len("森"), End: len("森鷗")}, {Query: "髙", Start: len("森鷗外と"), End: len("森鷗外と髙")}},
+	}
+	got, gotInfo := anonymizeSnippet(snippet, info)
+	if got != "*鷗**髙*" {
+		t.Errorf("snippet = %q", got)
+	}
+	if got[gotInfo.MatchStart:gotInfo.MatchEnd] != "鷗" || got[gotInfo.Matches[1].Start:gotInfo.Matches[1].End] != "髙" {
+		t.Errorf("match positions = %+v", gotInfo)
+	}
+	if gotInfo.Class != AuditLevel3 || gotInfo.Raw != nil || gotInfo.Converted != "" {
+		t.Errorf("info = %+v", gotInfo)
+	}
+	if gotInfo.Path != anonymizePath("secret/a.txt") || strings.Contains(gotInfo.Path, "secret") {
+		t.Errorf("path = %q", gotInfo.Path)
+	}
+	// 元の位置情報は変更しない
+	if info.MatchStart != len("森") || info.Matches[1].Start != len("森鷗外と") {
+		t.Errorf("original info modified: %+v", info)
+	}
+}
+
+func TestMatchClass(t *testing.T) {
+	tests := []struct {
+		match, want string
+	}{
+		{"A", AnonymizeJIS90},
+		{"鷗", AuditLevel3},
+		{"鷗外𠂉", "level3,jis90,level4"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := matchClass(tt.match); got != tt.want {
+			t.Errorf("matchClass(%q) = %q, want %q", tt.match, got, tt.want)
+		}
+	}
+}
+
+func TestRun_Anonymize(t *testing.T) {
+	files := map[string]string{"customer/顧客名簿.txt": "山田 鷗太郎 様\n"}
+	reader := func(p string) (io.ReadCloser, error) { return io.NopCloser(strings.NewReader(files[p])), nil }
+
+	stdout := new(bytes.Buffer)
+	code := Run(AppContext{Args: []string{"app", "-anonymize", "-format", "json", "-q", "鷗", "customer/顧客名簿.txt"}, Stdout: stdout, Stderr: io.Discard, FileReader: reader})
+	if code != ExitMatch {
+		t.Fatalf("Run() exit code = %d", code)
+	}
+	out := stdout.String()
+	for _, leaked := range []string{"customer", "顧客", "山田", "太郎"} {
+		if strings.Contains(out, leaked) {
+			t.Errorf("output contains %q:\n%s", leaked, out)
+		}
+	}
+	var report JSONReport
+	if err := json.Unmarshal(stdout.Bytes(), &report); err != nil {
+		t.Fatal(err)
+	}
+	js := report.Results[0].Snippets[0]
+	if report.Input != anonymizePath("customer/顧客名簿.txt") || js.Match != "鷗" || js.Class != AuditLevel3 || js.Pre != "***" || js.Line != 1 {
+		t.Errorf("report = %+v", report)
+	}
+
+	if code := Run(AppContext{Args: []string{"app", "-anonymize", "-passthrough", "matched", "-q", "鷗", "customer/顧客名簿.txt"}, Stdout: io.Discard, Stderr: io.Discard, FileReader: reader}); code != ExitError {
+		t.Errorf("-anonymize -passthrough: exit code = %d, want %d", code, ExitError)
+	}
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"strings"
+)
+
+// snippetArenaBlock はスニペット用のブロックの既定の大きさ(バイト)です
+const snippetArenaBlock = 64 << 10
+
+// snippetArena はスニペットの文字列を大きなブロックに詰めて確保します。
+// スニペットの上限を大きくした場合でも、スニペットごとの確保とサイズクラスの切り上げによる無駄がなく、
+// 使用量はスニペットの内容の合計にほぼ等しくなります。
+// 確保済みの部分は書き換えないため、返した文字列はブロックが切り替わった後も有効です。
+type snippetArena struct {
+	block *strings.Builder
+}
+
+// join は parts を連結したスニペットをブロック内に確保して返します
+func (a *snippetArena) join(parts ...string) string {
+	n := 0
+	for _, p := range parts {
+		n += len(p)
+	}
+	// 残りに収まらなければ新しいブロックに切り替える(再確保で既存の文字列が複製されないように)
+	if a.block == nil || a.block.Cap()-a.block.Len() < n {
+		a.block = new(strings.Builder)
+		a.block.Grow(max(snippetArenaBlock, n))
+	}
+
+	start := a.block.Len()
+	for _, p := range parts {
+		a.block.WriteString(p)
+	}
+	return a.block.String()[start:]
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestSnippetArena はブロックの切り替えをまたいでも確保済みのスニペットが壊れないか確認します
+func TestSnippetArena(t *testing.T) {
+	var arena snippetArena
+	var got, want []string
+	for i := 0; i < 3*snippetArenaBlock/100; i++ {
+		pre, hit := strings.Repeat("前", i%40), strings.Repeat("x", 50)
+		got = append(got, arena.join(pre, hit, "後"))
+		want = append(want, pre+hit+"後")
+	}
+	long := strings.Repeat("y", 2*snippetArenaBlock)
+	got = append(got, arena.join(long))
+	want = append(want, long)
+
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("snippet %d corrupted: got %q", i, got[i])
+		}
+	}
+}
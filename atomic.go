@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// atomicFile は一時ファイルに書き込み、Commit で本来のパスに置き換える出力ファイルです。
+// 途中で失敗・中断しても、書きかけのレポートが完成したものとして後続の処理に渡ることはありません。
+// AppContext に Rename がない場合は本来のパスに直接書き込みます。
+type atomicFile struct {
+	io.WriteCloser
+	tmp, path string
+	rename    func(string, string) error
+	remove    func(string) error
+	done      bool
+}
+
+// createAtomic は path と同じディレクトリに一時ファイルを作成します(置き換えを同じファイルシステム内で行うため)
+func createAtomic(ctx AppContext, path string) (*atomicFile, error) {
+	if ctx.Rename == nil {
+		f, err := ctx.FileCreator(path)
+		if err != nil {
+			return nil, err
+		}
+		return &atomicFile{WriteCloser: f, tmp: path, path: path}, nil
+	}
+
+	tmp := fmt.Sprintf("%s.%d.tmp", path, os.Getpid())
+	f, err := ctx.FileCreator(tmp)
+	if err != nil {
+		return nil, err
+	}
+	return &atomicFile{WriteCloser: f, tmp: tmp, path: path, rename: ctx.Rename, remove: ctx.Remove}, nil
+}
+
+// Commit はファイルを閉じ、一時ファイルを本来のパスに置き換えます
+func (a *atomicFile) Commit() error {
+	a.done = true
+	if err := a.Close(); err != nil {
+		a.discard()
+		return err
+	}
+	if a.rename == nil {
+		return nil
+	}
+	if err := a.rename(a.tmp, a.path); err != nil {
+		a.discard()
+		return err
+	}
+	return nil
+}
+
+// Abort は Commit していなければファイルを閉じて一時ファイルを削除します(defer での呼び出しを想定)
+func (a *atomicFile) Abort() {
+	if a.done {
+		return
+	}
+	a.done = true
+	a.Close()
+	a.discard()
+}
+
+// discard は一時ファイルを削除します。直接書き込んでいる場合は何もしません。
+func (a *atomicFile) discard() {
+	if a.rename != nil && a.remove != nil {
+		a.remove(a.tmp)
+	}
+}
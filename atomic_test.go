@@ -0,0 +1,86 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+)
+
+// TestRun_AtomicOutput は -o のレポートが成功時のみ置き換えられ、失敗時は既存のレポートと一時ファイルが残らないか確認します
+func TestRun_AtomicOutput(t *testing.T) {
+	dir := t.TempDir()
+	out := filepath.Join(dir, "report.txt")
+	if err := os.WriteFile(out, []byte("previous report\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	run := func(input func(string) (io.ReadCloser, error)) int {
+		return Run(AppContext{
+			Args:        []string{"app", "-o", out, "input.log"},
+			ExecPath:    "app_WARN",
+			Stdout:      io.Discard,
+			Stderr:      io.Discard,
+			FileReader:  input,
+			FileCreator: func(path string) (io.WriteCloser, error) { return os.Create(path) },
+			Rename:      os.Rename,
+			Remove:      os.Remove,
+		})
+	}
+	assertReport := func(want string) {
+		t.Helper()
+		got, err := os.ReadFile(out)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(got), want) {
+			t.Errorf("Report = %q, want it to contain %q", got, want)
+		}
+		entries, _ := os.ReadDir(dir)
+		if len(entries) != 1 {
+			t.Errorf("Temporary files left: %v", entries)
+		}
+	}
+
+	failing := func(string) (io.ReadCloser, error) { return nil, errors.New("disk error") }
+	if code := run(failing); code != ExitError {
+		t.Fatalf("Run() exit code = %d, want %d", code, ExitError)
+	}
+	assertReport("previous report")
+
+	ok := func(string) (io.ReadCloser, error) { return io.NopCloser(strings.NewReader("WARN a\n")), nil }
+	if code := run(ok); code != ExitMatch {
+		t.Fatalf("Run() exit code = %d", code)
+	}
+	assertReport("[WARN]\n該当数: 1\n")
+}
+
+// fullDisk は書き込みのたびにディスクの空き不足を返すファイルです
+type fullDisk struct{}
+
+func (fullDisk) Write([]byte) (int, error) { return 0, syscall.ENOSPC }
+func (fullDisk) Close() error              { return nil }
+
+// TestRun_WriteErrorSalvage はレポートを書き込めない場合に、該当数を標準エラーに残して専用の終了コードを返すか確認します
+func TestRun_WriteErrorSalvage(t *testing.T) {
+	stderr := new(strings.Builder)
+	code := Run(AppContext{
+		Args:        []string{"app", "-o", "report.txt", "input.log"},
+		ExecPath:    "app_WARN_ERROR",
+		Stdout:      io.Discard,
+		Stderr:      stderr,
+		FileReader:  func(string) (io.ReadCloser, error) { return io.NopCloser(strings.NewReader("WARN a\nWARN b\n")), nil },
+		FileCreator: func(string) (io.WriteCloser, error) { return fullDisk{}, nil },
+	})
+	if code != ExitWriteError {
+		t.Fatalf("Run() exit code = %d, want %d", code, ExitWriteError)
+	}
+	for _, want := range []string{"no space left on device", "query=WARN count=2", "query=ERROR count=0"} {
+		if !strings.Contains(stderr.String(), want) {
+			t.Errorf("Stderr should contain %q.\n Stderr: %s", want, stderr.String())
+		}
+	}
+}
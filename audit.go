@@ -0,0 +1,219 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// AuditJIS2004 は -audit で指定する、JIS X 0208 の外の文字を JIS X 0213 の面・水準で分類する監査です
+const AuditJIS2004 = "jis2004"
+
+// JIS X 0208 の外の文字の分類
+const (
+	AuditPlane1  = "plane1"  // 第1面の非漢字
+	AuditLevel3  = "level3"  // 第1面の第3水準漢字
+	AuditLevel4  = "level4"  // 第2面の第4水準漢字
+	AuditOutside = "outside" // JIS X 0213 にも含まれない
+)
+
+// auditClassOrder は出力する分類の順序です
+var auditClassOrder = []string{AuditPlane1, AuditLevel3, AuditLevel4, AuditOutside}
+
+// auditLabels はテキスト形式で表示する分類の名前です
+var auditLabels = map[string]string{
+	AuditPlane1:  "第1面 非漢字",
+	AuditLevel3:  "第1面 第3水準漢字",
+	AuditLevel4:  "第2面 第4水準漢字",
+	AuditOutside: "JIS X 0213 の範囲外",
+}
+
+// jis2004Classes は JIS X 0213 の文字のうち JIS X 0208 に含まれないものの分類です(初回利用時に構築する)
+var jis2004Classes = sync.OnceValue(func() map[rune]string {
+	classes := make(map[rune]string)
+	for i, r := range sjis2004Table {
+		if r == 0 || isJIS90(r) {
+			continue
+		}
+		class := AuditPlane1
+		switch {
+		case i/0xBD >= sjis2004Plane2Row:
+			class = AuditLevel4
+		case unicode.Is(unicode.Han, r):
+			class = AuditLevel3
+		}
+		// 同じ文字に複数の符号位置がある場合は先に現れた(第1面の)ものを使う
+		if _, ok := classes[r]; !ok {
+			classes[r] = class
+		}
+	}
+	return classes
+})
+
+// jis2004PairSet は JIS X 0213 で1文字として収録されている2文字の組み合わせ(か゚ 等)です
+var jis2004PairSet = sync.OnceValue(func() map[[2]rune]bool {
+	pairs := make(map[[2]rune]bool, len(sjis2004Pairs))
+	for _, pair := range sjis2004Pairs {
+		pairs[pair] = true
+	}
+	return pairs
+})
+
+// classifyJIS2004 は文字の分類を返します。ASCII・半角カタカナ・JIS X 0208 の文字は空を返します。
+func classifyJIS2004(r rune) string {
+	if isJIS90(r) || unicode.IsControl(r) {
+		return ""
+	}
+	if class, ok := jis2004Classes()[r]; ok {
+		return class
+	}
+	return AuditOutside
+}
+
+// AuditEntry は JIS X 0208 の外の1文字の出現状況です
+type AuditEntry struct {
+	Char    string         `json:"char"`
+	Code    string         `json:"code"`
+	Class   string         `json:"class"`
+	Count   int            `json:"count"`
+	Snippet string         `json:"snippet"` // 最初の出現箇所とその前後
+	Files   []CharLocation `json:"files"`   // ファイルごとの初出位置(走査順)
+}
+
+// JIS2004Audit は複数ファイルを跨いで JIS X 0208 の外の文字を分類して集計します。
+// CharInventory と同じく、各文字はファイルごとの初出位置のみを保持します。
+type JIS2004Audit struct {
+	contextSize int
+	entries     map[string]*AuditEntry
+	lastPath    map[string]string
+}
+
+// NewJIS2004Audit は前後 contextSize 文字の文脈を記録する JIS2004Audit を生成します
+func NewJIS2004Audit(contextSize int) *JIS2004Audit {
+	return &JIS2004Audit{
+		contextSize: contextSize,
+		entries:     make(map[string]*AuditEntry),
+		lastPath:    make(map[string]string),
+	}
+}
+
+// Scan は1ファイル分の入力を走査して JIS X 0208 の外の文字を記録します
+func (a *JIS2004Audit) Scan(r io.Reader, path string) error {
+	scanner := newLineReader(r)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := scanner.Text()
+		if lineNum == 1 {
+			line = strings.TrimPrefix(line, utf8BOM)
+		}
+		runes := []rune(line)
+		for i := 0; i < len(runes); i++ {
+			c := runes[i]
+			if c < 0x80 {
+				continue
+			}
+			// 結合文字との組み合わせで収録されている文字は2文字をまとめて1文字とみなす
+			n, class := 1, ""
+			if i+1 < len(runes) && jis2004PairSet()[[2]rune{c, runes[i+1]}] {
+				n, class = 2, AuditPlane1
+			} else if class = classifyJIS2004(c); class == "" {
+				continue
+			}
+
+			char := string(runes[i : i+n])
+			entry, ok := a.entries[char]
+			if !ok {
+				from, to := snippetRange(len(runes), i, i+n, a.contextSize)
+				entry = &AuditEntry{Char: char, Code: runeCodes(char), Class: class, Snippet: string(runes[from:to])}
+				a.entries[char] = entry
+			}
+			entry.Count++
+			if a.lastPath[char] != path {
+				a.lastPath[char] = path
+				entry.Files = append(entry.Files, CharLocation{Path: path, Line: lineNum, Col: i + 1})
+			}
+			i += n - 1
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("error reading stream: %w", err)
+	}
+	return nil
+}
+
+// Entries は記録した文字を分類の順、分類内ではコードポイント順に返します
+func (a *JIS2004Audit) Entries() []*AuditEntry {
+	rank := make(map[string]int, len(auditClassOrder))
+	for i, class := range auditClassOrder {
+		rank[class] = i
+	}
+	entries := make([]*AuditEntry, 0, len(a.entries))
+	for _, e := range a.entries {
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if ri, rj := rank[entries[i].Class], rank[entries[j].Class]; ri != rj {
+			return ri < rj
+		}
+		// UTF-8 のバイト順はコードポイント順と一致する
+		return entries[i].Char < entries[j].Char
+	})
+	return entries
+}
+
+// WriteAudit は監査結果を出力します。テキスト形式では分類ごとに見出しを付け、1文字2行で
+// "  U+4FF1 俱 3件: a.txt:1:5" と最初の出現箇所の前後を出力します。
+func WriteAudit(w io.Writer, entries []*AuditEntry, format string) error {
+	if format == FormatJSON || format == FormatJSONL {
+		enc := json.NewEncoder(w)
+		if format == FormatJSON {
+			enc.SetIndent("", "  ")
+			if entries == nil {
+				entries = []*AuditEntry{}
+			}
+			return enc.Encode(struct {
+				Schema string        `json:"schema"`
+				Audit  string        `json:"audit"`
+				Chars  []*AuditEntry `json:"chars"`
+			}{SchemaVersion, AuditJIS2004, entries})
+		}
+		for _, e := range entries {
+			if err := enc.Encode(e); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	ew := &errorWriter{w: w}
+	for _, class := range auditClassOrder {
+		var kinds, total int
+		for _, e := range entries {
+			if e.Class == class {
+				kinds++
+				total += e.Count
+			}
+		}
+		if kinds == 0 {
+			continue
+		}
+		fmt.Fprintf(ew, "%s: %d種 %d件\n", auditLabels[class], kinds, total)
+		for _, e := range entries {
+			if e.Class != class {
+				continue
+			}
+			locs := make([]string, len(e.Files))
+			for i, loc := range e.Files {
+				locs[i] = fmt.Sprintf("%s:%d:%d", loc.Path, loc.Line, loc.Col)
+			}
+			fmt.Fprintf(ew, "  %s %s %d件: %s\n", e.Code, e.Char, e.Count, strings.Join(locs, ", "))
+			fmt.Fprintf(ew, "    %s\n", EscapeSnippet(e.Snippet, EscapeNonPrintable))
+		}
+	}
+	return ew.err
+}
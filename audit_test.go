@@ -0,0 +1,57 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestClassifyJIS2004(t *testing.T) {
+	tests := []struct {
+		r    rune
+		want string
+	}{
+		{'あ', ""},
+		{'ｱ', ""},
+		{'A', ""},
+		{'①', AuditPlane1},
+		{'俱', AuditLevel3},
+		{'鷗', AuditLevel3},
+		{'丂', AuditLevel4},
+		{'𠂉', AuditLevel4},
+		{'髙', AuditOutside}, // IBM拡張文字。JIS X 0213 には含まれない
+		{'😀', AuditOutside},
+	}
+	for _, tt := range tests {
+		if got := classifyJIS2004(tt.r); got != tt.want {
+			t.Errorf("classifyJIS2004(%q) = %q, want %q", tt.r, got, tt.want)
+		}
+	}
+}
+
+func TestJIS2004Audit(t *testing.T) {
+	a := NewJIS2004Audit(2)
+	if err := a.Scan(strings.NewReader("森鷗外と丂\nか゚の髙橋\n"), "a.txt"); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.Scan(strings.NewReader("鷗\n"), "b.txt"); err != nil {
+		t.Fatal(err)
+	}
+
+	entries := a.Entries()
+	var got []string
+	for _, e := range entries {
+		got = append(got, e.Class+" "+e.Char)
+	}
+	want := []string{"plane1 か゚", "level3 鷗", "level4 丂", "outside 髙"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("Entries() = %v, want %v", got, want)
+	}
+
+	ogai := entries[1]
+	if ogai.Count != 2 || ogai.Snippet != "森鷗外と" || len(ogai.Files) != 2 || ogai.Files[0] != (CharLocation{"a.txt", 1, 2}) {
+		t.Errorf("鷗 = %+v", ogai)
+	}
+	if entries[0].Code != "U+304B U+309A" {
+		t.Errorf("か゚ Code = %q", entries[0].Code)
+	}
+}
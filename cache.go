@@ -0,0 +1,82 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+// ResultCache は検索結果を (ファイルのハッシュ, クエリ集合, オプション) をキーとして保存します。
+// 夜間バッチで変更のない多数のファイルを再走査する負荷を避けるために使います。
+type ResultCache struct {
+	Dir    string
+	Open   func(string) (io.ReadCloser, error)
+	Create func(string) (io.WriteCloser, error)
+	Rename func(string, string) error // 一時ファイルを置き換える(nil なら直接書き込む)
+	Remove func(string) error
+}
+
+// CacheKey はキャッシュのキーを生成します
+func CacheKey(fileHash string, queries []string, opts SearchOptions) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "file=%s\x00", fileHash)
+	fmt.Fprintf(h, "queries=%s\x00", strings.Join(queries, "\x1f"))
+	fmt.Fprintf(h, "options=%s\x00", opts.cacheKey())
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// cacheKey は結果に影響するオプションを文字列化します。
+// SearchOptions に結果を変える項目を追加した場合はここにも追加すること。
+func (o SearchOptions) cacheKey() string {
+	compare := ""
+	if o.CompareEncoding != nil {
+		compare = EncodingName(o.CompareEncoding)
+	}
+	variants := ""
+	if o.Variants != nil {
+		variants = o.Variants.String()
+	}
+	return fmt.Sprintf("context=%d fold=%t sample=%d redact=%d escape=%d raw=%t align=%s unit=%s/%d compare=%s variants=%s romaji=%t anchor=%s filters=%s cooccur=%t enc=%s combine=%t suppress=%s icase=%t normalize=%s unicode=%s extended=%t whole=%t maxtext=%d count=%t maxsnippet=%d snippets=%d foldwidth=%t foldkana=%t growth=%t ivs=%t",
+		o.ContextSize, o.FoldDuplicates, o.SampleEvery, o.RedactMask, o.Escape, o.KeepRaw, o.ContextAlign, o.ContextUnit, o.SentenceMax, compare, variants, o.Romaji, o.Anchor, strings.Join(o.Filters, ","), o.CoOccurrence, o.InputEncoding, o.CombineLines, o.SuppressMarker, o.IgnoreCase, o.Normalize, o.UnicodeVersion, o.Extended, o.WholeText, o.MaxTextBytes, o.CountOnly, o.MaxSnippetBytes, o.MaxSnippets, o.FoldWidth, o.FoldKana, o.AllowGrowth, o.IgnoreIVS)
+}
+
+// path はキーに対応するキャッシュファイルのパスを返します
+func (c *ResultCache) path(key string) string {
+	return filepath.Join(c.Dir, key+".json")
+}
+
+// Load はキャッシュされた結果を返します。存在しないか読めない場合は false を返します。
+func (c *ResultCache) Load(key string) (map[string]*SearchResult, bool) {
+	f, err := c.Open(c.path(key))
+	if err != nil {
+		return nil, false
+	}
+	defer f.Close()
+
+	var results map[string]*SearchResult
+	if err := json.NewDecoder(f).Decode(&results); err != nil {
+		return nil, false
+	}
+	return results, true
+}
+
+// Store は結果をキャッシュに保存します。並行して読む実行が書きかけの結果を使わないよう、
+// 一時ファイルに書き出してから置き換えます。
+func (c *ResultCache) Store(key string, results map[string]*SearchResult) error {
+	f, err := createAtomic(AppContext{FileCreator: c.Create, Rename: c.Rename, Remove: c.Remove}, c.path(key))
+	if err != nil {
+		return fmt.Errorf("failed to create cache file: %w", err)
+	}
+	defer f.Abort()
+	if err := json.NewEncoder(f).Encode(results); err != nil {
+		return fmt.Errorf("failed to write cache file: %w", err)
+	}
+	if err := f.Commit(); err != nil {
+		return fmt.Errorf("failed to write cache file: %w", err)
+	}
+	return nil
+}
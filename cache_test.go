@@ -0,0 +1,89 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+// TestRun_Cache は2回目の実行でキャッシュされた結果が使われるか確認します
+func TestRun_Cache(t *testing.T) {
+	files := map[string]*bytes.Buffer{
+		"in.txt": bytes.NewBufferString("WARN a\nWARN b\n"),
+	}
+	opened := 0
+	ctx := AppContext{
+		Args:     []string{"app", "-cache", "cache", "in.txt"},
+		ExecPath: "app_WARN",
+		Stderr:   io.Discard,
+		FileReader: func(path string) (io.ReadCloser, error) {
+			if path == "in.txt" {
+				opened++
+			}
+			buf, ok := files[path]
+			if !ok {
+				return nil, os.ErrNotExist
+			}
+			return io.NopCloser(bytes.NewReader(buf.Bytes())), nil
+		},
+		FileCreator: func(path string) (io.WriteCloser, error) {
+			files[path] = new(bytes.Buffer)
+			return nopWriteCloser{files[path]}, nil
+		},
+		Rename: func(from, to string) error {
+			files[to] = files[from]
+			delete(files, from)
+			return nil
+		},
+	}
+
+	first := new(bytes.Buffer)
+	ctx.Stdout = first
+	if code := Run(ctx); code != 0 {
+		t.Fatalf("First Run() exit code = %d", code)
+	}
+	if opened != 2 {
+		t.Errorf("First run should hash and scan the input. opened %d times", opened)
+	}
+	// キャッシュは一時ファイルに書き出してから置き換える
+	for path := range files {
+		if strings.HasSuffix(path, ".tmp") {
+			t.Errorf("Temporary cache file left behind: %s", path)
+		}
+	}
+
+	opened = 0
+	second := new(bytes.Buffer)
+	ctx.Stdout = second
+	if code := Run(ctx); code != 0 {
+		t.Fatalf("Second Run() exit code = %d", code)
+	}
+	if opened != 1 {
+		t.Errorf("Second run should only hash the input. opened %d times", opened)
+	}
+	if first.String() != second.String() || !strings.Contains(second.String(), "該当数: 2") {
+		t.Errorf("Cached output mismatch.\n first:  %s\n second: %s", first.String(), second.String())
+	}
+}
+
+// TestCacheKey_CompareEncoding は比較先の文字コードを名前で区別し、別名でも同じキーになるか確認します
+func TestCacheKey_CompareEncoding(t *testing.T) {
+	key := func(name string) string {
+		enc, err := LookupEncoding(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return CacheKey("hash", []string{"WARN"}, SearchOptions{CompareEncoding: enc})
+	}
+	if key("sjis") != key("cp932") {
+		t.Error("Aliases of the same encoding should share a cache key")
+	}
+	if key("sjis") == key("euc-jp") {
+		t.Error("Different compare encodings should not share a cache key")
+	}
+	if key("sjis") == CacheKey("hash", []string{"WARN"}, SearchOptions{}) {
+		t.Error("A compare encoding should change the cache key")
+	}
+}
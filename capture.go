@@ -0,0 +1,130 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// RegexpQueryPrefix で始まるクエリは正規表現として照合します(例: "re:user=(?P<user>\w+)")
+const RegexpQueryPrefix = "re:"
+
+// MaxCaptureValues はキャプチャグループごとに個別に数える値の種類の上限です。
+// 上限を超えた新しい値は OtherCaptureValue にまとめて数えます。
+const MaxCaptureValues = 10000
+
+// OtherCaptureValue は上限を超えた値をまとめて数えるキーです
+const OtherCaptureValue = "(その他)"
+
+// MaxCaptureDisplay はテキスト出力で表示する値の種類の上限です
+const MaxCaptureDisplay = 10
+
+// ValidateQueries は正規表現クエリ・文字の種類のクエリ・コードポイントの範囲クエリが解釈できるかを確認します。
+// extended が真(-E)なら、文字の種類・範囲のクエリ以外はすべて正規表現として確認します。
+// 1文字ずつ照合する文字の種類・範囲のクエリは、anchor (-anchor)による位置の固定を指定できません。
+func ValidateQueries(queries []string, extended bool, anchor string) error {
+	for _, q := range queries {
+		_, class, err := lookupCharClass(q, "")
+		if err != nil {
+			return err
+		}
+		_, _, isRange, err := parseRuneRange(q)
+		if err != nil {
+			return err
+		}
+		if anchor != "" && (class || isRange) {
+			return fmt.Errorf("-anchor cannot be used with character class or range query %q", q)
+		}
+		if expr, ok := regexpQuery(q, extended); ok {
+			if _, err := compileQueryRegexp(expr); err != nil {
+				return fmt.Errorf("invalid regexp query %q: %w", q, err)
+			}
+		}
+	}
+	return nil
+}
+
+// regexpQuery はクエリが正規表現であれば、その式を返します。
+// RegexpQueryPrefix で始まるクエリのほか、extended が真なら文字の種類・範囲・個人情報のクエリ以外を正規表現とみなします。
+func regexpQuery(query string, extended bool) (string, bool) {
+	if expr, ok := strings.CutPrefix(query, RegexpQueryPrefix); ok {
+		return expr, true
+	}
+	if !extended || strings.HasPrefix(query, CharClassPrefix) || piiPatterns[query] != nil {
+		return "", false
+	}
+	if _, _, ok, _ := parseRuneRange(query); ok {
+		return "", false
+	}
+	return query, true
+}
+
+// compileQueryRegexp は \p{JIS3} 等の独自の文字の種類を書き換えてから正規表現を解釈します
+func compileQueryRegexp(expr string) (*regexp.Regexp, error) {
+	expanded, err := expandJISClasses(expr)
+	if err != nil {
+		return nil, err
+	}
+	return regexp.Compile(expanded)
+}
+
+// recordCaptures は行内の最初のヒットで名前付きグループが捕捉した値を数えます
+func recordCaptures(res *SearchResult, re *regexp.Regexp, line string) {
+	match := re.FindStringSubmatch(line)
+	if match == nil {
+		return
+	}
+	for i, name := range re.SubexpNames() {
+		if name == "" || i >= len(match) {
+			continue
+		}
+		addCapture(res, name, match[i], 1)
+	}
+}
+
+// addCapture は捕捉値の件数を加算します
+func addCapture(res *SearchResult, name, value string, n int) {
+	if res.Captures == nil {
+		res.Captures = make(map[string]map[string]int)
+	}
+	values := res.Captures[name]
+	if values == nil {
+		values = make(map[string]int)
+		res.Captures[name] = values
+	}
+	if _, ok := values[value]; !ok && len(values) >= MaxCaptureValues {
+		value = OtherCaptureValue
+	}
+	values[value] += n
+}
+
+// hasNamedGroups は正規表現が名前付きグループを含むかを返します
+func hasNamedGroups(re *regexp.Regexp) bool {
+	for _, name := range re.SubexpNames() {
+		if name != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// captureNames はキャプチャグループ名を名前順に返します
+func captureNames(captures map[string]map[string]int) []string {
+	names := make([]string, 0, len(captures))
+	for name := range captures {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// formatCaptures は捕捉値を件数の多い順に上位 MaxCaptureDisplay 件まで "alice=3, bob=1 (2種)" の形式で返します
+func formatCaptures(values map[string]int) string {
+	s := formatVariants(values)
+	if len(values) > MaxCaptureDisplay {
+		parts := strings.SplitN(s, ", ", MaxCaptureDisplay+1)
+		s = strings.Join(parts[:MaxCaptureDisplay], ", ") + ", …"
+	}
+	return fmt.Sprintf("%s (%d種)", s, len(values))
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// TestRegexpCaptures は名前付きグループの捕捉値が集計・出力されるか確認します
+func TestRegexpCaptures(t *testing.T) {
+	input := "user=alice 髙\nuser=bob 髙\nuser=alice 髙\nuser=carol ok\n"
+	query := `re:user=(?P<user>\w+) 髙`
+
+	results, err := SearchStreamWithOptions(strings.NewReader(input), []string{query}, SearchOptions{ContextSize: 2})
+	if err != nil {
+		t.Fatalf("SearchStreamWithOptions() error = %v", err)
+	}
+	res := results[query]
+	if res.Count != 3 {
+		t.Errorf("Count = %d, want 3", res.Count)
+	}
+	if got := res.Captures["user"]; got["alice"] != 2 || got["bob"] != 1 || len(got) != 2 {
+		t.Errorf("Captures = %v, want alice=2 bob=1", got)
+	}
+
+	out := new(bytes.Buffer)
+	WriteResults(out, results, []string{query})
+	if !strings.Contains(out.String(), "キャプチャ(user): alice=2, bob=1 (2種)\n") {
+		t.Errorf("Output missing capture summary:\n%s", out.String())
+	}
+
+	// ファイルを跨いだ統合でも件数が加算される
+	merged := NewResults([]string{query})
+	MergeResults(merged, results, "a.log", 0)
+	MergeResults(merged, results, "b.log", 0)
+	if got := merged[query].Captures["user"]["alice"]; got != 4 {
+		t.Errorf("Merged alice = %d, want 4", got)
+	}
+
+	if err := ValidateQueries([]string{"re:(?P<x>"}, false, ""); err == nil {
+		t.Error("ValidateQueries() should reject invalid expressions")
+	}
+}
@@ -0,0 +1,229 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+
+	"golang.org/x/text/encoding"
+)
+
+// CheckQueriesCommand はクエリの一覧を走査せずに検査するサブコマンドの名前です
+const CheckQueriesCommand = "check-queries"
+
+// check-queries が報告する問題の種類
+const (
+	IssueDuplicate       = "duplicate"       // 同じクエリが複数回指定されている
+	IssueInvalid         = "invalid"         // 未知の文字の種類・不正な範囲・正規表現の誤り
+	IssueUnrepresentable = "unrepresentable" // 入力の文字コードで表現できない文字を含む(ヒットし得ない)
+	IssueOverlap         = "overlap"         // コードポイントの範囲が他の範囲と重なる
+)
+
+// issueLabels はテキスト形式で表示する問題の種類の名前です
+var issueLabels = map[string]string{
+	IssueDuplicate:       "重複",
+	IssueInvalid:         "不正",
+	IssueUnrepresentable: "表現不可",
+	IssueOverlap:         "範囲の重複",
+}
+
+// QuerySource は検査するクエリと、その指定元(ファイル名・-q 等)です
+type QuerySource struct {
+	Query  string
+	Source string
+}
+
+// QueryIssue は1つのクエリの問題です
+type QueryIssue struct {
+	Query  string `json:"query"`
+	Kind   string `json:"kind"`
+	Detail string `json:"detail"`
+}
+
+// CheckQueries はクエリの一覧の問題を指定順に返します。
+// enc が nil でなければ、文字列のクエリがその文字コードで表現できない文字を含むかも確認します。
+func CheckQueries(queries []QuerySource, enc encoding.Encoding, extended bool) []QueryIssue {
+	var issues []QueryIssue
+
+	sources := make(map[string][]string)
+	var order []string
+	for _, q := range queries {
+		if _, seen := sources[q.Query]; !seen {
+			order = append(order, q.Query)
+		}
+		sources[q.Query] = append(sources[q.Query], q.Source)
+	}
+
+	type runeRange struct {
+		query  string
+		lo, hi rune
+	}
+	var ranges []runeRange
+	for _, q := range order {
+		if len(sources[q]) > 1 {
+			issues = append(issues, QueryIssue{Query: q, Kind: IssueDuplicate, Detail: strings.Join(sources[q], ", ")})
+		}
+		if err := ValidateQueries([]string{q}, extended, ""); err != nil {
+			issues = append(issues, QueryIssue{Query: q, Kind: IssueInvalid, Detail: err.Error()})
+			continue
+		}
+		if lo, hi, ok, _ := parseRuneRange(q); ok {
+			for _, r := range ranges {
+				if lo <= r.hi && r.lo <= hi {
+					issues = append(issues, QueryIssue{Query: q, Kind: IssueOverlap, Detail: r.query})
+				}
+			}
+			ranges = append(ranges, runeRange{q, lo, hi})
+			continue
+		}
+		if enc != nil && literalQuery(q, extended) {
+			if chars := unrepresentableChars(q, enc); chars != "" {
+				issues = append(issues, QueryIssue{Query: q, Kind: IssueUnrepresentable, Detail: chars})
+			}
+		}
+	}
+	return issues
+}
+
+// literalQuery はクエリが文字列として照合されるか(文字の種類・範囲・正規表現・プリセットでないか)を返します
+func literalQuery(q string, extended bool) bool {
+	if strings.HasPrefix(q, CharClassPrefix) || piiPatterns[q] != nil || queryPresets[q] != nil {
+		return false
+	}
+	_, isRegexp := regexpQuery(q, extended)
+	return !isRegexp
+}
+
+// unrepresentableChars は enc で表現できない文字を "鷗 U+9DD7" の形式で並べて返します(なければ空)
+func unrepresentableChars(q string, enc encoding.Encoding) string {
+	encoder := enc.NewEncoder()
+	var chars []string
+	seen := make(map[rune]bool)
+	for _, c := range q {
+		if seen[c] {
+			continue
+		}
+		seen[c] = true
+		if _, err := encoder.String(string(c)); err != nil {
+			chars = append(chars, fmt.Sprintf("%s %s", EscapeSnippet(string(c), EscapeNonPrintable), runeCodes(string(c))))
+		}
+	}
+	return strings.Join(chars, ", ")
+}
+
+// WriteQueryIssues は検査結果を出力します。テキスト形式では1件1行で "重複: 髙橋 (q.txt, -q)" のように出力します。
+func WriteQueryIssues(w io.Writer, issues []QueryIssue, format string) error {
+	if format == FormatJSON || format == FormatJSONL {
+		enc := json.NewEncoder(w)
+		if format == FormatJSON {
+			enc.SetIndent("", "  ")
+			if issues == nil {
+				issues = []QueryIssue{}
+			}
+			return enc.Encode(struct {
+				Schema string       `json:"schema"`
+				Issues []QueryIssue `json:"issues"`
+			}{SchemaVersion, issues})
+		}
+		for _, issue := range issues {
+			if err := enc.Encode(issue); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	for _, issue := range issues {
+		detail := issue.Detail
+		if issue.Kind == IssueOverlap {
+			detail += " と重なります"
+		}
+		if _, err := fmt.Fprintf(w, "%s: %s (%s)\n", issueLabels[issue.Kind], EscapeSnippet(issue.Query, EscapeNonPrintable), detail); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runCheckQueries は check-queries サブコマンドを実行します。
+// 引数のクエリファイル・-config・-q・実行ファイル名のクエリを走査せずに検査し、問題があれば ExitRuleViolation を返します。
+func runCheckQueries(ctx AppContext, logger *slog.Logger, args []string) int {
+	fs := flag.NewFlagSet(CheckQueriesCommand, flag.ContinueOnError)
+	configFile := fs.String("config", "", "Config file whose queries are checked as well")
+	var flagQueries queryList
+	fs.Var(&flagQueries, "q", "Query to check; may be repeated")
+	inputEnc := fs.String("enc", "", "Input encoding; report literal queries with characters it cannot represent (sjis|eucjp|...)")
+	extended := fs.Bool("E", false, "Check queries as regular expressions, as with -E when searching")
+	format := fs.String("format", FormatText, "Output format (text|json|jsonl)")
+	if err := fs.Parse(args); err != nil {
+		return ExitError
+	}
+
+	var enc encoding.Encoding
+	if *inputEnc != "" && *inputEnc != encodingUTF8 && *inputEnc != EncodingAuto {
+		var err error
+		if enc, err = LookupEncoding(*inputEnc); err != nil {
+			logger.Error("Invalid input encoding", "code", CodeInvalidOption, "error", err)
+			return ExitError
+		}
+	}
+
+	var queries []QuerySource
+	if names, err := execNameQueries(ctx.ExecPath); err == nil {
+		for _, q := range names {
+			queries = append(queries, QuerySource{q, "executable name"})
+		}
+	}
+	if *configFile != "" {
+		cf, err := ctx.FileReader(*configFile)
+		if err != nil {
+			logger.Error("Failed to open config file", "code", CodeOpenFailed, "path", *configFile, "error", err)
+			return ExitError
+		}
+		loaded, err := LoadConfigFile(cf)
+		cf.Close()
+		if err != nil {
+			logger.Error("Invalid config file", "code", CodeInvalidConfig, "path", *configFile, "error", err)
+			return ExitError
+		}
+		for _, q := range loaded.Queries {
+			queries = append(queries, QuerySource{q, *configFile})
+		}
+	}
+	for _, path := range fs.Args() {
+		qf, err := ctx.FileReader(path)
+		if err != nil {
+			logger.Error("Failed to open query file", "code", CodeOpenFailed, "path", path, "error", err)
+			return ExitError
+		}
+		listed, err := LoadQueryFile(qf)
+		qf.Close()
+		if err != nil {
+			logger.Error("Invalid query file", "code", CodeInvalidQuery, "path", path, "error", err)
+			return ExitError
+		}
+		for _, q := range listed {
+			queries = append(queries, QuerySource{q, path})
+		}
+	}
+	for _, q := range flagQueries {
+		queries = append(queries, QuerySource{q, "-q"})
+	}
+	if len(queries) == 0 {
+		logger.Error("No queries to check; give query files, -config or -q", "code", CodeUsage)
+		return ExitError
+	}
+
+	issues := CheckQueries(queries, enc, *extended)
+	if err := WriteQueryIssues(ctx.Stdout, issues, *format); err != nil {
+		logger.Error("Failed to write results", "code", CodeWriteFailed, "error", err)
+		return ExitWriteError
+	}
+	if len(issues) > 0 {
+		return ExitRuleViolation
+	}
+	return 0
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"golang.org/x/text/encoding/japanese"
+)
+
+// TestCheckQueries は重複・不正・表現不可・範囲の重複を指定順に報告するか確認します
+func TestCheckQueries(t *testing.T) {
+	queries := []QuerySource{
+		{"森鷗外", "q.txt"},
+		{"U+3400..U+4DBF", "q.txt"},
+		{"@class:nosuch", "q.txt"},
+		{"re:[", "q.txt"},
+		{"U+4D00..U+4E10", "q.txt"},
+		{"re:鷗", "q.txt"},
+		{"森鷗外", "-q"},
+	}
+	got := CheckQueries(queries, japanese.ShiftJIS, false)
+	var kinds []string
+	for _, issue := range got {
+		kinds = append(kinds, issue.Query+" "+issue.Kind)
+	}
+	want := []string{
+		"森鷗外 duplicate",
+		"森鷗外 unrepresentable",
+		"@class:nosuch invalid",
+		"re:[ invalid",
+		"U+4D00..U+4E10 overlap",
+	}
+	if !reflect.DeepEqual(kinds, want) {
+		t.Errorf("CheckQueries() = %q, want %q", kinds, want)
+	}
+	if got[0].Detail != "q.txt, -q" || got[1].Detail != "鷗 U+9DD7" || got[4].Detail != "U+3400..U+4DBF" {
+		t.Errorf("Details = %q, %q, %q", got[0].Detail, got[1].Detail, got[4].Detail)
+	}
+}
+
+// TestRun_CheckQueries は check-queries サブコマンドが問題の有無を終了コードで返すか確認します
+func TestRun_CheckQueries(t *testing.T) {
+	files := map[string]string{
+		"ok.txt":  "森鷗外\nU+3400..U+4DBF\n",
+		"dup.txt": "ERROR\nWARN\n",
+	}
+	run := func(args ...string) (string, int) {
+		stdout := new(bytes.Buffer)
+		code := Run(AppContext{
+			Args:     append([]string{"app", "check-queries"}, args...),
+			ExecPath: "app_WARN",
+			Stdout:   stdout,
+			Stderr:   io.Discard,
+			FileReader: func(path string) (io.ReadCloser, error) {
+				return io.NopCloser(strings.NewReader(files[path])), nil
+			},
+		})
+		return stdout.String(), code
+	}
+
+	if out, code := run("-enc", "sjis2004", "ok.txt"); code != 0 || out != "" {
+		t.Errorf("Clean queries: code %d, output %q", code, out)
+	}
+	out, code := run("-enc", "sjis", "ok.txt", "dup.txt")
+	want := "重複: WARN (executable name, dup.txt)\n表現不可: 森鷗外 (鷗 U+9DD7)\n"
+	if code != ExitRuleViolation || out != want {
+		t.Errorf("Issues: code %d\nGot:\n%s\nWant:\n%s", code, out, want)
+	}
+}
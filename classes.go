@@ -0,0 +1,175 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/japanese"
+)
+
+// CharClassPrefix は文字の種類で照合するクエリの接頭辞です(例: "@class:pua")
+const CharClassPrefix = "@class:"
+
+// charClasses はポリシーやクエリで指定できる文字の種類です
+var charClasses = map[string]func(rune) bool{
+	"halfwidth-kana": isHalfwidthKana,
+	"ivs":            isIVS,
+	"jis2004-glyph":  isJIS2004GlyphChange,
+	"non-bmp":        isNonBMP,
+	"non-jis90":      func(r rune) bool { return !isJIS90(r) },
+	"pua":            func(r rune) bool { return unicode.Is(unicode.Co, r) },
+}
+
+// versionedClasses はUnicodeの版(-unicode-version)によって判定が変わる文字の種類です
+var versionedClasses = map[string]func(version string) func(rune) bool{
+	"unassigned": func(version string) func(rune) bool {
+		return func(r rune) bool { return !assignedIn(version, r) }
+	},
+}
+
+// charClassNames は利用可能な文字の種類を名前順に返します
+func charClassNames() []string {
+	names := make([]string, 0, len(charClasses)+len(versionedClasses))
+	for name := range charClasses {
+		names = append(names, name)
+	}
+	for name := range versionedClasses {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// lookupCharClass は "@class:名前" 形式のクエリに対応する判定関数を返します。
+// version はUnicodeの版で、空なら DefaultUnicodeVersion で判定します。
+// 文字の種類のクエリでなければ ok は false です。
+func lookupCharClass(query, version string) (pred func(rune) bool, ok bool, err error) {
+	name, ok := strings.CutPrefix(query, CharClassPrefix)
+	if !ok {
+		return nil, false, nil
+	}
+	if newPred, found := versionedClasses[name]; found {
+		if version == "" {
+			version = DefaultUnicodeVersion
+		}
+		return newPred(version), true, nil
+	}
+	pred, found := charClasses[name]
+	if !found {
+		return nil, true, fmt.Errorf("unknown character class: %s (available: %s)", name, strings.Join(charClassNames(), ", "))
+	}
+	return pred, true, nil
+}
+
+// RuneRangeSeparator はコードポイントの範囲クエリ(例: "U+3400..U+4DBF")の区切りです
+const RuneRangeSeparator = ".."
+
+// parseRuneRange は "U+3400..U+4DBF" 形式のクエリをコードポイントの範囲として解釈します。
+// 範囲のクエリでなければ ok は false です。
+func parseRuneRange(query string) (lo, hi rune, ok bool, err error) {
+	from, to, found := strings.Cut(query, RuneRangeSeparator)
+	if !found || !hasCodepointPrefix(from) || !hasCodepointPrefix(to) {
+		return 0, 0, false, nil
+	}
+	if lo, err = parseCodepoint(from); err == nil {
+		hi, err = parseCodepoint(to)
+	}
+	if err != nil {
+		return 0, 0, true, fmt.Errorf("invalid codepoint range %q: %w", query, err)
+	}
+	if lo > hi {
+		return 0, 0, true, fmt.Errorf("invalid codepoint range %q: start is after end", query)
+	}
+	return lo, hi, true, nil
+}
+
+// hasCodepointPrefix は s が "U+" で始まるかを返します(大文字・小文字は区別しない)
+func hasCodepointPrefix(s string) bool {
+	return len(s) > 2 && strings.EqualFold(s[:2], "U+")
+}
+
+// parseCodepoint は "U+4E00" 形式のコードポイントを解釈します
+func parseCodepoint(s string) (rune, error) {
+	n, err := strconv.ParseUint(s[2:], 16, 32)
+	if err != nil || n > unicode.MaxRune {
+		return 0, fmt.Errorf("invalid codepoint %s", s)
+	}
+	return rune(n), nil
+}
+
+// isHalfwidthKana は半角カタカナ(句読点・濁点を含む)であるかを返します
+func isHalfwidthKana(r rune) bool {
+	return r >= 0xFF61 && r <= 0xFF9F
+}
+
+// isIVS は異体字セレクタ(IVS, U+E0100〜U+E01EF)であるかを返します
+func isIVS(r rune) bool {
+	return r >= 0xE0100 && r <= 0xE01EF
+}
+
+// jis90Alternates はJIS X 0208の同じ区点に対し、変換表によって異なるコードポイントが割り当てられる文字です。
+// 復号表(WHATWG準拠)の割り当てに加えて、JISの規格票どおりの割り当ても許容します。
+var jis90Alternates = []rune{'〜', '‖', '−', '¢', '£', '¬'}
+
+// jis90Set はJIS X 0208:1990に収録された文字の集合です(初回利用時に構築する)
+var jis90Set = sync.OnceValue(func() map[rune]bool {
+	set := make(map[rune]bool, 7000)
+	dec := japanese.EUCJP.NewDecoder()
+	// 非漢字(1〜8区)と第1・第2水準漢字(16〜84区)。13区のNEC特殊文字や89区以降のIBM拡張文字は含めない
+	for row := 1; row <= 84; row++ {
+		if row > 8 && row < 16 {
+			continue
+		}
+		for cell := 1; cell <= 94; cell++ {
+			s, err := dec.Bytes([]byte{byte(0xA0 + row), byte(0xA0 + cell)})
+			if err != nil {
+				continue
+			}
+			if r, _ := utf8.DecodeRune(s); r != utf8.RuneError {
+				set[r] = true
+			}
+		}
+	}
+	for _, r := range jis90Alternates {
+		set[r] = true
+	}
+	return set
+})
+
+// isJIS90 はASCII、JIS X 0201の片仮名、JIS X 0208:1990のいずれかに含まれる文字であるかを返します。
+// 異体字セレクタは直前の文字に付随するものとして含めます(ivs で別に判定できる)。
+func isJIS90(r rune) bool {
+	if r < utf8.RuneSelf || isHalfwidthKana(r) || unicode.Is(unicode.Variation_Selector, r) {
+		return true
+	}
+	return jis90Set()[r]
+}
+
+// classMatcher は指定の種類の文字に1文字ずつ一致します
+type classMatcher func(rune) bool
+
+func (m classMatcher) find(line string) []int {
+	for i, r := range line {
+		if m(r) {
+			return []int{i, i + utf8.RuneLen(r)}
+		}
+	}
+	return nil
+}
+
+func (m classMatcher) findAll(line string) [][]int {
+	var locs [][]int
+	for i, r := range line {
+		if m(r) {
+			locs = append(locs, []int{i, i + utf8.RuneLen(r)})
+		}
+	}
+	return locs
+}
+
+func (classMatcher) alwaysRedact() bool { return false }
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestCharClasses は文字の種類の判定を確認します
+func TestCharClasses(t *testing.T) {
+	tests := []struct {
+		class string
+		r     rune
+		want  bool
+	}{
+		{"non-jis90", '亜', false},
+		{"non-jis90", '〜', false},
+		{"non-jis90", 'ｱ', false},
+		{"non-jis90", '髙', true}, // IBM拡張文字
+		{"non-jis90", '①', true}, // NEC特殊文字
+		{"non-jis90", '俱', true}, // JIS X 0213で追加
+		{"non-jis90", '𠮷', true},
+		{"pua", '\uE000', true},
+		{"pua", '亜', false},
+		{"halfwidth-kana", 'ｶ', true},
+		{"halfwidth-kana", 'カ', false},
+		{"ivs", '\U000E0100', true},
+		{"ivs", '\uFE00', false},
+		{"jis2004-glyph", '辻', true},
+		{"jis2004-glyph", '葛', true},
+		{"jis2004-glyph", '亜', false},
+		{"non-bmp", '𠮟', true},
+		{"non-bmp", '叱', false},
+	}
+	for _, tt := range tests {
+		pred, ok, err := lookupCharClass(CharClassPrefix+tt.class, "")
+		if !ok || err != nil {
+			t.Fatalf("lookupCharClass(%q) = %v, %v", tt.class, ok, err)
+		}
+		if got := pred(tt.r); got != tt.want {
+			t.Errorf("%s(%q) = %v, want %v", tt.class, tt.r, got, tt.want)
+		}
+	}
+
+	if err := ValidateQueries([]string{"@class:nope"}, false, ""); err == nil {
+		t.Error("ValidateQueries() should reject unknown character classes")
+	}
+}
+
+// TestSearchCharClass は文字の種類のクエリでヒットとスニペットが得られるか確認します
+func TestSearchCharClass(t *testing.T) {
+	input := "渡邊\n髙橋 様\nｶﾅ\n"
+	results, err := SearchStreamWithOptions(strings.NewReader(input), []string{"@class:non-jis90"}, SearchOptions{ContextSize: 1})
+	if err != nil {
+		t.Fatalf("SearchStreamWithOptions() error = %v", err)
+	}
+	res := results["@class:non-jis90"]
+	if res.Count != 1 || res.Snippets[0] != "髙橋" {
+		t.Errorf("Result = %d %q, want 1 hit on 髙", res.Count, res.Snippets)
+	}
+}
+
+// TestRuneRangeQuery はコードポイントの範囲クエリが範囲内の文字に1文字ずつ一致するか確認します
+func TestRuneRangeQuery(t *testing.T) {
+	query := "U+3400..U+4DBF"
+	results, err := SearchStreamWithOptions(strings.NewReader("㐀と䶿\n亜\n"), []string{query}, SearchOptions{ContextSize: 0})
+	if err != nil {
+		t.Fatalf("SearchStreamWithOptions() error = %v", err)
+	}
+	if res := results[query]; res.Count != 1 || res.Snippets[0] != "㐀" {
+		t.Errorf("Result = %d %q, want 1 hit on 㐀", res.Count, res.Snippets)
+	}
+
+	if lo, hi, ok, err := parseRuneRange("u+20000..U+2a6df"); !ok || err != nil || lo != 0x20000 || hi != 0x2A6DF {
+		t.Errorf("parseRuneRange() = %X, %X, %v, %v", lo, hi, ok, err)
+	}
+	if _, _, ok, _ := parseRuneRange("1..2"); ok {
+		t.Error("parseRuneRange() should ignore queries without U+")
+	}
+	for _, bad := range []string{"U+4DBF..U+3400", "U+XYZ..U+4DBF", "U+0..U+110000"} {
+		if err := ValidateQueries([]string{bad}, false, ""); err == nil {
+			t.Errorf("ValidateQueries(%q) should fail", bad)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+// -color の指定値
+const (
+	ColorAuto   = "auto"   // 標準出力が端末で、-o を指定していない場合のみ色を付ける
+	ColorAlways = "always" // 出力先によらず色を付ける
+	ColorNever  = "never"
+)
+
+// ANSIエスケープシーケンスによるヒット部分の強調(grep と同じ太字の赤)
+const (
+	highlightStart = "\x1b[1;31m"
+	highlightEnd   = "\x1b[0m"
+)
+
+// ColorEnabled は -color の指定と出力先からヒット部分に色を付けるかを返します。
+// auto の場合、環境変数 NO_COLOR が設定されていれば色を付けません。
+func ColorEnabled(mode string, stdoutIsTerminal, toFile, noColorEnv bool) (bool, error) {
+	switch mode {
+	case ColorAlways:
+		return true, nil
+	case ColorNever:
+		return false, nil
+	case ColorAuto, "":
+		return stdoutIsTerminal && !toFile && !noColorEnv, nil
+	}
+	return false, fmt.Errorf("invalid color mode: %s (auto|always|never)", mode)
+}
+
+// colorizeSnippet はスニペット中のヒット部分をANSIエスケープシーケンスで囲んで返します。
+// 複数クエリのヒットをまとめたスニペットでは各ヒット部分を、それ以外では [MatchStart, MatchEnd) を強調します。
+// 範囲が文字の途中を指す場合(キャッシュから読み込んだ古い結果など)は、マルチバイト文字を壊さないよう強調しません。
+func colorizeSnippet(snippet string, info SnippetInfo) string {
+	spans := info.Matches
+	if len(spans) == 0 {
+		spans = []MatchSpan{{Start: info.MatchStart, End: info.MatchEnd}}
+	}
+	for _, m := range spans {
+		if !runeBoundary(snippet, m.Start) || !runeBoundary(snippet, m.End) || m.Start > m.End {
+			return snippet
+		}
+	}
+
+	out := make([]byte, 0, len(snippet)+len(spans)*(len(highlightStart)+len(highlightEnd)))
+	pos := 0
+	for _, m := range spans {
+		if m.Start < pos {
+			return snippet
+		}
+		if m.Start == m.End {
+			continue
+		}
+		out = append(out, snippet[pos:m.Start]...)
+		out = append(out, highlightStart...)
+		out = append(out, snippet[m.Start:m.End]...)
+		out = append(out, highlightEnd...)
+		pos = m.End
+	}
+	out = append(out, snippet[pos:]...)
+	return string(out)
+}
+
+// runeBoundary は i が s の文字の境界(先頭・末尾を含む)であるかを返します
+func runeBoundary(s string, i int) bool {
+	if i < 0 || i > len(s) {
+		return false
+	}
+	return i == len(s) || utf8.RuneStart(s[i])
+}
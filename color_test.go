@@ -0,0 +1,81 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+// TestColorizeSnippet はヒット部分のみが文字単位で色付けされるか確認します
+func TestColorizeSnippet(t *testing.T) {
+	tests := []struct {
+		name    string
+		snippet string
+		info    SnippetInfo
+		want    string
+	}{
+		{"Single", "髙橋と鷗外", SnippetInfo{MatchStart: 9, MatchEnd: 12}, "髙橋と\x1b[1;31m鷗\x1b[0m外"},
+		{"Combined", "WARN 外字 ERROR", SnippetInfo{Matches: []MatchSpan{{Start: 0, End: 4}, {Start: 12, End: 17}}},
+			"\x1b[1;31mWARN\x1b[0m 外字 \x1b[1;31mERROR\x1b[0m"},
+		{"InsideRune", "髙橋", SnippetInfo{MatchStart: 1, MatchEnd: 3}, "髙橋"},
+		{"OutOfRange", "髙橋", SnippetInfo{MatchStart: 3, MatchEnd: 9}, "髙橋"},
+		{"Empty", "髙橋", SnippetInfo{}, "髙橋"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := colorizeSnippet(tt.snippet, tt.info); got != tt.want {
+				t.Errorf("colorizeSnippet() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestRun_Color は -color の指定と出力先に応じてヒット部分が色付けされるか確認します
+func TestRun_Color(t *testing.T) {
+	run := func(tty bool, env map[string]string, args ...string) (int, string) {
+		stdout := new(bytes.Buffer)
+		code := Run(AppContext{
+			Args:        append([]string{"app"}, args...),
+			ExecPath:    "app_鷗",
+			Stdout:      stdout,
+			Stderr:      io.Discard,
+			StdoutIsTTY: tty,
+			Getenv:      func(k string) string { return env[k] },
+			FileReader:  func(string) (io.ReadCloser, error) { return io.NopCloser(strings.NewReader("森鷗外\n")), nil },
+			FileCreator: func(string) (io.WriteCloser, error) { return nopWriteCloser{io.Discard}, nil },
+		})
+		return code, stdout.String()
+	}
+
+	colored := "line 1: 森\x1b[1;31m鷗\x1b[0m外\n"
+	tests := []struct {
+		name    string
+		tty     bool
+		env     map[string]string
+		args    []string
+		colored bool
+	}{
+		{"AutoTerminal", true, nil, []string{"input.log"}, true},
+		{"AutoPipe", false, nil, []string{"input.log"}, false},
+		{"AutoWithOutputFile", true, nil, []string{"-o", "out.txt", "input.log"}, false},
+		{"AutoNoColor", true, map[string]string{"NO_COLOR": "1"}, []string{"input.log"}, false},
+		{"Always", false, map[string]string{"NO_COLOR": "1"}, []string{"-color", "always", "input.log"}, true},
+		{"Never", true, nil, []string{"-color", "never", "input.log"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, out := run(tt.tty, tt.env, tt.args...)
+			if code != ExitMatch {
+				t.Fatalf("Run() exit code = %d", code)
+			}
+			if got := strings.Contains(out, colored); got != tt.colored {
+				t.Errorf("Colored = %v, want %v\n Output: %q", got, tt.colored, out)
+			}
+		})
+	}
+
+	if code, _ := run(true, nil, "-color", "rainbow", "input.log"); code != ExitError {
+		t.Errorf("Run() with invalid -color exit code = %d, want %d", code, ExitError)
+	}
+}
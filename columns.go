@@ -0,0 +1,252 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/text/encoding"
+)
+
+// -columns で指定できる列の区切り方
+const (
+	ColumnsCSV   = "csv"
+	ColumnsTSV   = "tsv"
+	ColumnsFixed = "fixed" // fixed:10,8,20 のように各列の幅(文字数)を指定する
+)
+
+// ColumnLayout は表形式の入力を列に分ける方法です
+type ColumnLayout struct {
+	Kind   string
+	Widths []int // ColumnsFixed の場合の各列の幅(文字数)
+}
+
+// ParseColumnLayout は -columns の指定(csv|tsv|fixed:幅,幅,...)を解釈します
+func ParseColumnLayout(spec string) (ColumnLayout, error) {
+	kind, widths, hasWidths := strings.Cut(spec, ":")
+	switch {
+	case (kind == ColumnsCSV || kind == ColumnsTSV) && !hasWidths:
+		return ColumnLayout{Kind: kind}, nil
+	case kind == ColumnsFixed && hasWidths:
+		layout := ColumnLayout{Kind: kind}
+		for _, w := range strings.Split(widths, ",") {
+			n, err := strconv.Atoi(strings.TrimSpace(w))
+			if err != nil || n <= 0 {
+				return ColumnLayout{}, fmt.Errorf("invalid column width: %q", w)
+			}
+			layout.Widths = append(layout.Widths, n)
+		}
+		return layout, nil
+	}
+	return ColumnLayout{}, fmt.Errorf("invalid column layout: %s (csv|tsv|fixed:W1,W2,...)", spec)
+}
+
+// ColumnStat は1列分の集計です
+type ColumnStat struct {
+	Column      int      `json:"column"` // 1始まり
+	Name        string   `json:"name,omitempty"`
+	Rows        int      `json:"rows"`         // この列を持つ行の数
+	ProblemRows int      `json:"problem_rows"` // この列に問題文字を含む行の数
+	Chars       []string `json:"chars"`        // 問題文字の種類(コードポイント順)
+}
+
+// ColumnStats は表形式の入力について、変換先の文字コードで表現できない文字を列ごとに集計します。
+// 複数ファイルは列の位置をそろえて合算します。Header が真なら各ファイルの先頭行を列名として扱います。
+type ColumnStats struct {
+	Layout  ColumnLayout
+	Header  bool
+	encoder *encoding.Encoder
+	checked map[rune]bool // 文字ごとの判定結果のキャッシュ(true なら表現できない)
+	columns []*columnStat
+}
+
+// columnStat は集計途中の1列分の状態です
+type columnStat struct {
+	name        string
+	rows        int
+	problemRows int
+	chars       map[rune]bool
+}
+
+// NewColumnStats は enc で表現できない文字を列ごとに集計する ColumnStats を生成します
+func NewColumnStats(enc encoding.Encoding, layout ColumnLayout, header bool) *ColumnStats {
+	return &ColumnStats{
+		Layout:  layout,
+		Header:  header,
+		encoder: enc.NewEncoder(),
+		checked: make(map[rune]bool),
+	}
+}
+
+// Scan は1ファイル分の入力を行ごとに列に分けて集計します
+func (cs *ColumnStats) Scan(r io.Reader) error {
+	next := cs.rowReader(r)
+	for first := true; ; first = false {
+		fields, err := next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("error reading stream: %w", err)
+		}
+		if first && len(fields) > 0 {
+			fields[0] = strings.TrimPrefix(fields[0], utf8BOM)
+		}
+		if first && cs.Header {
+			for i, name := range fields {
+				if col := cs.column(i); col.name == "" {
+					col.name = name
+				}
+			}
+			continue
+		}
+		for i, field := range fields {
+			cs.add(cs.column(i), field)
+		}
+	}
+}
+
+// rowReader は区切り方に応じて1行分の列を順に返す関数を返します
+func (cs *ColumnStats) rowReader(r io.Reader) func() ([]string, error) {
+	if cs.Layout.Kind == ColumnsCSV {
+		cr := csv.NewReader(r)
+		cr.FieldsPerRecord = -1
+		cr.LazyQuotes = true
+		return func() ([]string, error) {
+			fields, err := cr.Read()
+			// 列数の揃わない行も集計する
+			if errors.Is(err, csv.ErrFieldCount) {
+				err = nil
+			}
+			return fields, err
+		}
+	}
+
+	scanner := newLineReader(r)
+	return func() ([]string, error) {
+		if !scanner.Scan() {
+			if err := scanner.Err(); err != nil {
+				return nil, err
+			}
+			return nil, io.EOF
+		}
+		line := scanner.Text()
+		if cs.Layout.Kind == ColumnsTSV {
+			return strings.Split(line, "\t"), nil
+		}
+		return splitFixedWidth(line, cs.Layout.Widths), nil
+	}
+}
+
+// splitFixedWidth は行を文字数で指定した幅の列に分けます。
+// 幅の合計を超える部分は最後の列の後ろに1列として加え、行が短い場合は存在する列のみを返します。
+func splitFixedWidth(line string, widths []int) []string {
+	runes := []rune(line)
+	var fields []string
+	pos := 0
+	for _, w := range widths {
+		if pos >= len(runes) {
+			return fields
+		}
+		end := min(pos+w, len(runes))
+		fields = append(fields, string(runes[pos:end]))
+		pos = end
+	}
+	if pos < len(runes) {
+		fields = append(fields, string(runes[pos:]))
+	}
+	return fields
+}
+
+// column は i 番目(0始まり)の列の集計を返します(なければ追加する)
+func (cs *ColumnStats) column(i int) *columnStat {
+	for len(cs.columns) <= i {
+		cs.columns = append(cs.columns, &columnStat{chars: make(map[rune]bool)})
+	}
+	return cs.columns[i]
+}
+
+// add は1行分の列の値を集計します
+func (cs *ColumnStats) add(col *columnStat, field string) {
+	col.rows++
+	problem := false
+	for _, c := range field {
+		if cs.isProblem(c) {
+			col.chars[c] = true
+			problem = true
+		}
+	}
+	if problem {
+		col.problemRows++
+	}
+}
+
+// isProblem は文字が変換先の文字コードで表現できないかを返します
+func (cs *ColumnStats) isProblem(c rune) bool {
+	problem, ok := cs.checked[c]
+	if !ok {
+		_, err := cs.encoder.String(string(c))
+		problem = err != nil
+		cs.checked[c] = problem
+	}
+	return problem
+}
+
+// Stats は列ごとの集計結果を列の順に返します
+func (cs *ColumnStats) Stats() []ColumnStat {
+	stats := make([]ColumnStat, len(cs.columns))
+	for i, col := range cs.columns {
+		runes := make([]rune, 0, len(col.chars))
+		for c := range col.chars {
+			runes = append(runes, c)
+		}
+		sort.Slice(runes, func(i, j int) bool { return runes[i] < runes[j] })
+		chars := make([]string, len(runes))
+		for j, c := range runes {
+			chars[j] = string(c)
+		}
+		stats[i] = ColumnStat{Column: i + 1, Name: col.name, Rows: col.rows, ProblemRows: col.problemRows, Chars: chars}
+	}
+	return stats
+}
+
+// WriteColumnStats は列ごとの集計を出力します。
+// テキスト形式では1列1行で "列2 (氏名): 1000行中 12行 問題文字 3種: 髙 﨑 鷗" のように出力します。
+func WriteColumnStats(w io.Writer, stats []ColumnStat, format string) error {
+	if format == FormatJSON || format == FormatJSONL {
+		enc := json.NewEncoder(w)
+		if format == FormatJSON {
+			enc.SetIndent("", "  ")
+			return enc.Encode(struct {
+				Schema  string       `json:"schema"`
+				Columns []ColumnStat `json:"columns"`
+			}{SchemaVersion, stats})
+		}
+		for _, s := range stats {
+			if err := enc.Encode(s); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	for _, s := range stats {
+		label := fmt.Sprintf("列%d", s.Column)
+		if s.Name != "" {
+			label += fmt.Sprintf(" (%s)", s.Name)
+		}
+		line := fmt.Sprintf("%s: %d行中 %d行 問題文字 %d種", label, s.Rows, s.ProblemRows, len(s.Chars))
+		if len(s.Chars) > 0 {
+			line += ": " + strings.Join(s.Chars, " ")
+		}
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"golang.org/x/text/encoding/japanese"
+)
+
+// TestColumnStats は区切り方ごとに、列ごとの行数・問題のある行数・問題文字の種類が集計されるか確認します
+func TestColumnStats(t *testing.T) {
+	tests := []struct {
+		name   string
+		layout string
+		header bool
+		input  string
+		want   string
+	}{
+		{
+			name:   "CSV",
+			layout: "csv",
+			header: true,
+			input:  "id,氏名,住所\n1,髙橋,\"東京都\n𠮷野町\"\n2,森鷗外,本郷\n3,﨑山\n",
+			want:   "列1 (id): 3行中 0行 問題文字 0種\n列2 (氏名): 3行中 1行 問題文字 1種: 鷗\n列3 (住所): 2行中 1行 問題文字 1種: 𠮷\n",
+		},
+		{
+			name:   "TSV",
+			layout: "tsv",
+			input:  "a\t鷗\r\nb\t鷗𠮷\r\n",
+			want:   "列1: 2行中 0行 問題文字 0種\n列2: 2行中 2行 問題文字 2種: 鷗 𠮷\n",
+		},
+		{
+			name:   "Fixed",
+			layout: "fixed:2,3",
+			input:  "01森鷗外東京\n02高橋\n",
+			want:   "列1: 2行中 0行 問題文字 0種\n列2: 2行中 1行 問題文字 1種: 鷗\n列3: 1行中 0行 問題文字 0種\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			layout, err := ParseColumnLayout(tt.layout)
+			if err != nil {
+				t.Fatal(err)
+			}
+			cs := NewColumnStats(japanese.ShiftJIS, layout, tt.header)
+			if err := cs.Scan(strings.NewReader(tt.input)); err != nil {
+				t.Fatal(err)
+			}
+			out := new(bytes.Buffer)
+			if err := WriteColumnStats(out, cs.Stats(), FormatText); err != nil {
+				t.Fatal(err)
+			}
+			if out.String() != tt.want {
+				t.Errorf("Column stats mismatch.\nGot:\n%s\nWant:\n%s", out.String(), tt.want)
+			}
+		})
+	}
+
+	for _, spec := range []string{"xlsx", "fixed", "fixed:3,0", "csv:2"} {
+		if _, err := ParseColumnLayout(spec); err == nil {
+			t.Errorf("ParseColumnLayout(%q) should fail", spec)
+		}
+	}
+}
+
+// TestRun_ColumnStats は複数ファイルの集計が列の位置をそろえて合算されるか確認します
+func TestRun_ColumnStats(t *testing.T) {
+	files := map[string]string{"a.tsv": "x\t鷗\n", "b.tsv": "y\t高\tz\n"}
+	stdout := new(bytes.Buffer)
+	code := Run(AppContext{
+		Args:       []string{"app", "-column-stats", "sjis", "-columns", "tsv", "-format", "jsonl", "a.tsv", "b.tsv"},
+		ExecPath:   "app",
+		Stdout:     stdout,
+		Stderr:     io.Discard,
+		FileReader: func(path string) (io.ReadCloser, error) { return io.NopCloser(strings.NewReader(files[path])), nil },
+	})
+	if code != 0 {
+		t.Fatalf("Run() exit code = %d", code)
+	}
+	want := `{"column":1,"rows":2,"problem_rows":0,"chars":[]}` + "\n" +
+		`{"column":2,"rows":2,"problem_rows":1,"chars":["鷗"]}` + "\n" +
+		`{"column":3,"rows":1,"problem_rows":0,"chars":[]}` + "\n"
+	if stdout.String() != want {
+		t.Errorf("Output = %s, want %s", stdout.String(), want)
+	}
+}
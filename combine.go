@@ -0,0 +1,99 @@
+package main
+
+import (
+	"sort"
+	"strings"
+)
+
+// MatchSpan はスニペット中のヒット部分の位置(バイト単位、[Start, End))です
+type MatchSpan struct {
+	Query string `json:"query"`
+	Start int    `json:"start"`
+	End   int    `json:"end"`
+}
+
+// combinedSnippet は1行で複数のクエリがヒットした場合に、1つにまとめるスニペットです。
+// 最初にヒットしたクエリの結果に格納し、後続のクエリのヒット部分を追加していきます。
+type combinedSnippet struct {
+	res      *SearchResult
+	idx      int
+	lineText string
+	source   rawLine // -hex-snippets で元のバイト列を取り出す変換前の行
+	src      []rune
+	from, to int
+	spans    []runeSpan
+}
+
+// runeSpan は行内のヒット部分の位置(ルーン単位)です
+type runeSpan struct {
+	query      string
+	start, end int
+}
+
+// add は別のクエリのヒット部分を追加し、スニペットの範囲を広げて作り直します
+func (c *combinedSnippet) add(query string, start, end, from, to int, opts SearchOptions) {
+	prevFrom, prevTo := c.from, c.to
+	c.from, c.to = min(c.from, from), max(c.to, to)
+	c.spans = append(c.spans, runeSpan{query, start, end})
+
+	snippet, matches := c.build(opts.Escape)
+	// 上限を超える場合は広げずに元のスニペットを残す(このヒット部分は強調しない)
+	if opts.MaxSnippetBytes > 0 && len(snippet) > opts.MaxSnippetBytes {
+		c.from, c.to = prevFrom, prevTo
+		c.spans = c.spans[:len(c.spans)-1]
+		return
+	}
+	info := &c.res.Infos[c.idx]
+	c.res.Snippets[c.idx] = snippet
+	info.Matches = matches
+	// 主たるヒット位置は最初のクエリのものを保つ
+	for _, m := range matches {
+		if m.Query == c.spans[0].query {
+			info.MatchStart, info.MatchEnd = m.Start, m.End
+		}
+	}
+	if opts.CompareEncoding != nil {
+		info.Converted = SimulateConversion(snippet, opts.CompareEncoding)
+	}
+	if info.Raw != nil {
+		info.Raw = c.source.span(c.lineText, c.from, c.to)
+	}
+}
+
+// build はスニペットと各ヒット部分の位置を作成します。重なるヒットは前のヒットの終わりから数えます。
+func (c *combinedSnippet) build(escape int) (string, []MatchSpan) {
+	spans := append([]runeSpan(nil), c.spans...)
+	sort.SliceStable(spans, func(i, j int) bool { return spans[i].start < spans[j].start })
+
+	var sb strings.Builder
+	matches := make([]MatchSpan, 0, len(spans))
+	pos := c.from
+	for _, s := range spans {
+		start := max(s.start, pos)
+		end := max(s.end, start)
+		sb.WriteString(EscapeSnippet(string(c.src[pos:start]), escape))
+		m := MatchSpan{Query: s.query, Start: sb.Len()}
+		sb.WriteString(EscapeSnippet(string(c.src[start:end]), escape))
+		m.End = sb.Len()
+		matches = append(matches, m)
+		pos = end
+	}
+	sb.WriteString(EscapeSnippet(string(c.src[pos:c.to]), escape))
+	return sb.String(), matches
+}
+
+// highlightSnippet はまとめたスニペットの各ヒット部分を【】で囲んで返します
+func highlightSnippet(snippet string, matches []MatchSpan) string {
+	if len(matches) < 2 {
+		return snippet
+	}
+	var sb strings.Builder
+	pos := 0
+	for _, m := range matches {
+		sb.WriteString(snippet[pos:m.Start])
+		sb.WriteString("【" + snippet[m.Start:m.End] + "】")
+		pos = m.End
+	}
+	sb.WriteString(snippet[pos:])
+	return sb.String()
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestCombineLines は同じ行の複数クエリのヒットが1件のスニペットにまとまるか確認します
+func TestCombineLines(t *testing.T) {
+	input := "前置き髙橋と齋藤の記録\n齋藤のみ\n"
+	opts := SearchOptions{ContextSize: 1, CombineLines: true}
+
+	results, err := SearchStreamWithOptions(strings.NewReader(input), []string{"髙橋", "齋藤"}, opts)
+	if err != nil {
+		t.Fatalf("SearchStreamWithOptions() error = %v", err)
+	}
+
+	first, second := results["髙橋"], results["齋藤"]
+	if first.Count != 1 || second.Count != 2 {
+		t.Fatalf("Count = %d, %d, want 1, 2", first.Count, second.Count)
+	}
+	if len(first.Snippets) != 1 || first.Snippets[0] != "き髙橋と齋藤の" {
+		t.Errorf("combined snippet = %q", first.Snippets)
+	}
+	// 2つ目のクエリは別の行のヒットのみスニペットを持つ
+	if len(second.Snippets) != 1 || second.Snippets[0] != "齋藤の" {
+		t.Errorf("second snippets = %q", second.Snippets)
+	}
+
+	matches := first.Infos[0].Matches
+	if len(matches) != 2 || matches[1].Query != "齋藤" {
+		t.Fatalf("Matches = %+v", matches)
+	}
+	if got := highlightSnippet(first.Snippets[0], matches); got != "き【髙橋】と【齋藤】の" {
+		t.Errorf("highlightSnippet() = %q", got)
+	}
+}
@@ -0,0 +1,262 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// ConfigFileExt は実行ファイルと同じディレクトリから自動で読み込む設定ファイルの拡張子です。
+// 実行ファイル app_WARN.exe であれば、アプリ名の部分を使った app.toml を読み込みます。
+const ConfigFileExt = ".toml"
+
+// configAliases は設定ファイルで使える、フラグ名より分かりやすいキーです
+var configAliases = map[string]string{
+	"context":     "n",
+	"encoding":    "enc",
+	"output-file": "o", // output は追加のレポートの -output に使う
+}
+
+// FileConfig は設定ファイル(-config)の内容です。TOMLのうち、テーブルを含まない key = value の形式に対応します。
+//
+// 優先順位は「明示したフラグ > 設定ファイル > 既定値」です。
+// queries のクエリは実行ファイル名のクエリと -f・-q のクエリの間に加えます(-replace-queries では使いません)。
+type FileConfig struct {
+	Queries  []string
+	settings []configSetting
+}
+
+// configSetting は queries 以外の1つの設定です
+type configSetting struct {
+	key, value string
+	line       int
+}
+
+// ConfigPathFor は実行ファイルと同じディレクトリにある設定ファイルのパスを返します
+func ConfigPathFor(execPath string) string {
+	base := filepath.Base(execPath)
+	app, _, _ := strings.Cut(strings.TrimSuffix(base, filepath.Ext(base)), "_")
+	return filepath.Join(filepath.Dir(execPath), app+ConfigFileExt)
+}
+
+// LoadConfigFile は設定ファイルを読み込みます。
+// 値は文字列・整数・小数・真偽値と、それらの配列(フラグにはカンマ区切りで渡す)を指定できます。
+func LoadConfigFile(r io.Reader) (*FileConfig, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("error reading config: %w", err)
+	}
+
+	cfg := &FileConfig{}
+	p := &tomlParser{src: string(data), line: 1}
+	seen := make(map[string]bool)
+	for {
+		p.skipBlank(true)
+		if p.done() {
+			return cfg, nil
+		}
+		line := p.line
+		if p.peek() == '[' {
+			return nil, fmt.Errorf("config line %d: tables are not supported", line)
+		}
+		key, err := p.key()
+		if err != nil {
+			return nil, fmt.Errorf("config line %d: %w", line, err)
+		}
+		if seen[key] {
+			return nil, fmt.Errorf("config line %d: duplicate key %q", line, key)
+		}
+		seen[key] = true
+
+		p.skipBlank(false)
+		if p.done() || p.peek() != '=' {
+			return nil, fmt.Errorf("config line %d: expected key = value", line)
+		}
+		p.pos++
+		p.skipBlank(false)
+		values, isArray, err := p.value()
+		if err != nil {
+			return nil, fmt.Errorf("config line %d: %w", line, err)
+		}
+		if err := p.endOfLine(); err != nil {
+			return nil, fmt.Errorf("config line %d: %w", p.line, err)
+		}
+
+		if key == "queries" {
+			cfg.Queries = append(cfg.Queries, values...)
+			continue
+		}
+		if isArray && len(values) == 0 {
+			continue
+		}
+		cfg.settings = append(cfg.settings, configSetting{key: key, value: strings.Join(values, ","), line: line})
+	}
+}
+
+// Apply は明示されていないフラグに設定ファイルの値を設定します
+func (c *FileConfig) Apply(fs *flag.FlagSet) error {
+	explicit := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) { explicit[f.Name] = true })
+
+	for _, s := range c.settings {
+		name := s.key
+		if alias, ok := configAliases[name]; ok {
+			name = alias
+		}
+		if name == "config" || fs.Lookup(name) == nil {
+			return fmt.Errorf("config line %d: unknown setting %q", s.line, s.key)
+		}
+		if explicit[name] {
+			continue
+		}
+		if err := fs.Set(name, s.value); err != nil {
+			return fmt.Errorf("config line %d: invalid value for %s: %w", s.line, s.key, err)
+		}
+	}
+	return nil
+}
+
+// tomlParser は設定ファイルの字句を読み進めます
+type tomlParser struct {
+	src  string
+	pos  int
+	line int
+}
+
+func (p *tomlParser) done() bool { return p.pos >= len(p.src) }
+
+func (p *tomlParser) peek() byte { return p.src[p.pos] }
+
+// skipBlank は空白を読み飛ばします。newlines が真なら改行とコメントも読み飛ばします。
+func (p *tomlParser) skipBlank(newlines bool) {
+	for !p.done() {
+		switch c := p.peek(); {
+		case c == ' ' || c == '\t' || c == '\r':
+			p.pos++
+		case newlines && c == '\n':
+			p.pos++
+			p.line++
+		case newlines && c == '#':
+			for !p.done() && p.peek() != '\n' {
+				p.pos++
+			}
+		default:
+			return
+		}
+	}
+}
+
+// endOfLine は値の後ろに空白とコメントのみが続くことを確認し、改行まで読み進めます
+func (p *tomlParser) endOfLine() error {
+	p.skipBlank(false)
+	if p.done() || p.peek() == '\n' || p.peek() == '#' {
+		p.skipBlank(true)
+		return nil
+	}
+	return errors.New("unexpected text after value")
+}
+
+// key はキー(英数字・"_"・"-" からなるもの、または引用符で囲んだもの)を読みます
+func (p *tomlParser) key() (string, error) {
+	if c := p.peek(); c == '"' || c == '\'' {
+		return p.str()
+	}
+	start := p.pos
+	for !p.done() {
+		c := p.peek()
+		if c != '_' && c != '-' && (c < '0' || c > '9') && (c < 'a' || c > 'z') && (c < 'A' || c > 'Z') {
+			break
+		}
+		p.pos++
+	}
+	if p.pos == start {
+		return "", errors.New("expected key")
+	}
+	return p.src[start:p.pos], nil
+}
+
+// value は値を読みます。配列の場合は要素ごとの値を返します(改行をまたいでも構わない)。
+func (p *tomlParser) value() (values []string, isArray bool, err error) {
+	if p.done() {
+		return nil, false, errors.New("missing value")
+	}
+	if p.peek() != '[' {
+		v, err := p.scalar()
+		return []string{v}, false, err
+	}
+
+	p.pos++
+	for {
+		p.skipBlank(true)
+		if p.done() {
+			return nil, true, errors.New("unterminated array")
+		}
+		if p.peek() == ']' {
+			p.pos++
+			return values, true, nil
+		}
+		v, err := p.scalar()
+		if err != nil {
+			return nil, true, err
+		}
+		values = append(values, v)
+		p.skipBlank(true)
+		if !p.done() && p.peek() == ',' {
+			p.pos++
+		} else if p.done() || p.peek() != ']' {
+			return nil, true, errors.New("expected , or ] in array")
+		}
+	}
+}
+
+// scalar は文字列・数値・真偽値を読みます
+func (p *tomlParser) scalar() (string, error) {
+	if c := p.peek(); c == '"' || c == '\'' {
+		return p.str()
+	}
+	start := p.pos
+	for !p.done() && !strings.ContainsRune(" \t\r\n#,]", rune(p.peek())) {
+		p.pos++
+	}
+	raw := p.src[start:p.pos]
+	if raw == "true" || raw == "false" {
+		return raw, nil
+	}
+	if _, err := strconv.ParseFloat(strings.ReplaceAll(raw, "_", ""), 64); err != nil {
+		return "", fmt.Errorf("invalid value %q (strings must be quoted)", raw)
+	}
+	return strings.ReplaceAll(raw, "_", ""), nil
+}
+
+// str は "..."(エスケープを解釈する)または '...'(そのまま)の文字列を読みます
+func (p *tomlParser) str() (string, error) {
+	quote := p.peek()
+	start := p.pos
+	p.pos++
+	for !p.done() {
+		switch c := p.peek(); {
+		case c == '\n':
+			return "", errors.New("unterminated string")
+		case c == '\\' && quote == '"':
+			p.pos += 2
+			continue
+		case c == quote:
+			p.pos++
+			raw := p.src[start:p.pos]
+			if quote == '\'' {
+				return raw[1 : len(raw)-1], nil
+			}
+			s, err := strconv.Unquote(raw)
+			if err != nil {
+				return "", fmt.Errorf("invalid string %s", raw)
+			}
+			return s, nil
+		}
+		p.pos++
+	}
+	return "", errors.New("unterminated string")
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestLoadConfigFile は文字列・数値・真偽値・複数行の配列とコメントを読み込めるか確認します
+func TestLoadConfigFile(t *testing.T) {
+	src := `# 検索設定
+queries = [
+  "髙橋", # 氏名
+  'C:\logs',
+]
+context = 5
+format = "json"
+fold-duplicates = true
+exclude = ["vendor", "*.bak"]
+`
+	cfg, err := LoadConfigFile(strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("LoadConfigFile() error = %v", err)
+	}
+	if want := []string{"髙橋", `C:\logs`}; !reflect.DeepEqual(cfg.Queries, want) {
+		t.Errorf("Queries = %q, want %q", cfg.Queries, want)
+	}
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	n := fs.Int("n", 20, "")
+	format := fs.String("format", "text", "")
+	fold := fs.Bool("fold-duplicates", false, "")
+	exclude := fs.String("exclude", "", "")
+	fs.Parse([]string{"-format", "table"})
+	if err := cfg.Apply(fs); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if *n != 5 || *format != "table" || !*fold || *exclude != "vendor,*.bak" {
+		t.Errorf("flags = %d %s %t %s, explicit flags should win over the config", *n, *format, *fold, *exclude)
+	}
+
+	for _, bad := range []string{"[section]\n", "format = json\n", "a = 1\na = 2\n", "q = [\"x\"\n", "format = \"json\" extra\n"} {
+		if _, err := LoadConfigFile(strings.NewReader(bad)); err == nil {
+			t.Errorf("LoadConfigFile(%q) should fail", bad)
+		}
+	}
+	cfg, _ = LoadConfigFile(strings.NewReader("unknown = 1\n"))
+	if err := cfg.Apply(fs); err == nil {
+		t.Error("Apply() should reject unknown settings")
+	}
+}
+
+// TestRun_ConfigFile は実行ファイルの隣の設定ファイルが自動で読み込まれ、クエリが合わさるか確認します
+func TestRun_ConfigFile(t *testing.T) {
+	dir := t.TempDir()
+	writeLogFile(t, dir+"/objis.toml", "queries = [\"ERROR\"]\ncontext = 2\n", os.O_TRUNC)
+	writeLogFile(t, dir+"/input.log", "WARN disk\nERROR disk\n", os.O_TRUNC)
+
+	stdout := new(bytes.Buffer)
+	code := Run(AppContext{
+		Args:       []string{"app", dir + "/input.log"},
+		ExecPath:   dir + "/objis_WARN.exe",
+		Stdout:     stdout,
+		Stderr:     io.Discard,
+		FileReader: func(path string) (io.ReadCloser, error) { return os.Open(path) },
+		FileStat:   os.Stat,
+	})
+	if code != 0 {
+		t.Fatalf("Run() exit code = %d", code)
+	}
+	out := stdout.String()
+	for _, want := range []string{"[WARN]\n", "[ERROR]\n", "line 2: ERROR d\n"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Output should contain %q.\n Output: %s", want, out)
+		}
+	}
+}
+
+// TestRun_ConfigOutput は設定ファイルの output が -output(追加のレポート)に、output-file が -o に渡るか確認します
+func TestRun_ConfigOutput(t *testing.T) {
+	dir := t.TempDir()
+	config := fmt.Sprintf("output = %q\noutput-file = %q\n", "fmt=json,path="+filepath.Join(dir, "a.json"), filepath.Join(dir, "report.txt"))
+	writeLogFile(t, filepath.Join(dir, "objis.toml"), config, os.O_TRUNC)
+	writeLogFile(t, filepath.Join(dir, "input.log"), "WARN disk\n", os.O_TRUNC)
+
+	code := Run(AppContext{
+		Args:        []string{"app", filepath.Join(dir, "input.log")},
+		ExecPath:    filepath.Join(dir, "objis_WARN.exe"),
+		Stdout:      io.Discard,
+		Stderr:      io.Discard,
+		FileReader:  func(path string) (io.ReadCloser, error) { return os.Open(path) },
+		FileCreator: func(path string) (io.WriteCloser, error) { return os.Create(path) },
+		FileStat:    os.Stat,
+	})
+	if code != ExitMatch {
+		t.Fatalf("Run() exit code = %d", code)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "a.json"))
+	if err != nil || !json.Valid(data) {
+		t.Errorf("output should write a JSON report to a.json: %v\n%s", err, data)
+	}
+	if report, err := os.ReadFile(filepath.Join(dir, "report.txt")); err != nil || !strings.Contains(string(report), "[WARN]") {
+		t.Errorf("output-file should write the text report: %v\n%s", err, report)
+	}
+}
@@ -0,0 +1,121 @@
+package main
+
+import "unicode"
+
+// コンテキストの境界合わせ指定
+const (
+	ContextAlignChar = "char" // 文字数で機械的に切る(既定)
+	ContextAlignWord = "word" // 空白・句読点・文字種の変わり目まで広げる
+)
+
+// MaxAlignExtension は境界合わせで片側に広げる最大文字数です
+const MaxAlignExtension = 10
+
+// コンテキストの単位指定
+const (
+	ContextUnitChar     = "char"     // 前後の文字数で切る(既定)
+	ContextUnitSentence = "sentence" // ヒットを含む文(。！？で区切る)全体を切り出す
+)
+
+// DefaultSentenceMax は文単位のスニペットの既定の最大文字数です
+const DefaultSentenceMax = 200
+
+// contextRange はオプションに従ってスニペットとして切り出す範囲をルーン単位で返します
+func contextRange(lineRunes []rune, start, end int, opts SearchOptions) (from, to int) {
+	if opts.ContextUnit == ContextUnitSentence {
+		return sentenceRange(lineRunes, start, end, opts.SentenceMax)
+	}
+
+	from, to = snippetRange(len(lineRunes), start, end, opts.ContextSize)
+	if opts.ContextAlign == ContextAlignWord {
+		from, to = alignToWords(lineRunes, from, to)
+	}
+	return from, to
+}
+
+// alignToWords は範囲の両端が語の途中にある場合、語の境界まで(最大MaxAlignExtension文字)広げます
+func alignToWords(lineRunes []rune, from, to int) (int, int) {
+	for n := 0; from > 0 && n < MaxAlignExtension && sameWord(lineRunes[from-1], lineRunes[from]); n++ {
+		from--
+	}
+	for n := 0; to > 0 && to < len(lineRunes) && n < MaxAlignExtension && sameWord(lineRunes[to-1], lineRunes[to]); n++ {
+		to++
+	}
+	return from, to
+}
+
+// sentenceRange はヒットを含む文の範囲を返します。
+// 文が maxLen 文字を超える場合はヒット部分を中心に maxLen 文字まで切り詰めます。
+func sentenceRange(lineRunes []rune, start, end, maxLen int) (from, to int) {
+	from = start
+	for from > 0 && !isSentenceEnd(lineRunes[from-1]) {
+		from--
+	}
+	// 文頭の空白は含めない
+	for from < start && unicode.IsSpace(lineRunes[from]) {
+		from++
+	}
+
+	to = end
+	for to < len(lineRunes) && !isSentenceEnd(lineRunes[to-1]) {
+		to++
+	}
+	// 区切り記号が連続する場合(！？など)はまとめて含める
+	for to < len(lineRunes) && isSentenceEnd(lineRunes[to]) {
+		to++
+	}
+
+	if maxLen <= 0 || to-from <= maxLen {
+		return from, to
+	}
+
+	// ヒット部分の前後に残りの文字数を均等に割り当てる
+	budget := max(maxLen-(end-start), 0)
+	before := min(start-from, budget/2)
+	after := min(to-end, budget-before)
+	before = min(start-from, budget-after)
+	return start - before, end + after
+}
+
+// isSentenceEnd は文末の区切り記号であるかを返します
+func isSentenceEnd(r rune) bool {
+	switch r {
+	case '。', '！', '？', '!', '?':
+		return true
+	}
+	return false
+}
+
+// sameWord は隣り合う2文字が同じ語に属するか(境界文字でなく文字種が同じか)を返します
+func sameWord(a, b rune) bool {
+	ca := wordClass(a)
+	return ca != wordBoundary && ca == wordClass(b)
+}
+
+// 語の判定に用いる文字種
+const (
+	wordBoundary = iota // 空白・句読点・記号
+	wordHan
+	wordHiragana
+	wordKatakana
+	wordAlnum
+	wordOther
+)
+
+// wordClass は文字の文字種を返します
+func wordClass(r rune) int {
+	switch {
+	case unicode.IsSpace(r) || unicode.IsPunct(r) || unicode.IsSymbol(r):
+		return wordBoundary
+	case unicode.Is(unicode.Han, r):
+		return wordHan
+	case unicode.Is(unicode.Hiragana, r):
+		return wordHiragana
+	case unicode.Is(unicode.Katakana, r) || r == 'ー':
+		return wordKatakana
+	case unicode.IsLetter(r) || unicode.IsDigit(r):
+		return wordAlnum
+	default:
+		return wordOther
+	}
+}
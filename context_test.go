@@ -0,0 +1,56 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestSearchStream_ContextAlignWord は語の途中で切れないようにコンテキストが広がるか確認します
+func TestSearchStream_ContextAlignWord(t *testing.T) {
+	content := "担当者は東京都千代田区の髙橋太郎さんです"
+
+	tests := []struct {
+		align string
+		want  string
+	}{
+		{ContextAlignChar, "田区の髙橋太郎さ"},
+		{ContextAlignWord, "東京都千代田区の髙橋太郎さんです"},
+	}
+	for _, tt := range tests {
+		results, err := SearchStreamWithOptions(strings.NewReader(content), []string{"髙橋"}, SearchOptions{
+			ContextSize:  3,
+			ContextAlign: tt.align,
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if got := results["髙橋"].Snippets[0]; got != tt.want {
+			t.Errorf("align=%s mismatch.\n got:  %q\n want: %q", tt.align, got, tt.want)
+		}
+	}
+}
+
+// TestSearchStream_ContextUnitSentence はヒットを含む文全体が切り出され、最大長で切り詰められるか確認します
+func TestSearchStream_ContextUnitSentence(t *testing.T) {
+	content := "前の文です。担当は髙橋です！次の文。"
+
+	tests := []struct {
+		max  int
+		want string
+	}{
+		{0, "担当は髙橋です！"},
+		{4, "は髙橋で"},
+	}
+	for _, tt := range tests {
+		results, err := SearchStreamWithOptions(strings.NewReader(content), []string{"髙橋"}, SearchOptions{
+			ContextUnit: ContextUnitSentence,
+			SentenceMax: tt.max,
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if got := results["髙橋"].Snippets[0]; got != tt.want {
+			t.Errorf("max=%d mismatch.\n got:  %q\n want: %q", tt.max, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"io"
+	"strconv"
+)
+
+// recordCoOccurrence は同じ行でヒットしたクエリの組ごとに行数を数えます
+func recordCoOccurrence(results map[string]*SearchResult, queries []string, hit []int) {
+	for _, i := range hit {
+		for _, j := range hit {
+			if i == j {
+				continue
+			}
+			addCoOccurrence(results[queries[i]], queries[j], 1)
+		}
+	}
+}
+
+// addCoOccurrence は res のクエリと other が同じ行でヒットした行数を加算します
+func addCoOccurrence(res *SearchResult, other string, n int) {
+	if res.CoOccur == nil {
+		res.CoOccur = make(map[string]int)
+	}
+	res.CoOccur[other] += n
+}
+
+// WriteCoOccurrence はクエリの組ごとの共起行数を行列として出力します。対角成分は各クエリの該当数です。
+func WriteCoOccurrence(w io.Writer, results map[string]*SearchResult, queryOrder []string) {
+	var order []string
+	for _, q := range queryOrder {
+		if _, ok := results[q]; ok {
+			order = append(order, q)
+		}
+	}
+
+	header := append([]string{"共起"}, order...)
+	rows := [][]string{header}
+	for _, q := range order {
+		row := []string{q}
+		for _, other := range order {
+			n := results[q].CoOccur[other]
+			if other == q {
+				n = results[q].Count
+			}
+			row = append(row, strconv.Itoa(n))
+		}
+		rows = append(rows, row)
+	}
+	writeTableRows(w, rows)
+}
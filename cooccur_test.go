@@ -0,0 +1,30 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// TestCoOccurrence はクエリの組ごとに同じ行でヒットした行数が行列として出力されるか確認します
+func TestCoOccurrence(t *testing.T) {
+	input := "氏名 髙橋\n氏名 山田\n住所 髙\n氏名 髙木 住所\n"
+	queries := []string{"髙", "氏名", "住所"}
+	results, err := SearchStreamWithOptions(strings.NewReader(input), queries, SearchOptions{ContextSize: 1, CoOccurrence: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out := new(bytes.Buffer)
+	WriteCoOccurrence(out, results, queries)
+	want := strings.Join([]string{
+		"共起 | 髙 | 氏名 | 住所",
+		"-----+----+------+-----",
+		"髙   | 3  | 2    | 2",
+		"氏名 | 2  | 3    | 1",
+		"住所 | 2  | 1    | 2",
+	}, "\n") + "\n"
+	if out.String() != want {
+		t.Errorf("Matrix mismatch.\nGot:\n%s\nWant:\n%s", out.String(), want)
+	}
+}
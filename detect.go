@@ -0,0 +1,145 @@
+package main
+
+import (
+	"bytes"
+	"unicode"
+	"unicode/utf8"
+)
+
+// EncodingAuto は入力の文字コードを先頭部分から判定する指定です
+const EncodingAuto = "auto"
+
+// DetectSampleSize は文字コードの判定に用いる先頭部分のバイト数です
+const DetectSampleSize = 64 << 10
+
+// 判定結果のうち、変換を必要としない UTF-8 を表す名前
+const encodingUTF8 = "utf-8"
+
+// utf8BOM は UTF-8 のBOMです。先頭行から取り除いてから照合します(行頭へのヒットを逃さず、スニペットに混ざらないように)。
+const utf8BOM = "\xef\xbb\xbf"
+
+// jisEscapes は ISO-2022-JP で文字集合を切り替えるエスケープシーケンスです
+var jisEscapes = [][]byte{
+	[]byte("\x1b$B"), []byte("\x1b$@"), []byte("\x1b(J"), []byte("\x1b(I"), []byte("\x1b$(Q"), []byte("\x1b$(O"),
+}
+
+// DetectEncoding は入力の先頭部分から文字コードを推定し、LookupEncoding で使える名前を返します。
+// BOM、JISエスケープシーケンス、UTF-8としての妥当性、UTF-16の0バイトの偏りを順に確認し、
+// いずれにも当たらなければ Shift_JIS / Shift_JIS-2004 / EUC-JP として復号した結果を比較します。
+func DetectEncoding(sample []byte) string {
+	if bytes.HasPrefix(sample, []byte(utf8BOM)) {
+		return "utf-8-bom"
+	}
+	if enc := DetectUTF16BOM(sample); enc != "" {
+		return enc
+	}
+
+	// 途中で切れた末尾の文字を判定に含めない
+	if i := bytes.LastIndexByte(sample, '\n'); i > 0 && len(sample) == DetectSampleSize {
+		sample = sample[:i+1]
+	}
+
+	if enc := detectUTF16(sample); enc != "" {
+		return enc
+	}
+
+	ascii := true
+	for _, b := range sample {
+		if b >= utf8.RuneSelf {
+			ascii = false
+			break
+		}
+	}
+	if ascii {
+		for _, esc := range jisEscapes {
+			if bytes.Contains(sample, esc) {
+				return "iso2022jp"
+			}
+		}
+		return encodingUTF8
+	}
+	if utf8.Valid(sample) {
+		return encodingUTF8
+	}
+
+	best, bestErrors, bestScore := "sjis", -1, 0
+	for _, name := range []string{"sjis", "eucjp", "sjis2004"} {
+		enc, _ := LookupEncoding(name)
+		decoded, _ := enc.NewDecoder().Bytes(sample)
+		errors, score := scoreJapanese(string(decoded))
+		// 復号できない箇所が少ないものを選び、同数なら日本語の文章らしいものを選ぶ
+		if bestErrors < 0 || errors < bestErrors || (errors == bestErrors && score > bestScore) {
+			best, bestErrors, bestScore = name, errors, score
+		}
+	}
+	return best
+}
+
+// DetectUTF16BOM は先頭が UTF-16 のBOMであれば、その文字コードの名前を返します(なければ空)
+func DetectUTF16BOM(sample []byte) string {
+	switch {
+	case bytes.HasPrefix(sample, []byte{0xFF, 0xFE}):
+		return "utf-16le"
+	case bytes.HasPrefix(sample, []byte{0xFE, 0xFF}):
+		return "utf-16be"
+	}
+	return ""
+}
+
+// detectUTF16 はBOMのないUTF-16を、偶数・奇数位置の0バイトの偏りと、
+// 2バイト単位に揃った改行(LF)の位置から判定します。
+// 日本語の文章は0バイトをほとんど含まないため、改行の位置も手掛かりにします。
+func detectUTF16(sample []byte) string {
+	if len(sample) < 4 {
+		return ""
+	}
+	var even, odd, lfLE, lfBE int
+	for i, b := range sample {
+		if i%2 == 0 && i+1 < len(sample) {
+			switch {
+			case b == '\n' && sample[i+1] == 0:
+				lfLE++
+			case b == 0 && sample[i+1] == '\n':
+				lfBE++
+			}
+		}
+		if b != 0 {
+			continue
+		}
+		if i%2 == 0 {
+			even++
+		} else {
+			odd++
+		}
+	}
+	switch {
+	case lfLE > 0 && lfBE == 0:
+		return "utf-16le"
+	case lfBE > 0 && lfLE == 0:
+		return "utf-16be"
+	}
+	half := len(sample) / 2
+	switch {
+	case odd > half*3/10 && even < half/20:
+		return "utf-16le"
+	case even > half*3/10 && odd < half/20:
+		return "utf-16be"
+	}
+	return ""
+}
+
+// scoreJapanese は復号結果の置換文字の数と、かな・漢字らしさの得点を返します。
+// 誤った文字コードで復号すると半角カナや記号が多く現れるため減点します。
+func scoreJapanese(s string) (errors, score int) {
+	for _, r := range s {
+		switch {
+		case r == utf8.RuneError:
+			errors++
+		case unicode.In(r, unicode.Hiragana, unicode.Katakana, unicode.Han) && (r < 0xFF61 || r > 0xFF9F):
+			score++
+		case r >= 0xFF61 && r <= 0xFF9F:
+			score--
+		}
+	}
+	return errors, score
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestDetectEncoding は各文字コードで符号化した日本語の文章が正しく判定されるか確認します
+func TestDetectEncoding(t *testing.T) {
+	text := "外字の監査ログです。氏名に含まれる文字を確認してください。\n"
+
+	tests := []struct {
+		want string
+		data string
+	}{
+		{"utf-8", text},
+		{"utf-8-bom", "\xef\xbb\xbf" + text},
+		{"utf-8", "ascii only\n"},
+	}
+	for _, name := range []string{"sjis", "eucjp", "iso2022jp", "utf-16le", "utf-16be"} {
+		enc, _ := LookupEncoding(name)
+		encoded, err := enc.NewEncoder().String(text)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// UTF-16 はBOMなしでも判定できることを確認するため、エンコーダが付けるBOMを除く
+		if strings.HasPrefix(name, "utf-16") {
+			encoded = encoded[2:]
+		}
+		tests = append(tests, struct{ want, data string }{name, encoded})
+	}
+	// CP932 にない第3水準の文字(鷗)を含む場合は Shift_JIS-2004
+	sjis2004, _ := ShiftJIS2004.NewEncoder().String("森鷗外の作品を確認します。\n")
+	tests = append(tests, struct{ want, data string }{"sjis2004", sjis2004})
+
+	for _, tt := range tests {
+		if got := DetectEncoding([]byte(tt.data)); got != tt.want {
+			t.Errorf("DetectEncoding(%s) = %s", tt.want, got)
+		}
+	}
+}
+
+// TestSearchAutoEncoding は -enc auto で判定した文字コードが結果に記録されるか確認します
+func TestSearchAutoEncoding(t *testing.T) {
+	enc, _ := LookupEncoding("eucjp")
+	input, _ := enc.NewEncoder().String("氏名: 外字テスト\n")
+
+	results, err := SearchStreamWithOptions(strings.NewReader(input), []string{"外字"},
+		SearchOptions{ContextSize: 2, InputEncoding: EncodingAuto})
+	if err != nil {
+		t.Fatal(err)
+	}
+	res := results["外字"]
+	if res.Count != 1 || res.Encoding != "eucjp" || res.Snippets[0] != ": 外字テス" {
+		t.Errorf("Result = %d %s %q, want 1 hit detected as eucjp", res.Count, res.Encoding, res.Snippets)
+	}
+}
+
+// TestSearchUTF16BOM は文字コードの指定がなくても UTF-16 のBOMがあれば UTF-16 として検索するか確認します
+func TestSearchUTF16BOM(t *testing.T) {
+	for _, name := range []string{"utf-16le", "utf-16be"} {
+		enc, _ := LookupEncoding(name)
+		input, _ := enc.NewEncoder().String("氏名: 外字テスト\r\n")
+
+		results, err := SearchStreamWithOptions(strings.NewReader(input), []string{"外字"}, SearchOptions{ContextSize: 2})
+		if err != nil {
+			t.Fatal(err)
+		}
+		res := results["外字"]
+		if res.Count != 1 || res.Encoding != name || res.Snippets[0] != ": 外字テス" {
+			t.Errorf("%s: Result = %d %s %q, want 1 hit read as %s", name, res.Count, res.Encoding, res.Snippets, name)
+		}
+	}
+
+	// -enc utf16le の指定でも読める
+	enc, _ := LookupEncoding("utf16le")
+	input, _ := enc.NewEncoder().String("外字\n")
+	results, err := SearchStreamWithOptions(strings.NewReader(input), []string{"外字"}, SearchOptions{InputEncoding: "utf16le"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res := results["外字"]; res.Count != 1 || res.Encoding != "" {
+		t.Errorf("utf16le: Result = %d %q, want 1 hit without a detected encoding", res.Count, res.Encoding)
+	}
+}
+
+// TestSearchStream_UTF8BOM は先頭行のBOMを取り除いて照合し、行の位置はBOMを含めて数えるか確認します
+func TestSearchStream_UTF8BOM(t *testing.T) {
+	input := utf8BOM + "WARN a\nWARN b\n"
+	for _, opts := range []SearchOptions{
+		{ContextSize: 5, Anchor: AnchorStart},
+		{ContextSize: 5, WholeText: true},
+	} {
+		results, err := SearchStreamWithOptions(strings.NewReader(input), []string{"WARN"}, opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res := results["WARN"]
+		if res.Count != 2 || strings.Contains(res.Snippets[0], utf8BOM) {
+			t.Errorf("WholeText=%t: Result = %d %q, want 2 hits without BOM", opts.WholeText, res.Count, res.Snippets)
+		}
+		if res.Last == nil || res.Last.Offset != int64(len(utf8BOM+"WARN a\n")) {
+			t.Errorf("WholeText=%t: Last = %+v, want offset %d", opts.WholeText, res.Last, len(utf8BOM+"WARN a\n"))
+		}
+	}
+}
@@ -0,0 +1,422 @@
+package main
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/text/encoding"
+)
+
+// WorkerTokenEnv はワーカーとコーディネータが共有するトークンを指定する環境変数です。
+// コマンドライン引数はプロセス一覧から見えるため、トークンは環境変数で渡します。
+const WorkerTokenEnv = "OBJIS_WORKER_TOKEN"
+
+// ScanRequest はコーディネータからワーカーへ送る走査要求です。
+// パスはワーカーの -worker-root からの相対パス(スラッシュ区切り)で指定します。
+type ScanRequest struct {
+	Path    string        `json:"path"`
+	Queries []string      `json:"queries"`
+	Options RemoteOptions `json:"options"`
+}
+
+// RemoteOptions はネットワーク越しに受け渡せる検索オプションです
+type RemoteOptions struct {
+	ContextSize    int      `json:"context_size"`
+	FoldDuplicates bool     `json:"fold_duplicates,omitempty"`
+	SampleEvery    int      `json:"sample_every,omitempty"`
+	RedactMask     rune     `json:"redact_mask,omitempty"`
+	Escape         int      `json:"escape,omitempty"`
+	ContextAlign   string   `json:"context_align,omitempty"`
+	Romaji         bool     `json:"romaji,omitempty"`
+	Anchor         string   `json:"anchor,omitempty"`
+	ContextUnit    string   `json:"context_unit,omitempty"`
+	SentenceMax    int      `json:"sentence_max,omitempty"`
+	Filters        []string `json:"filters,omitempty"`
+	CoOccurrence   bool     `json:"cooccurrence,omitempty"`
+	InputEncoding  string   `json:"input_encoding,omitempty"`
+	CombineLines   bool     `json:"combine_lines,omitempty"`
+	SuppressMarker string   `json:"suppress_marker,omitempty"`
+	IgnoreCase     bool     `json:"ignore_case,omitempty"`
+	Normalize      string   `json:"normalize,omitempty"`
+	Engine         string   `json:"engine,omitempty"`
+	UnicodeVersion string   `json:"unicode_version,omitempty"`
+	Extended       bool     `json:"extended,omitempty"`
+	WholeText      bool     `json:"whole_text,omitempty"`
+	MaxTextBytes   int64    `json:"max_text_bytes,omitempty"`
+	CountOnly      bool     `json:"count_only,omitempty"`
+
+	MaxSnippetBytes int  `json:"max_snippet_bytes,omitempty"`
+	MaxSnippets     int  `json:"max_snippets,omitempty"`
+	FoldWidth       bool `json:"fold_width,omitempty"`
+	FoldKana        bool `json:"fold_kana,omitempty"`
+	AllowGrowth     bool `json:"allow_growth,omitempty"`
+	IgnoreIVS       bool `json:"ignore_ivs,omitempty"`
+
+	Variants        VariantTable `json:"variants,omitempty"`
+	CompareEncoding string       `json:"compare_encoding,omitempty"` // LookupEncoding で指定できる名前
+	KeepRaw         bool         `json:"keep_raw,omitempty"`
+}
+
+// NewRemoteOptions は検索オプションのうち送信可能な項目を取り出します
+func NewRemoteOptions(opts SearchOptions) RemoteOptions {
+	return RemoteOptions{
+		ContextSize:    opts.ContextSize,
+		FoldDuplicates: opts.FoldDuplicates,
+		SampleEvery:    opts.SampleEvery,
+		RedactMask:     opts.RedactMask,
+		Escape:         opts.Escape,
+		ContextAlign:   opts.ContextAlign,
+		Romaji:         opts.Romaji,
+		Anchor:         opts.Anchor,
+		ContextUnit:    opts.ContextUnit,
+		SentenceMax:    opts.SentenceMax,
+		Filters:        opts.Filters,
+		CoOccurrence:   opts.CoOccurrence,
+		InputEncoding:  opts.InputEncoding,
+		CombineLines:   opts.CombineLines,
+		SuppressMarker: opts.SuppressMarker,
+		IgnoreCase:     opts.IgnoreCase,
+		Normalize:      opts.Normalize,
+		Engine:         opts.Engine,
+		UnicodeVersion: opts.UnicodeVersion,
+		Extended:       opts.Extended,
+		WholeText:      opts.WholeText,
+		MaxTextBytes:   opts.MaxTextBytes,
+		CountOnly:      opts.CountOnly,
+
+		MaxSnippetBytes: opts.MaxSnippetBytes,
+		MaxSnippets:     opts.MaxSnippets,
+		FoldWidth:       opts.FoldWidth,
+		FoldKana:        opts.FoldKana,
+		AllowGrowth:     opts.AllowGrowth,
+		IgnoreIVS:       opts.IgnoreIVS,
+
+		Variants:        opts.Variants,
+		CompareEncoding: EncodingName(opts.CompareEncoding),
+		KeepRaw:         opts.KeepRaw,
+	}
+}
+
+// validate は受信したクエリとオプションに、ワーカー側で解釈できない値がないかを確認します。
+// 照合時には解釈できない値を文字列としての照合等に置き換えるため、弾かずに走査すると誤った結果を
+// 正しいものとしてコーディネータに返してしまいます。Run と同じ確認をしたうえで、照合方法を実際に組み立てます。
+func (o RemoteOptions) validate(queries []string) (err error) {
+	if len(queries) == 0 {
+		return errors.New("no queries")
+	}
+	if err := ValidateQueries(queries, o.Extended, o.Anchor); err != nil {
+		return err
+	}
+	for _, name := range o.Filters {
+		if _, err := lookupFilter(name); err != nil {
+			return err
+		}
+	}
+	switch {
+	case o.ContextSize < 0 || o.SampleEvery < 0 || o.SentenceMax < 0 || o.MaxSnippets < 0 || o.MaxSnippetBytes < 0 || o.MaxTextBytes < 0:
+		return errors.New("negative size option")
+	case o.Escape < EscapeNone || o.Escape > EscapeNonASCII:
+		return fmt.Errorf("invalid escape mode: %d", o.Escape)
+	case o.ContextAlign != "" && o.ContextAlign != ContextAlignChar && o.ContextAlign != ContextAlignWord:
+		return fmt.Errorf("invalid context alignment: %s", o.ContextAlign)
+	case o.ContextUnit != "" && o.ContextUnit != ContextUnitChar && o.ContextUnit != ContextUnitSentence:
+		return fmt.Errorf("invalid context unit: %s", o.ContextUnit)
+	case !ValidEngine(o.Engine):
+		return fmt.Errorf("invalid engine: %s", o.Engine)
+	}
+	switch o.Anchor {
+	case "", AnchorStart, AnchorEnd, AnchorFull:
+	default:
+		return fmt.Errorf("invalid anchor: %s", o.Anchor)
+	}
+	if _, err := LookupUnicodeVersion(o.UnicodeVersion); err != nil {
+		return err
+	}
+	if o.Normalize != "" {
+		if _, err := LookupNormalization(o.Normalize); err != nil {
+			return err
+		}
+	}
+	for _, name := range []string{o.InputEncoding, o.CompareEncoding} {
+		if name != "" && name != EncodingAuto {
+			if _, err := LookupEncoding(name); err != nil {
+				return err
+			}
+		}
+	}
+	if o.CompareEncoding == EncodingAuto {
+		return errors.New("compare encoding must not be auto")
+	}
+
+	// 組み合わせによっては照合方法を組み立てられないため、実際に組み立てて確かめる
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("invalid query: %v", r)
+		}
+	}()
+	opts := o.SearchOptions()
+	for _, q := range queries {
+		newMatcher(q, opts)
+	}
+	return nil
+}
+
+// SearchOptions は受信したオプションを検索オプションに戻します(validate で確認済みであること)
+func (o RemoteOptions) SearchOptions() SearchOptions {
+	var compare encoding.Encoding
+	if o.CompareEncoding != "" {
+		compare, _ = LookupEncoding(o.CompareEncoding)
+	}
+	return SearchOptions{
+		ContextSize:    o.ContextSize,
+		FoldDuplicates: o.FoldDuplicates,
+		SampleEvery:    o.SampleEvery,
+		RedactMask:     o.RedactMask,
+		Escape:         o.Escape,
+		ContextAlign:   o.ContextAlign,
+		Romaji:         o.Romaji,
+		Anchor:         o.Anchor,
+		ContextUnit:    o.ContextUnit,
+		SentenceMax:    o.SentenceMax,
+		Filters:        o.Filters,
+		CoOccurrence:   o.CoOccurrence,
+		InputEncoding:  o.InputEncoding,
+		CombineLines:   o.CombineLines,
+		SuppressMarker: o.SuppressMarker,
+		IgnoreCase:     o.IgnoreCase,
+		Normalize:      o.Normalize,
+		Engine:         o.Engine,
+		UnicodeVersion: o.UnicodeVersion,
+		Extended:       o.Extended,
+		WholeText:      o.WholeText,
+		MaxTextBytes:   o.MaxTextBytes,
+		CountOnly:      o.CountOnly,
+
+		MaxSnippetBytes: o.MaxSnippetBytes,
+		MaxSnippets:     o.MaxSnippets,
+		FoldWidth:       o.FoldWidth,
+		FoldKana:        o.FoldKana,
+		AllowGrowth:     o.AllowGrowth,
+		IgnoreIVS:       o.IgnoreIVS,
+
+		Variants:        o.Variants,
+		CompareEncoding: compare,
+		KeepRaw:         o.KeepRaw,
+	}
+}
+
+// ScanResponse はワーカーが返す走査結果です
+type ScanResponse struct {
+	Path    string                   `json:"path"`
+	Results map[string]*SearchResult `json:"results"`
+}
+
+// errOutsideRoot は走査要求のパスがワーカーのルートディレクトリの外を指していることを表します
+var errOutsideRoot = errors.New("path must be relative to the worker root")
+
+// checkWorkerPath は走査要求のパスがルートディレクトリからの相対パスで、その外に出ないかを確認します。
+// 絶対パスや ".." を含むパスは拒否します(シンボリックリンクによる脱出は RootOpener で防ぐ)。
+func checkWorkerPath(path string) error {
+	if !filepath.IsLocal(filepath.FromSlash(path)) {
+		return fmt.Errorf("%w: %s", errOutsideRoot, path)
+	}
+	return nil
+}
+
+// RootOpener は root の下のファイルのみを open で開く関数を返します。
+// シンボリックリンクを解決した実際のパスが root の外になる場合も開きません。
+func RootOpener(root string, open func(string) (io.ReadCloser, error)) (func(string) (io.ReadCloser, error), error) {
+	realRoot, err := filepath.EvalSymlinks(root)
+	if err != nil {
+		return nil, err
+	}
+	if info, err := os.Stat(realRoot); err != nil {
+		return nil, err
+	} else if !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", root)
+	}
+	return func(path string) (io.ReadCloser, error) {
+		if err := checkWorkerPath(path); err != nil {
+			return nil, err
+		}
+		real, err := filepath.EvalSymlinks(filepath.Join(realRoot, filepath.FromSlash(path)))
+		if err != nil {
+			return nil, err
+		}
+		if rel, err := filepath.Rel(realRoot, real); err != nil || !filepath.IsLocal(rel) {
+			return nil, fmt.Errorf("%w: %s", errOutsideRoot, path)
+		}
+		return open(real)
+	}, nil
+}
+
+// RequireToken は Authorization: Bearer ヘッダーのトークンが一致する要求のみを h に渡すハンドラを返します
+func RequireToken(token string, h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+// workerListenAddr はホストを省略した待ち受けアドレス(":8080")をループバックに限定します。
+// 他のホストから受け付けるには "0.0.0.0:8080" のように明示します。
+func workerListenAddr(addr string) string {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil || host != "" {
+		return addr
+	}
+	return net.JoinHostPort("127.0.0.1", port)
+}
+
+// DefaultWorkerTimeout は -worker-timeout の既定値です。大きなファイルの走査も1回の要求で終わる長さにします。
+const DefaultWorkerTimeout = 30 * time.Minute
+
+// workerHeaderTimeout はワーカーが要求のヘッダーを待つ時間です(接続だけ開いて送らない相手を切る)
+const workerHeaderTimeout = 10 * time.Second
+
+// newWorkerServer はワーカーの HTTP サーバーを返します。
+// 走査要求は同期的に結果を返すため、書き込みの期限は timeout(コーディネータの待ち時間と同じ)にします。
+func newWorkerServer(addr string, h http.Handler, timeout time.Duration) *http.Server {
+	return &http.Server{
+		Addr:              addr,
+		Handler:           h,
+		ReadHeaderTimeout: workerHeaderTimeout,
+		ReadTimeout:       time.Minute,
+		WriteTimeout:      timeout,
+		IdleTimeout:       2 * time.Minute,
+	}
+}
+
+// WorkerHandler は分散走査のワーカーとして POST /scan を処理するハンドラを返します。
+// open にはルートディレクトリの下のみを開く関数(RootOpener)を渡します。認証は RequireToken で行います。
+func WorkerHandler(open func(string) (io.ReadCloser, error)) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /scan", func(w http.ResponseWriter, r *http.Request) {
+		var req ScanRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, fmt.Sprintf("invalid request: %v", err), http.StatusBadRequest)
+			return
+		}
+		if err := checkWorkerPath(req.Path); err != nil {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		if err := req.Options.validate(req.Queries); err != nil {
+			http.Error(w, fmt.Sprintf("invalid request: %v", err), http.StatusBadRequest)
+			return
+		}
+
+		f, err := open(req.Path)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("failed to open %s: %v", req.Path, err), http.StatusNotFound)
+			return
+		}
+		defer f.Close()
+
+		// 圧縮ファイルも展開して検索する(暗号化ZIPのパスワードは問い合わせられない)
+		results, err := searchFile(f, req.Path, req.Queries, req.Options.SearchOptions(), func() (string, error) {
+			return "", errors.New("encrypted zip entries are not supported by workers")
+		})
+		if err != nil {
+			http.Error(w, fmt.Sprintf("search failed: %v", err), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(ScanResponse{Path: req.Path, Results: results})
+	})
+	return mux
+}
+
+// Coordinate はファイル一覧をワーカーに振り分けて走査させ、結果をパスの順に統合します。
+// 各ワーカーは空き次第次のファイルを取りに行くため、ファイルサイズの偏りがあっても負荷が均されます。
+// token はワーカーと共有するトークンです。
+func Coordinate(client *http.Client, token string, workers, paths, queries []string, opts RemoteOptions) (map[string]*SearchResult, error) {
+	if len(workers) == 0 {
+		return nil, fmt.Errorf("no workers specified")
+	}
+
+	responses := make([]map[string]*SearchResult, len(paths))
+	errs := make([]error, len(paths))
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for _, worker := range workers {
+		wg.Add(1)
+		go func(base string) {
+			defer wg.Done()
+			for i := range jobs {
+				responses[i], errs[i] = requestScan(client, base, token, ScanRequest{Path: paths[i], Queries: queries, Options: opts})
+			}
+		}(workerURL(worker))
+	}
+	for i := range paths {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	merged := NewResults(queries)
+	for i, path := range paths {
+		if errs[i] != nil {
+			return nil, fmt.Errorf("%s: %w", path, errs[i])
+		}
+		MergeResults(merged, responses[i], path, opts.MaxSnippets)
+	}
+	return merged, nil
+}
+
+// requestScan は1つのワーカーに走査を依頼します
+func requestScan(client *http.Client, base, token string, req ScanRequest) (map[string]*SearchResult, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	hreq, err := http.NewRequest(http.MethodPost, base+"/scan", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	hreq.Header.Set("Content-Type", "application/json")
+	hreq.Header.Set("Authorization", "Bearer "+token)
+	resp, err := client.Do(hreq)
+	if err != nil {
+		return nil, fmt.Errorf("worker %s: %w", base, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("worker %s: %s: %s", base, resp.Status, strings.TrimSpace(string(msg)))
+	}
+
+	var scan ScanResponse
+	if err := json.NewDecoder(resp.Body).Decode(&scan); err != nil {
+		return nil, fmt.Errorf("worker %s: invalid response: %w", base, err)
+	}
+	return scan.Results, nil
+}
+
+// workerURL は "host:port" 形式の指定にスキームを補います
+func workerURL(worker string) string {
+	if strings.Contains(worker, "://") {
+		return strings.TrimSuffix(worker, "/")
+	}
+	return "http://" + strings.TrimSuffix(worker, "/")
+}
@@ -0,0 +1,238 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestCoordinate はファイル一覧がワーカーに分配され、結果がパス順に統合されるか確認します
+func TestCoordinate(t *testing.T) {
+	files := map[string]string{
+		"a.log": "WARN a1\nINFO\n",
+		"b.log": "WARN b1\nWARN b2\n",
+		"c.log": "ERROR\n",
+	}
+	open := func(path string) (io.ReadCloser, error) {
+		return io.NopCloser(strings.NewReader(files[path])), nil
+	}
+
+	w1 := httptest.NewServer(RequireToken("secret", WorkerHandler(open)))
+	defer w1.Close()
+	w2 := httptest.NewServer(RequireToken("secret", WorkerHandler(open)))
+	defer w2.Close()
+
+	results, err := Coordinate(w1.Client(), "secret", []string{w1.URL, w2.URL}, []string{"a.log", "b.log", "c.log"},
+		[]string{"WARN", "ERROR"}, RemoteOptions{ContextSize: 2})
+	if err != nil {
+		t.Fatalf("Coordinate() error = %v", err)
+	}
+
+	if got := results["WARN"].Count; got != 3 {
+		t.Errorf("WARN count = %d, want 3", got)
+	}
+	if got := results["ERROR"].Count; got != 1 {
+		t.Errorf("ERROR count = %d, want 1", got)
+	}
+	if got := results["WARN"].Lines; got != 5 {
+		t.Errorf("Total lines = %d, want 5", got)
+	}
+
+	wantPaths := []string{"a.log", "b.log", "b.log"}
+	for i, info := range results["WARN"].Infos {
+		if info.Path != wantPaths[i] {
+			t.Errorf("Snippet %d path = %s, want %s", i, info.Path, wantPaths[i])
+		}
+	}
+}
+
+// TestWorkerRoot はワーカーのルートディレクトリの外を指す走査要求が拒否されるか確認します
+func TestWorkerRoot(t *testing.T) {
+	dir := t.TempDir()
+	rootDir := filepath.Join(dir, "root")
+	if err := os.Mkdir(rootDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	os.WriteFile(filepath.Join(rootDir, "a.log"), []byte("WARN a\n"), 0o644)
+	os.WriteFile(filepath.Join(dir, "secret.txt"), []byte("WARN secret\n"), 0o644)
+	symlinked := os.Symlink(filepath.Join(dir, "secret.txt"), filepath.Join(rootDir, "link.txt")) == nil
+
+	open, err := RootOpener(rootDir, func(path string) (io.ReadCloser, error) { return os.Open(path) })
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(RequireToken("secret", WorkerHandler(open)))
+	defer srv.Close()
+
+	if _, err := Coordinate(srv.Client(), "secret", []string{srv.URL}, []string{"a.log"}, []string{"WARN"}, RemoteOptions{}); err != nil {
+		t.Errorf("Coordinate(a.log) error = %v", err)
+	}
+	escapes := []string{"../secret.txt", filepath.Join(dir, "secret.txt"), "sub/../../secret.txt"}
+	if symlinked {
+		escapes = append(escapes, "link.txt")
+	}
+	for _, path := range escapes {
+		if _, err := Coordinate(srv.Client(), "secret", []string{srv.URL}, []string{path}, []string{"WARN"}, RemoteOptions{}); err == nil {
+			t.Errorf("Coordinate(%s) should be rejected as outside the worker root", path)
+		}
+	}
+}
+
+// TestWorkerToken はトークンのない、または一致しない要求が拒否されるか確認します
+func TestWorkerToken(t *testing.T) {
+	opened := false
+	open := func(string) (io.ReadCloser, error) {
+		opened = true
+		return io.NopCloser(strings.NewReader("WARN\n")), nil
+	}
+	srv := httptest.NewServer(RequireToken("secret", WorkerHandler(open)))
+	defer srv.Close()
+
+	for _, auth := range []string{"", "Bearer wrong", "secret"} {
+		req, _ := http.NewRequest(http.MethodPost, srv.URL+"/scan", strings.NewReader(`{"path":"a.log","queries":["WARN"]}`))
+		if auth != "" {
+			req.Header.Set("Authorization", auth)
+		}
+		resp, err := srv.Client().Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusUnauthorized {
+			t.Errorf("Authorization %q: status = %d, want 401", auth, resp.StatusCode)
+		}
+	}
+	if opened {
+		t.Error("Unauthorized requests should not open files")
+	}
+}
+
+func TestWorkerListenAddr(t *testing.T) {
+	tests := map[string]string{
+		":8080":          "127.0.0.1:8080",
+		"0.0.0.0:8080":   "0.0.0.0:8080",
+		"localhost:8080": "localhost:8080",
+		"[::1]:8080":     "[::1]:8080",
+	}
+	for addr, want := range tests {
+		if got := workerListenAddr(addr); got != want {
+			t.Errorf("workerListenAddr(%q) = %q, want %q", addr, got, want)
+		}
+	}
+}
+
+// TestRun_CoordinatorOptions は -variants・-compare-enc・-hex-snippets がワーカーに渡り、ローカルでの検索と同じ結果になるか確認します
+func TestRun_CoordinatorOptions(t *testing.T) {
+	files := map[string]string{
+		"a.txt":        "髙橋と高田\n",
+		"variants.txt": "高 髙\n",
+	}
+	open := func(path string) (io.ReadCloser, error) {
+		s, ok := files[path]
+		if !ok {
+			return nil, os.ErrNotExist
+		}
+		return io.NopCloser(strings.NewReader(s)), nil
+	}
+	worker := httptest.NewServer(RequireToken("secret", WorkerHandler(open)))
+	defer worker.Close()
+
+	run := func(args ...string) JSONResult {
+		t.Helper()
+		stdout := new(bytes.Buffer)
+		args = append([]string{"app", "-q", "高", "-variants", "variants.txt", "-compare-enc", "sjis", "-hex-snippets", "-format", "json"}, args...)
+		getenv := func(key string) string {
+			if key == WorkerTokenEnv {
+				return "secret"
+			}
+			return ""
+		}
+		if code := Run(AppContext{Args: args, Stdout: stdout, Stderr: io.Discard, FileReader: open, Getenv: getenv}); code != ExitMatch {
+			t.Fatalf("Run(%v) exit code = %d", args, code)
+		}
+		var report JSONReport
+		if err := json.Unmarshal(stdout.Bytes(), &report); err != nil {
+			t.Fatal(err)
+		}
+		return report.Results[0]
+	}
+
+	local := run("a.txt")
+	remote := run("-coordinator", "-workers", worker.URL, "a.txt")
+	if remote.Count != local.Count || remote.Variants["髙"] != 1 || !maps.Equal(remote.Variants, local.Variants) {
+		t.Errorf("remote = %d %v, local = %d %v", remote.Count, remote.Variants, local.Count, local.Variants)
+	}
+	if len(remote.Snippets) == 0 || remote.Snippets[0].Hex == "" || remote.Snippets[0].Converted != local.Snippets[0].Converted || remote.Snippets[0].Hex != local.Snippets[0].Hex {
+		t.Errorf("remote snippets = %+v, local = %+v", remote.Snippets, local.Snippets)
+	}
+}
+
+// TestWorkerValidate はワーカーが解釈できないクエリ・オプションの走査要求を 400 で拒否するか確認します
+func TestWorkerValidate(t *testing.T) {
+	open := func(string) (io.ReadCloser, error) { return io.NopCloser(strings.NewReader("WARN\n")), nil }
+	srv := httptest.NewServer(WorkerHandler(open))
+	defer srv.Close()
+
+	tests := []struct {
+		name    string
+		queries []string
+		opts    RemoteOptions
+	}{
+		{"no queries", nil, RemoteOptions{}},
+		{"regexp", []string{"re:(unclosed"}, RemoteOptions{}},
+		{"extended", []string{"(unclosed"}, RemoteOptions{Extended: true}},
+		{"class", []string{"@class:nope"}, RemoteOptions{}},
+		{"anchored class", []string{"@class:non-jis90"}, RemoteOptions{Anchor: AnchorStart}},
+		{"filter", []string{"WARN"}, RemoteOptions{Filters: []string{"nope"}}},
+		{"normalize", []string{"WARN"}, RemoteOptions{Normalize: "nfx"}},
+		{"engine", []string{"WARN"}, RemoteOptions{Engine: "nope"}},
+		{"unicode version", []string{"WARN"}, RemoteOptions{UnicodeVersion: "1.0"}},
+		{"input encoding", []string{"WARN"}, RemoteOptions{InputEncoding: "nope"}},
+		{"context", []string{"WARN"}, RemoteOptions{ContextSize: -1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, _ := json.Marshal(ScanRequest{Path: "a.log", Queries: tt.queries, Options: tt.opts})
+			resp, err := srv.Client().Post(srv.URL+"/scan", "application/json", bytes.NewReader(body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != http.StatusBadRequest {
+				t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusBadRequest)
+			}
+		})
+	}
+}
+
+// TestRun_CoordinatorRejects はワーカーで行えない出力と、期限のない -worker-timeout を拒否するか確認します
+func TestRun_CoordinatorRejects(t *testing.T) {
+	getenv := func(string) string { return "secret" }
+	for _, tt := range []struct {
+		args []string
+		want string
+	}{
+		{[]string{"-lines-out", "hits.txt"}, "cannot be used with -passthrough or -lines-out"},
+		{[]string{"-passthrough", "matched"}, "cannot be used with -passthrough or -lines-out"},
+		{[]string{"-worker-timeout", "0"}, "-worker-timeout must be positive"},
+	} {
+		args := append(append([]string{"app", "-q", "WARN", "-coordinator", "-workers", "127.0.0.1:1"}, tt.args...), "a.log")
+		stderr := new(bytes.Buffer)
+		if code := Run(AppContext{Args: args, Stdout: io.Discard, Stderr: stderr, Getenv: getenv}); code != ExitError || !strings.Contains(stderr.String(), tt.want) {
+			t.Errorf("Run(%v) exit code = %d, want %d with %q\n Stderr: %s", args, code, ExitError, tt.want, stderr.String())
+		}
+	}
+
+	srv := newWorkerServer("127.0.0.1:0", http.NotFoundHandler(), time.Minute)
+	if srv.ReadHeaderTimeout <= 0 || srv.ReadTimeout <= 0 || srv.WriteTimeout != time.Minute {
+		t.Errorf("worker server timeouts = %v %v %v", srv.ReadHeaderTimeout, srv.ReadTimeout, srv.WriteTimeout)
+	}
+}
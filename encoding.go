@@ -0,0 +1,95 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/encoding/unicode"
+)
+
+// ConversionMarker は変換先の文字コードで表現できない文字の代わりに表示する文字です(げた記号)
+const ConversionMarker = '〓'
+
+// encodings は名前で指定できる文字コードの一覧です
+var encodings = map[string]encoding.Encoding{
+	"sjis":        japanese.ShiftJIS,
+	"shift_jis":   japanese.ShiftJIS,
+	"cp932":       japanese.ShiftJIS,
+	"eucjp":       japanese.EUCJP,
+	"euc-jp":      japanese.EUCJP,
+	"iso2022jp":   ISO2022JP,
+	"iso-2022-jp": ISO2022JP,
+	"jis":         ISO2022JP,
+
+	"utf-8-bom": unicode.UTF8BOM,
+	"utf-16le":  unicode.UTF16(unicode.LittleEndian, unicode.UseBOM),
+	"utf-16be":  unicode.UTF16(unicode.BigEndian, unicode.UseBOM),
+	"utf16le":   unicode.UTF16(unicode.LittleEndian, unicode.UseBOM),
+	"utf16be":   unicode.UTF16(unicode.BigEndian, unicode.UseBOM),
+
+	"sjis2004":       ShiftJIS2004,
+	"shift_jis-2004": ShiftJIS2004,
+	"shift_jisx0213": ShiftJIS2004,
+}
+
+// LookupEncoding は名前(大文字小文字を区別しない)に対応する文字コードを返します
+func LookupEncoding(name string) (encoding.Encoding, error) {
+	enc, ok := encodings[strings.ToLower(name)]
+	if !ok {
+		names := make([]string, 0, len(encodings))
+		for n := range encodings {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("unknown encoding: %s (supported: %s)", name, strings.Join(names, ", "))
+	}
+	return enc, nil
+}
+
+// EncodingName は LookupEncoding で enc を得られる名前を返します(一覧にない文字コードは空)。
+// 別名が複数ある場合は辞書順で最初の名前を返します。
+func EncodingName(enc encoding.Encoding) string {
+	names := make([]string, 0, len(encodings))
+	for n, e := range encodings {
+		if e == enc {
+			names = append(names, n)
+		}
+	}
+	if len(names) == 0 {
+		return ""
+	}
+	sort.Strings(names)
+	return names[0]
+}
+
+// IsUTF16 は文字コードの名前が UTF-16 (utf-16le / utf16be 等)を指すかを返します
+func IsUTF16(name string) bool {
+	name = strings.ToLower(name)
+	return strings.HasPrefix(name, "utf-16") || strings.HasPrefix(name, "utf16")
+}
+
+// SimulateConversion は s を enc に変換して読み戻した結果を返します。
+// 変換できない文字は ConversionMarker に置き換えます。
+func SimulateConversion(s string, enc encoding.Encoding) string {
+	encoder := enc.NewEncoder()
+	decoder := enc.NewDecoder()
+
+	var sb strings.Builder
+	for _, r := range s {
+		encoded, err := encoder.String(string(r))
+		if err != nil {
+			sb.WriteRune(ConversionMarker)
+			continue
+		}
+		decoded, err := decoder.String(encoded)
+		if err != nil {
+			sb.WriteRune(ConversionMarker)
+			continue
+		}
+		sb.WriteString(decoded)
+	}
+	return sb.String()
+}
@@ -0,0 +1,222 @@
+package main
+
+import (
+	"bytes"
+	"math"
+)
+
+// 照合エンジン(-engine)。いずれも結果は同じで、文字列クエリの事前照合の方法のみが異なります。
+const (
+	EngineAuto        = "auto"         // クエリの数と種類から選ぶ
+	EngineNaive       = "naive"        // クエリごとに行を文字列として照合する
+	EngineBytes       = "bytes"        // 読み込んだバイト列のままクエリごとに事前照合する
+	EngineAhoCorasick = "aho-corasick" // 全クエリを1回の走査で事前照合する
+)
+
+// autoAhoCorasickRules は auto で Aho-Corasick を選ぶ基準です(BenchmarkEngines の計測結果による)。
+// 行の平均バイト数が maxLineBytes 未満で、文字列クエリが minQueries 個以上なら Aho-Corasick を、
+// それ以外はクエリごとの bytes.Contains を選ぶ。行が長いほど bytes.Contains のSIMD照合が有利になる。
+var autoAhoCorasickRules = []struct{ maxLineBytes, minQueries int }{
+	{128, 8},
+	{1024, 48},
+	{math.MaxInt, 128},
+}
+
+// autoSampleLines は auto で行の平均バイト数を計測する行数です
+const autoSampleLines = 256
+
+// ValidEngine はエンジン名が正しいかを返します
+func ValidEngine(name string) bool {
+	switch name {
+	case "", EngineAuto, EngineNaive, EngineBytes, EngineAhoCorasick:
+		return true
+	}
+	return false
+}
+
+// prefilter はヒットし得ないクエリの照合と、どのクエリもヒットし得ない行の文字列への変換を省きます
+type prefilter interface {
+	// candidates は行でヒットし得るクエリの possible に印を付け、1つでもあれば true を返します
+	candidates(line []byte, possible []bool) bool
+}
+
+// newPrefilter はエンジン名に応じた事前照合を返します(nilなら全クエリを照合する)。
+// 前処理(-filter)を行う場合は元のバイト列で判定できないため、常にnilを返します。
+func newPrefilter(name string, matchers []matcher, opts SearchOptions) prefilter {
+	if len(opts.Filters) > 0 {
+		return nil
+	}
+	literals := make([][]byte, len(matchers))
+	n := 0
+	for i, m := range matchers {
+		// 大文字・小文字の無視や正規化など、文字列の完全一致以外の照合は事前照合できない
+		if lm, ok := m.(literalMatcher); ok && lm != "" {
+			literals[i] = []byte(lm)
+			n++
+		}
+	}
+	if n == 0 {
+		return nil
+	}
+
+	if name == EngineAuto {
+		if n < autoAhoCorasickRules[0].minQueries {
+			return bytesPrefilter(literals)
+		}
+		return &autoPrefilter{bytes: bytesPrefilter(literals), literals: literals, queries: n}
+	}
+	switch name {
+	case EngineBytes:
+		return bytesPrefilter(literals)
+	case EngineAhoCorasick:
+		return newAhoCorasick(literals)
+	}
+	return nil
+}
+
+// bytesPrefilter はクエリごとにバイト列のまま bytes.Contains で事前照合します(nilの要素は常に照合する)
+type bytesPrefilter [][]byte
+
+func (p bytesPrefilter) candidates(line []byte, possible []bool) bool {
+	hit := false
+	for i, lit := range p {
+		possible[i] = lit == nil || bytes.Contains(line, lit)
+		hit = hit || possible[i]
+	}
+	return hit
+}
+
+// autoPrefilter は最初の autoSampleLines 行を bytes で照合しながら行の平均バイト数を計測し、
+// 以降はその長さとクエリ数に応じたエンジンで照合します
+type autoPrefilter struct {
+	bytes    bytesPrefilter
+	literals [][]byte
+	queries  int // 文字列クエリの数
+	chosen   prefilter
+	lines    int
+	total    int // 計測した行のバイト数の合計
+}
+
+func (a *autoPrefilter) candidates(line []byte, possible []bool) bool {
+	if a.chosen != nil {
+		return a.chosen.candidates(line, possible)
+	}
+	a.lines++
+	a.total += len(line)
+	if a.lines == autoSampleLines {
+		a.chosen = a.bytes
+		avg := a.total / a.lines
+		for _, rule := range autoAhoCorasickRules {
+			if avg < rule.maxLineBytes {
+				if a.queries >= rule.minQueries {
+					a.chosen = newAhoCorasick(a.literals)
+				}
+				break
+			}
+		}
+	}
+	return a.bytes.candidates(line, possible)
+}
+
+// ahoCorasick は複数の文字列クエリを行の1回の走査で事前照合するオートマトンです。
+// 失敗リンクを解決済みの遷移表(DFA)として持ち、クエリに現れないバイトは1つの列にまとめます。
+type ahoCorasick struct {
+	class   [256]uint8 // バイトから遷移表の列への対応(0はクエリに現れないバイト)
+	width   int        // 遷移表の列数
+	delta   []int32    // 状態 s・列 c からの遷移先 delta[s*width+c]
+	out     [][]int    // 状態ごとに一致が確定するクエリの添字(失敗リンク先の分も含む)
+	always  []int      // 事前照合できない(常に照合する)クエリの添字
+	literal int        // 事前照合するクエリの数
+}
+
+func newAhoCorasick(literals [][]byte) *ahoCorasick {
+	ac := &ahoCorasick{width: 1}
+	for qi, lit := range literals {
+		if lit == nil {
+			ac.always = append(ac.always, qi)
+			continue
+		}
+		ac.literal++
+		for _, b := range lit {
+			if ac.class[b] == 0 {
+				ac.class[b] = uint8(ac.width)
+				ac.width++
+			}
+		}
+	}
+
+	// トライを作る(遷移がない箇所は-1)
+	newState := func() int32 {
+		for c := 0; c < ac.width; c++ {
+			ac.delta = append(ac.delta, -1)
+		}
+		ac.out = append(ac.out, nil)
+		return int32(len(ac.out) - 1)
+	}
+	newState()
+	for qi, lit := range literals {
+		if lit == nil {
+			continue
+		}
+		cur := int32(0)
+		for _, b := range lit {
+			i := int(cur)*ac.width + int(ac.class[b])
+			if ac.delta[i] < 0 {
+				next := newState()
+				ac.delta[i] = next
+			}
+			cur = ac.delta[i]
+		}
+		ac.out[cur] = append(ac.out[cur], qi)
+	}
+
+	// 幅優先で失敗リンクを求め、遷移のない箇所を失敗リンク先の遷移で埋める
+	fail := make([]int32, len(ac.out))
+	var queue []int32
+	for c := 0; c < ac.width; c++ {
+		if next := ac.delta[c]; next < 0 {
+			ac.delta[c] = 0
+		} else {
+			queue = append(queue, next)
+		}
+	}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		ac.out[cur] = append(ac.out[cur], ac.out[fail[cur]]...)
+		for c := 0; c < ac.width; c++ {
+			i := int(cur)*ac.width + c
+			fallback := ac.delta[int(fail[cur])*ac.width+c]
+			if next := ac.delta[i]; next < 0 {
+				ac.delta[i] = fallback
+			} else {
+				fail[next] = fallback
+				queue = append(queue, next)
+			}
+		}
+	}
+	return ac
+}
+
+func (ac *ahoCorasick) candidates(line []byte, possible []bool) bool {
+	clear(possible)
+	for _, qi := range ac.always {
+		possible[qi] = true
+	}
+	found := 0
+	cur := int32(0)
+	for _, b := range line {
+		cur = ac.delta[int(cur)*ac.width+int(ac.class[b])]
+		for _, qi := range ac.out[cur] {
+			if !possible[qi] {
+				possible[qi] = true
+				found++
+			}
+		}
+		// すべてのクエリが見つかれば残りを走査する必要はない
+		if found == ac.literal {
+			break
+		}
+	}
+	return found > 0 || len(ac.always) > 0
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestAhoCorasick は重なり合う・接尾辞を共有するクエリを1回の走査で見つけられるか確認します
+func TestAhoCorasick(t *testing.T) {
+	literals := [][]byte{[]byte("he"), []byte("she"), []byte("his"), []byte("hers"), nil, []byte("外字")}
+	ac := newAhoCorasick(literals)
+
+	tests := []struct {
+		line string
+		want []bool
+	}{
+		{"ushers", []bool{true, true, false, true, true, false}},
+		{"this 外字", []bool{false, false, true, false, true, true}},
+		{"none", []bool{false, false, false, false, true, false}},
+	}
+	for _, tt := range tests {
+		possible := make([]bool, len(literals))
+		ac.candidates([]byte(tt.line), possible)
+		if !reflect.DeepEqual(possible, tt.want) {
+			t.Errorf("candidates(%q) = %v, want %v", tt.line, possible, tt.want)
+		}
+	}
+}
+
+// TestSearchStream_Engines はどのエンジンでも同じ結果になるか確認します
+func TestSearchStream_Engines(t *testing.T) {
+	content := benchInput(200, 80, 0.1) + "外字 WARN ERROR\nINFO\n"
+	queries := append(benchQueries(12), "re:WA.N", "外字")
+
+	var want map[string]*SearchResult
+	for _, engine := range []string{EngineNaive, EngineBytes, EngineAhoCorasick, EngineAuto} {
+		opts := SearchOptions{ContextSize: 5, Engine: engine, FoldDuplicates: true}
+		got, err := SearchStreamWithOptions(strings.NewReader(content), queries, opts)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", engine, err)
+		}
+		if want == nil {
+			want = got
+			continue
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: results differ from naive", engine)
+		}
+	}
+}
+
+// TestAutoPrefilter は行の平均バイト数とクエリ数から事前照合の方法を選ぶか確認します
+func TestAutoPrefilter(t *testing.T) {
+	matchers := make([]matcher, 16)
+	for i, q := range benchQueries(len(matchers)) {
+		matchers[i] = literalMatcher(q)
+	}
+	possible := make([]bool, len(matchers))
+
+	for _, tt := range []struct {
+		line   string
+		wantAC bool
+	}{
+		{strings.Repeat("x", 40), true},
+		{strings.Repeat("x", 400), false},
+	} {
+		pre := newPrefilter(EngineAuto, matchers, SearchOptions{}).(*autoPrefilter)
+		for i := 0; i < autoSampleLines; i++ {
+			pre.candidates([]byte(tt.line), possible)
+		}
+		_, isAC := pre.chosen.(*ahoCorasick)
+		if isAC != tt.wantAC {
+			t.Errorf("line length %d: chose %T", len(tt.line), pre.chosen)
+		}
+	}
+
+	if pre := newPrefilter(EngineAuto, matchers[:2], SearchOptions{}); reflect.TypeOf(pre) != reflect.TypeOf(bytesPrefilter(nil)) {
+		t.Errorf("few queries should use bytes, got %T", pre)
+	}
+	if pre := newPrefilter(EngineAuto, matchers, SearchOptions{Filters: []string{"ansi"}}); pre != nil {
+		t.Errorf("filters should disable prefiltering, got %T", pre)
+	}
+}
+
+// benchQueries はベンチマーク用に n 個の文字列クエリを生成します
+func benchQueries(n int) []string {
+	queries := make([]string, n)
+	for i := range queries {
+		queries[i] = fmt.Sprintf("KEY%03d", i)
+	}
+	return queries
+}
+
+// benchInput は1行 width 文字の行を lines 行生成します。density の割合の行にクエリ KEY000 を含めます。
+func benchInput(lines, width int, density float64) string {
+	var sb strings.Builder
+	filler := strings.Repeat("abcdefghij 漢字かなカナ ", width/10+1)
+	hitEvery := 0
+	if density > 0 {
+		hitEvery = int(1 / density)
+	}
+	for i := 0; i < lines; i++ {
+		line := []rune(filler)[:width]
+		if hitEvery > 0 && i%hitEvery == 0 {
+			copy(line[width/2:], []rune("KEY000"))
+		}
+		sb.WriteString(string(line))
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
+
+// BenchmarkEngines は行の長さ・クエリ数・ヒット密度ごとに各エンジンの走査速度を比較します。
+// auto の判定基準(autoAhoCorasickRules)はこの結果に基づきます。
+//
+//	go test -run '^$' -bench Engines
+func BenchmarkEngines(b *testing.B) {
+	for _, width := range []int{40, 200, 2000} {
+		for _, nq := range []int{1, 4, 8, 16, 32, 64, 128} {
+			for _, density := range []float64{0, 0.01, 0.5} {
+				input := benchInput(20000*40/width, width, density)
+				queries := benchQueries(nq)
+				for _, engine := range []string{EngineNaive, EngineBytes, EngineAhoCorasick} {
+					name := fmt.Sprintf("width=%d/queries=%d/density=%g/%s", width, nq, density, engine)
+					b.Run(name, func(b *testing.B) {
+						b.SetBytes(int64(len(input)))
+						opts := SearchOptions{ContextSize: DefaultContextSize, Engine: engine}
+						for i := 0; i < b.N; i++ {
+							if _, err := SearchStreamWithOptions(strings.NewReader(input), queries, opts); err != nil {
+								b.Fatal(err)
+							}
+						}
+					})
+				}
+			}
+		}
+	}
+}
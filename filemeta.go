@@ -0,0 +1,161 @@
+package main
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// FileMeta は走査した入力ファイルの情報です。
+// レポートを監査対象のファイルの版と結び付けるために機械可読出力へ含めます。
+type FileMeta struct {
+	Path     string     `json:"path"`
+	Size     int64      `json:"size"`
+	Modified *time.Time `json:"modified,omitempty"`
+	SHA256   string     `json:"sha256"`
+	Verified bool       `json:"verified,omitempty"` // 期待値との照合に成功した
+
+	Ownership *FileOwnership `json:"ownership,omitempty"` // -file-owner 指定時のみ
+}
+
+// FileOwnership はファイルの所有者と権限です。
+// 共有ドライブの監査で、見つかった問題をファイルの担当部署に振り分けるために出力します。
+type FileOwnership struct {
+	Owner string `json:"owner,omitempty"` // 取得できない環境では空
+	Group string `json:"group,omitempty"`
+	Mode  string `json:"mode"` // "-rw-r-----" の形式
+}
+
+// String は所有者と権限を "owner:group -rw-r-----" の形式で返します
+func (o *FileOwnership) String() string {
+	if o.Owner == "" {
+		return o.Mode
+	}
+	return o.Owner + ":" + o.Group + " " + o.Mode
+}
+
+// AnnotateOwnership は結果のファイルごとの該当数と入力ファイルの情報に、所有者と権限を付けます。
+// ZIPのエントリにはアーカイブの所有者と権限を付け、情報を取得できないファイルには付けません。
+func AnnotateOwnership(results map[string]*SearchResult, file *FileMeta, stat func(string) (os.FileInfo, error)) {
+	cache := make(map[string]*FileOwnership)
+	lookup := func(path string) *FileOwnership {
+		archive, _ := splitZipEntry(path)
+		if o, ok := cache[archive]; ok {
+			return o
+		}
+		var o *FileOwnership
+		if archive != StdinPath {
+			if info, err := stat(archive); err == nil {
+				o = fileOwnership(info)
+			}
+		}
+		cache[archive] = o
+		return o
+	}
+
+	if file != nil {
+		file.Ownership = lookup(file.Path)
+	}
+	for _, res := range results {
+		for i := range res.Files {
+			res.Files[i].Ownership = lookup(res.Files[i].Path)
+		}
+	}
+}
+
+// metaReader は読み込んだ内容のバイト数とSHA-256を走査と同時に計算します
+type metaReader struct {
+	r    io.Reader
+	hash hash.Hash
+	size int64
+}
+
+func newMetaReader(r io.Reader) *metaReader {
+	return &metaReader{r: r, hash: sha256.New()}
+}
+
+func (m *metaReader) Read(p []byte) (int, error) {
+	n, err := m.r.Read(p)
+	m.hash.Write(p[:n])
+	m.size += int64(n)
+	return n, err
+}
+
+// Meta は読み込み済みの内容からファイル情報を生成します。info が nil でなければ更新日時も含めます。
+func (m *metaReader) Meta(path string, info os.FileInfo) *FileMeta {
+	meta := &FileMeta{
+		Path:   path,
+		Size:   m.size,
+		SHA256: hex.EncodeToString(m.hash.Sum(nil)),
+	}
+	if info != nil {
+		modified := info.ModTime()
+		meta.Modified = &modified
+	}
+	return meta
+}
+
+// ExpectedSHA256 は --verify-sha256 の指定値から期待するハッシュ値を取り出します。
+// 64桁の16進文字列であればそのまま用い、それ以外は sha256sum 形式のチェックサムファイルのパスとみなします。
+func ExpectedSHA256(spec string, open func(string) (io.ReadCloser, error)) (string, error) {
+	if isSHA256Hex(spec) {
+		return strings.ToLower(spec), nil
+	}
+
+	f, err := open(spec)
+	if err != nil {
+		return "", fmt.Errorf("failed to open checksum file: %w", err)
+	}
+	defer f.Close()
+
+	// "ハッシュ値  ファイル名" 形式の先頭フィールドを用いる
+	scanner := bufio.NewScanner(f)
+	if scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) > 0 && isSHA256Hex(fields[0]) {
+			return strings.ToLower(fields[0]), nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", fmt.Errorf("error reading checksum file: %w", err)
+	}
+	return "", errors.New("checksum file does not contain a SHA-256 hash")
+}
+
+// isSHA256Hex は s がSHA-256の16進表記(64桁)であるかを返します
+func isSHA256Hex(s string) bool {
+	if len(s) != sha256.Size*2 {
+		return false
+	}
+	_, err := hex.DecodeString(s)
+	return err == nil
+}
+
+// HashInput は入力全体を読み込んでサイズとSHA-256を計算します
+func HashInput(r io.Reader, path string) (*FileMeta, error) {
+	m := newMetaReader(r)
+	if _, err := io.Copy(io.Discard, m); err != nil {
+		return nil, fmt.Errorf("error reading input for hashing: %w", err)
+	}
+	return m.Meta(path, nil), nil
+}
+
+// VerifySHA256 は走査前に入力全体を読み込んでハッシュ値を照合し、一致すればファイル情報を返します
+func VerifySHA256(r io.Reader, path, expected string) (*FileMeta, error) {
+	meta, err := HashInput(r, path)
+	if err != nil {
+		return nil, err
+	}
+	if meta.SHA256 != expected {
+		return nil, fmt.Errorf("checksum mismatch: expected %s, got %s", expected, meta.SHA256)
+	}
+	meta.Verified = true
+	return meta, nil
+}
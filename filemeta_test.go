@@ -0,0 +1,92 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestRun_VerifySHA256 はハッシュ値の一致・不一致で走査の可否が変わるか確認します
+func TestRun_VerifySHA256(t *testing.T) {
+	const abcHash = "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"
+	files := map[string]string{
+		"in.txt":        "abc",
+		"in.txt.sha256": abcHash + "  in.txt\n",
+	}
+
+	tests := []struct {
+		name     string
+		spec     string
+		wantCode int
+	}{
+		{"Hash", strings.ToUpper(abcHash), 0},
+		{"SidecarFile", "in.txt.sha256", 0},
+		{"Mismatch", strings.Repeat("0", 64), ExitError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockStdout := new(bytes.Buffer)
+			ctx := AppContext{
+				Args:     []string{"app", "-verify-sha256", tt.spec, "in.txt"},
+				ExecPath: "app_b",
+				Stdout:   mockStdout,
+				Stderr:   io.Discard,
+				FileReader: func(path string) (io.ReadCloser, error) {
+					return io.NopCloser(strings.NewReader(files[path])), nil
+				},
+			}
+
+			if code := Run(ctx); code != tt.wantCode {
+				t.Fatalf("Run() exit code = %d, want %d", code, tt.wantCode)
+			}
+			if tt.wantCode == 0 && !strings.Contains(mockStdout.String(), "SHA-256: "+abcHash+" (verified)") {
+				t.Errorf("Report should record verified hash.\n Output: %s", mockStdout.String())
+			}
+		})
+	}
+}
+
+// TestRun_FileOwner は -file-owner でファイルごとの該当数に所有者と権限が付くか確認します
+func TestRun_FileOwner(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "a.log")
+	if err := os.WriteFile(path, []byte("WARN a\n"), 0o640); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chmod(path, 0o640); err != nil {
+		t.Fatal(err)
+	}
+
+	stdout := new(bytes.Buffer)
+	code := Run(AppContext{
+		Args:       []string{"app", "-format", "json", "-file-owner", dir},
+		ExecPath:   "app_WARN",
+		Stdout:     stdout,
+		Stderr:     io.Discard,
+		FileReader: func(p string) (io.ReadCloser, error) { return os.Open(p) },
+		FileStat:   os.Stat,
+		DirFS:      os.DirFS,
+	})
+	if code != ExitMatch {
+		t.Fatalf("Run() exit code = %d", code)
+	}
+	var report JSONReport
+	if err := json.Unmarshal(stdout.Bytes(), &report); err != nil {
+		t.Fatal(err)
+	}
+	files := report.Results[0].Files
+	if len(files) != 1 || files[0].Ownership == nil || files[0].Ownership.Mode != "-rw-r-----" {
+		t.Fatalf("Files = %+v, want a.log with mode -rw-r-----", files)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := fileOwnership(info).Owner; files[0].Ownership.Owner != want {
+		t.Errorf("Owner = %q, want %q", files[0].Ownership.Owner, want)
+	}
+}
@@ -0,0 +1,243 @@
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"html"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding"
+)
+
+// LineFilter は照合前に各行へ適用する前処理です。
+// エンコードされて見えなくなっている文字を検索対象として表に出すために使います。
+type LineFilter func(line string) string
+
+// lineFilters は -filter で指定できる前処理の一覧です
+var lineFilters = map[string]LineFilter{
+	"ansi":             stripANSI,
+	"base64":           decodeBase64Segments,
+	"html":             unescapeHTML,
+	"quoted-printable": decodeQuotedPrintable,
+	"unicode-escape":   unescapeUnicode,
+	"url":              decodePercent(nil),
+}
+
+// ansiPattern は端末の色指定・カーソル移動などのエスケープシーケンスです。
+// CSI (ESC [ ... 終端文字)、OSC (ESC ] ... BEL/ST)、その他の2文字シーケンスに一致します。
+var ansiPattern = regexp.MustCompile(`\x1b(?:\[[0-?]*[ -/]*[@-~]|\][^\x07\x1b]*(?:\x07|\x1b\\)|[0-Z\\^_a-~])`)
+
+// stripANSI は行からANSIエスケープシーケンスを取り除きます
+func stripANSI(line string) string {
+	if !strings.Contains(line, "\x1b") {
+		return line
+	}
+	return ansiPattern.ReplaceAllString(line, "")
+}
+
+// ParseFilters はカンマ区切りの前処理名を検証して返します。指定された順に適用します。
+func ParseFilters(spec string) ([]string, error) {
+	if spec == "" {
+		return nil, nil
+	}
+	var names []string
+	for _, name := range strings.Split(spec, ",") {
+		name = strings.TrimSpace(name)
+		if _, err := lookupFilter(name); err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+// filterNames は利用可能な前処理名を名前順に返します
+func filterNames() []string {
+	names := make([]string, 0, len(lineFilters))
+	for name := range lineFilters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// lookupFilter は前処理名に対応する関数を返します。
+// "url:sjis" のように ":" の後に復号時の文字コードを指定できます。
+func lookupFilter(name string) (LineFilter, error) {
+	if base, charset, ok := strings.Cut(name, ":"); ok && base == "url" {
+		enc, err := LookupEncoding(charset)
+		if err != nil {
+			return nil, fmt.Errorf("filter %s: %w", name, err)
+		}
+		return decodePercent(enc), nil
+	}
+	if f, ok := lineFilters[name]; ok {
+		return f, nil
+	}
+	return nil, fmt.Errorf("unknown filter: %s (available: %s)", name, strings.Join(filterNames(), ", "))
+}
+
+// resolveFilters は前処理名の一覧を関数の一覧に変換します。
+// 未知の名前は事前に ParseFilters・ワーカーの RemoteOptions.validate で弾きます(ここでは無視する)。
+func resolveFilters(names []string) []LineFilter {
+	var filters []LineFilter
+	for _, name := range names {
+		if f, err := lookupFilter(name); err == nil {
+			filters = append(filters, f)
+		}
+	}
+	return filters
+}
+
+// applyFilters は行に前処理を順に適用します
+func applyFilters(line string, filters []LineFilter) string {
+	for _, f := range filters {
+		line = f(line)
+	}
+	return line
+}
+
+// unescapeUnicode はJSON等の \uXXXX エスケープを文字に戻します。サロゲートペアも結合します。
+func unescapeUnicode(line string) string {
+	if !strings.Contains(line, `\u`) {
+		return line
+	}
+
+	var sb strings.Builder
+	for i := 0; i < len(line); {
+		r, ok := parseUnicodeEscape(line[i:])
+		if !ok {
+			sb.WriteByte(line[i])
+			i++
+			continue
+		}
+		n := 6
+		if utf16.IsSurrogate(r) {
+			low, ok := parseUnicodeEscape(line[i+6:])
+			if !ok || utf16.DecodeRune(r, low) == unicode.ReplacementChar {
+				// 対になっていないサロゲートは元の表記のまま残す
+				sb.WriteString(line[i : i+6])
+				i += 6
+				continue
+			}
+			r = utf16.DecodeRune(r, low)
+			n = 12
+		}
+		sb.WriteRune(r)
+		i += n
+	}
+	return sb.String()
+}
+
+// parseUnicodeEscape は先頭の \uXXXX を解析します
+func parseUnicodeEscape(s string) (rune, bool) {
+	if len(s) < 6 || s[0] != '\\' || s[1] != 'u' {
+		return 0, false
+	}
+	v, err := strconv.ParseUint(s[2:6], 16, 16)
+	if err != nil {
+		return 0, false
+	}
+	return rune(v), true
+}
+
+// base64Segment はbase64らしい区間です。短い英単語を誤って復号しないよう16文字以上に限ります。
+var base64Segment = regexp.MustCompile(`[A-Za-z0-9+/]{16,}={0,2}`)
+
+// decodeBase64Segments は行内のbase64区間を復号した文字列に置き換えます。
+// 復号結果が制御文字を含まないUTF-8テキストになる区間のみ置き換えます。
+// MIMEの本文のように76文字で折り返された場合も、各行は4文字単位のため行ごとに復号できます。
+func decodeBase64Segments(line string) string {
+	if len(line) < 16 {
+		return line
+	}
+	return base64Segment.ReplaceAllStringFunc(line, func(seg string) string {
+		enc := base64.StdEncoding
+		if !strings.HasSuffix(seg, "=") {
+			if len(seg)%4 != 0 {
+				enc = base64.RawStdEncoding
+			}
+		}
+		decoded, err := enc.DecodeString(seg)
+		if err != nil || !isPlainText(decoded) {
+			return seg
+		}
+		return string(decoded)
+	})
+}
+
+// qpSequence は =XX 形式のエンコード文字の連続です
+var qpSequence = regexp.MustCompile(`(?:=[0-9A-Fa-f]{2})+`)
+
+// decodeQuotedPrintable は行内のquoted-printable表記(=E5=A4=96)を文字に戻します。
+// 末尾の "=" (ソフト改行)は取り除きます。
+func decodeQuotedPrintable(line string) string {
+	if !strings.Contains(line, "=") {
+		return line
+	}
+	line = qpSequence.ReplaceAllStringFunc(line, func(seg string) string {
+		decoded, err := hex.DecodeString(strings.ReplaceAll(seg, "=", ""))
+		if err != nil || !isPlainText(decoded) {
+			return seg
+		}
+		return string(decoded)
+	})
+	return strings.TrimSuffix(line, "=")
+}
+
+// isPlainText は復号結果が表示可能なUTF-8テキストであるかを返します
+func isPlainText(b []byte) bool {
+	if !utf8.Valid(b) {
+		return false
+	}
+	for _, r := range string(b) {
+		if unicode.IsControl(r) && r != '\t' {
+			return false
+		}
+	}
+	return true
+}
+
+// unescapeHTML は数値文字参照(&#x9AD9; &#39641;)と名前付き文字参照(&amp;)を文字に戻します
+func unescapeHTML(line string) string {
+	if !strings.Contains(line, "&") {
+		return line
+	}
+	return html.UnescapeString(line)
+}
+
+// percentSequence は %XX 形式のエンコード文字の連続です
+var percentSequence = regexp.MustCompile(`(?:%[0-9A-Fa-f]{2})+`)
+
+// decodePercent はURLのパーセントエンコード(%E5%A4%96)を文字に戻す前処理を返します。
+// enc が nil の場合はUTF-8として、それ以外は指定の文字コードとして復号します。
+func decodePercent(enc encoding.Encoding) LineFilter {
+	return func(line string) string {
+		if !strings.Contains(line, "%") {
+			return line
+		}
+		return percentSequence.ReplaceAllStringFunc(line, func(seg string) string {
+			decoded, err := hex.DecodeString(strings.ReplaceAll(seg, "%", ""))
+			if err != nil {
+				return seg
+			}
+			if enc != nil {
+				if decoded, err = enc.NewDecoder().Bytes(decoded); err != nil || bytes.ContainsRune(decoded, utf8.RuneError) {
+					return seg
+				}
+			}
+			if !isPlainText(decoded) {
+				return seg
+			}
+			return string(decoded)
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestUnescapeUnicode は \uXXXX エスケープ(サロゲートペアを含む)が文字に戻るか確認します
+func TestUnescapeUnicode(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{`name: \u9ad9\u6a4b`, "name: 髙橋"},
+		{`\ud842\udfb7\u91ce\u5bb6`, "𠮷野家"},
+		{`broken \ud842 pair`, `broken \ud842 pair`},
+		{`not \u12 hex`, `not \u12 hex`},
+	}
+	for _, tt := range tests {
+		if got := unescapeUnicode(tt.in); got != tt.want {
+			t.Errorf("unescapeUnicode(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+// TestSearchWithFilters は前処理後の行に対して照合されるか確認します
+func TestSearchWithFilters(t *testing.T) {
+	input := `{"name":"\u9AD9\u6A4B"}` + "\n"
+	opts := SearchOptions{ContextSize: 2, Filters: []string{"unicode-escape"}}
+
+	results, err := SearchStreamWithOptions(strings.NewReader(input), []string{"髙"}, opts)
+	if err != nil {
+		t.Fatalf("SearchStreamWithOptions() error = %v", err)
+	}
+	if got := results["髙"].Count; got != 1 {
+		t.Errorf("Count = %d, want 1", got)
+	}
+
+	if _, err := ParseFilters("unicode-escape,nope"); err == nil {
+		t.Error("ParseFilters() should reject unknown filters")
+	}
+}
+
+// TestStripANSI は色指定やタイトル設定のシーケンスが除去されるか確認します
+func TestStripANSI(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"\x1b[31mERROR\x1b[0m 髙橋", "ERROR 髙橋"},
+		{"\x1b[1;38;5;208mWARN\x1b[m", "WARN"},
+		{"\x1b]0;title\x07prompt", "prompt"},
+		{"\x1b[2Kline\x1b=", "line"},
+		{"plain", "plain"},
+	}
+	for _, tt := range tests {
+		if got := stripANSI(tt.in); got != tt.want {
+			t.Errorf("stripANSI(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+
+	// 色付けで分断されたクエリも除去後はヒットし、スニペットも崩れない
+	results, err := SearchStreamWithOptions(strings.NewReader("\x1b[33m外\x1b[0m字あり\n"), []string{"外字"},
+		SearchOptions{ContextSize: 2, Filters: []string{"ansi"}})
+	if err != nil {
+		t.Fatalf("SearchStreamWithOptions() error = %v", err)
+	}
+	if res := results["外字"]; res.Count != 1 || res.Snippets[0] != "外字あり" {
+		t.Errorf("Result = %d %q, want 1 hit with a clean snippet", res.Count, res.Snippets)
+	}
+}
+
+// TestDecodeEncodedSegments はbase64・quoted-printableの区間が復号され、ヒットに注記が付くか確認します
+func TestDecodeEncodedSegments(t *testing.T) {
+	// "外字を含む本文です" のbase64とquoted-printable
+	b64 := "5aSW5a2X44KS5ZCr44KA5pys5paH44Gn44GZ"
+	qp := "=E5=A4=96=E5=AD=97"
+
+	if got := decodeBase64Segments("body: " + b64); got != "body: 外字を含む本文です" {
+		t.Errorf("decodeBase64Segments() = %q", got)
+	}
+	if got := decodeBase64Segments("id=abcdefghijklmnopqrstuvwx"); got != "id=abcdefghijklmnopqrstuvwx" {
+		t.Errorf("decodeBase64Segments() should keep segments that do not decode to text: %q", got)
+	}
+	if got := decodeQuotedPrintable("subject " + qp + "=3D1="); got != "subject 外字=1" {
+		t.Errorf("decodeQuotedPrintable() = %q", got)
+	}
+
+	input := "plain 外字\n" + b64 + "\n"
+	results, err := SearchStreamWithOptions(strings.NewReader(input), []string{"外字"},
+		SearchOptions{ContextSize: 1, Filters: []string{"base64"}})
+	if err != nil {
+		t.Fatalf("SearchStreamWithOptions() error = %v", err)
+	}
+	res := results["外字"]
+	if res.Count != 2 || res.Infos[0].Decoded || !res.Infos[1].Decoded {
+		t.Errorf("Infos = %+v, want only the second hit marked as decoded", res.Infos)
+	}
+	if got := decodedAnnotation(res, 1); got != " (エンコード部分内, 2行目)" {
+		t.Errorf("decodedAnnotation() = %q", got)
+	}
+}
+
+// TestDecodePercent はURLエンコードされた文字が指定の文字コードで復号されるか確認します
+func TestDecodePercent(t *testing.T) {
+	tests := []struct {
+		filter, in, want string
+	}{
+		{"url", "GET /search?q=%E9%AB%99%E6%A9%8B&x=1", "GET /search?q=髙橋&x=1"},
+		{"url:sjis", "name=%8A%4F%8E%9A", "name=外字"},
+		{"url", "rate=100%", "rate=100%"},
+		{"url", "bin=%00%FF", "bin=%00%FF"},
+	}
+	for _, tt := range tests {
+		f, err := lookupFilter(tt.filter)
+		if err != nil {
+			t.Fatalf("lookupFilter(%q) error = %v", tt.filter, err)
+		}
+		if got := f(tt.in); got != tt.want {
+			t.Errorf("%s(%q) = %q, want %q", tt.filter, tt.in, got, tt.want)
+		}
+	}
+
+	if _, err := ParseFilters("url:latin9"); err == nil {
+		t.Error("ParseFilters() should reject unknown charsets")
+	}
+}
+
+// TestUnescapeHTML は文字参照に隠れた外字がヒットするか確認します
+func TestUnescapeHTML(t *testing.T) {
+	if got := unescapeHTML("<td>&#x9AD9;&#27211; &amp; co</td>"); got != "<td>髙橋 & co</td>" {
+		t.Errorf("unescapeHTML() = %q", got)
+	}
+
+	results, err := SearchStreamWithOptions(strings.NewReader("名前,&#x9AD9;橋\n"), []string{"髙"},
+		SearchOptions{ContextSize: 1, Filters: []string{"html"}})
+	if err != nil {
+		t.Fatalf("SearchStreamWithOptions() error = %v", err)
+	}
+	if res := results["髙"]; res.Count != 1 || !res.Infos[0].Decoded {
+		t.Errorf("Result = %+v, want 1 decoded hit", res.Infos)
+	}
+}
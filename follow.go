@@ -0,0 +1,211 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/text/encoding"
+)
+
+// 追従モードでバッファが溢れた場合の扱い
+const (
+	OverflowDrop  = "drop"  // 溢れたヒットを破棄し、件数のみ通知する
+	OverflowBlock = "block" // 出力が追いつくまで読み込みを止める
+)
+
+// 追従モードの既定値
+const (
+	DefaultFollowInterval = 500 * time.Millisecond
+	DefaultFollowBuffer   = 1000
+)
+
+// FollowOptions は追従モードの読み込み間隔と流量制御を指定します
+type FollowOptions struct {
+	Interval time.Duration // 末尾に達した後、追記を確認する間隔
+	Rate     float64       // 1秒あたりに出力するヒットの上限(0なら無制限)
+	Buffer   int           // 出力待ちのヒットを溜めておく件数
+	Overflow string        // バッファが溢れた場合の扱い(drop|block)
+}
+
+// FollowEvent は追従中に見つかった1件のヒットです
+type FollowEvent struct {
+	Query   string
+	Line    int
+	Snippet string
+	Dropped int // 前回の出力以降に破棄したヒット数
+}
+
+// Follow は r の末尾に追記される行を監視し、ヒットするたびに emit を呼び出します。
+// ヒットは Buffer 件まで溜め、Rate を超えないように出力します。
+// ctx がキャンセルされるか emit がエラーを返すまで戻りません。
+func Follow(ctx context.Context, r io.Reader, queries []string, opts SearchOptions, fopts FollowOptions, emit func(FollowEvent) error) error {
+	matchers := make([]matcher, len(queries))
+	for i, q := range queries {
+		matchers[i] = newMatcher(q, opts)
+	}
+
+	filters := resolveFilters(opts.Filters)
+
+	// 追記を待つ間も読み込みを続けるため、変換は読み込んだ行ごとに行う
+	// (対応する文字コードでは改行のバイトが2バイト文字の一部に現れない)
+	var decoder *encoding.Decoder
+	if opts.InputEncoding == EncodingAuto {
+		return errors.New("-enc auto is not supported in follow mode; specify the encoding")
+	}
+	if opts.InputEncoding != "" {
+		enc, err := LookupEncoding(opts.InputEncoding)
+		if err != nil {
+			return err
+		}
+		decoder = enc.NewDecoder()
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	events := make(chan FollowEvent, max(fopts.Buffer, 1))
+	var dropped atomic.Int64
+	readErr := make(chan error, 1)
+
+	go func() {
+		defer close(events)
+		var allow, prevAllow *suppression
+		readErr <- followLines(ctx, r, fopts.Interval, func(lineNum int, lineText string) bool {
+			if decoder != nil {
+				lineText, _ = decoder.String(lineText)
+			}
+			prevAllow, allow = allow, parseSuppression(lineText, opts.SuppressMarker)
+			lineText = applyFilters(lineText, filters)
+			for qi, q := range queries {
+				loc := matchers[qi].find(lineText)
+				if loc == nil || allow.covers(q) || prevAllow.covers(q) {
+					continue
+				}
+				ev := FollowEvent{Query: q, Line: lineNum, Snippet: lineSnippet(lineText, loc, matchers[qi], opts)}
+				if fopts.Overflow == OverflowBlock {
+					select {
+					case events <- ev:
+					case <-ctx.Done():
+						return false
+					}
+					continue
+				}
+				select {
+				case events <- ev:
+				default:
+					dropped.Add(1)
+				}
+			}
+			return true
+		})
+	}()
+
+	var interval time.Duration
+	if fopts.Rate > 0 {
+		interval = time.Duration(float64(time.Second) / fopts.Rate)
+	}
+	var last time.Time
+
+	for ev := range events {
+		if interval > 0 {
+			if wait := interval - time.Since(last); wait > 0 {
+				select {
+				case <-time.After(wait):
+				case <-ctx.Done():
+				}
+			}
+			last = time.Now()
+		}
+		ev.Dropped = int(dropped.Swap(0))
+		if err := emit(ev); err != nil {
+			cancel()
+			for range events {
+			}
+			return err
+		}
+	}
+
+	if err := <-readErr; err != nil && !errors.Is(err, context.Canceled) {
+		return err
+	}
+	// 最後のヒット以降に破棄した件数も通知する
+	if n := int(dropped.Swap(0)); n > 0 {
+		return emit(FollowEvent{Dropped: n})
+	}
+	return nil
+}
+
+// followLines は行を読み込むたびに handle を呼び出し、末尾に達したら interval ごとに追記を待ちます。
+// handle が false を返すか ctx がキャンセルされると終了します。
+func followLines(ctx context.Context, r io.Reader, interval time.Duration, handle func(int, string) bool) error {
+	br := bufio.NewReader(r)
+	var partial strings.Builder
+	lineNum := 0
+
+	for {
+		chunk, err := br.ReadString('\n')
+		partial.WriteString(chunk)
+		if err == nil {
+			// CRのみの改行が混在していても、検索と同じく行として区切る
+			text := strings.TrimSuffix(strings.TrimSuffix(partial.String(), "\n"), "\r")
+			partial.Reset()
+			for _, line := range strings.Split(text, "\r") {
+				lineNum++
+				if lineNum == 1 {
+					line = strings.TrimPrefix(line, utf8BOM)
+				}
+				if !handle(lineNum, line) {
+					return ctx.Err()
+				}
+			}
+			continue
+		}
+		if !errors.Is(err, io.EOF) {
+			return fmt.Errorf("error reading stream: %w", err)
+		}
+
+		// 書きかけの行は改行が追記されるまで保留する
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// lineSnippet は1件のヒットのスニペットを切り出します(追従モードや全件の記録で使う)
+func lineSnippet(lineText string, loc []int, m matcher, opts SearchOptions) string {
+	lineRunes := []rune(lineText)
+	src := lineRunes
+	if mask := redactMask(m, opts); mask != 0 {
+		src = redactRunes(lineRunes, lineText, m, mask)
+	}
+	start, end := runeRange(lineText, loc)
+	from, to := contextRange(lineRunes, start, end, opts)
+	pre, hit, post := clampSnippet(
+		EscapeSnippet(string(src[from:start]), opts.Escape),
+		EscapeSnippet(string(src[start:end]), opts.Escape),
+		EscapeSnippet(string(src[end:to]), opts.Escape),
+		opts.MaxSnippetBytes)
+	return pre + hit + post
+}
+
+// WriteFollowEvent は追従モードのヒットを1行で出力します
+func WriteFollowEvent(w io.Writer, ev FollowEvent) error {
+	if ev.Dropped > 0 {
+		if _, err := fmt.Fprintf(w, "... %d 件のヒットを破棄しました\n", ev.Dropped); err != nil {
+			return err
+		}
+	}
+	if ev.Query == "" {
+		return nil
+	}
+	_, err := fmt.Fprintf(w, "[%s] %d行目: %s\n", ev.Query, ev.Line, ev.Snippet)
+	return err
+}
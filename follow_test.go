@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestFollowDropsOnOverflow は出力が詰まった間のヒットが破棄され、件数が通知されるか確認します
+func TestFollowDropsOnOverflow(t *testing.T) {
+	input := strings.Repeat("WARN x\n", 10)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	gate := make(chan struct{})
+	var got []FollowEvent
+	emit := func(ev FollowEvent) error {
+		if len(got) == 0 {
+			// 最初の出力で詰まらせ、その間に残りの行を読ませる
+			<-gate
+		}
+		got = append(got, ev)
+		if len(got) == 2 {
+			cancel()
+		}
+		return nil
+	}
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		close(gate)
+	}()
+
+	fopts := FollowOptions{Interval: time.Millisecond, Buffer: 1, Overflow: OverflowDrop}
+	if err := Follow(ctx, strings.NewReader(input), []string{"WARN"}, SearchOptions{ContextSize: 2}, fopts, emit); err != nil {
+		t.Fatalf("Follow() error = %v", err)
+	}
+
+	// 出力が詰まっている間はバッファの1件を除いて破棄され、破棄件数は次の出力で通知される
+	hits, dropped := 0, 0
+	for _, ev := range got {
+		if ev.Query != "" {
+			hits++
+		}
+		dropped += ev.Dropped
+	}
+	if hits != 2 || dropped < 8 || hits+dropped != 10 {
+		t.Errorf("Got %d hits and %d dropped, want 2 hits and the rest dropped: %+v", hits, dropped, got)
+	}
+}
+
+// TestFollowRate はヒットの出力が指定の頻度を超えないか確認します
+func TestFollowRate(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var times []time.Time
+	emit := func(ev FollowEvent) error {
+		times = append(times, time.Now())
+		if len(times) == 3 {
+			cancel()
+		}
+		return nil
+	}
+
+	fopts := FollowOptions{Interval: time.Millisecond, Rate: 20, Buffer: 10, Overflow: OverflowBlock}
+	if err := Follow(ctx, strings.NewReader("WARN\nWARN\nWARN\n"), []string{"WARN"}, SearchOptions{}, fopts, emit); err != nil {
+		t.Fatalf("Follow() error = %v", err)
+	}
+	if elapsed := times[2].Sub(times[0]); elapsed < 90*time.Millisecond {
+		t.Errorf("3 events took %v, want at least 100ms at 20/s", elapsed)
+	}
+}
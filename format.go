@@ -0,0 +1,522 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/width"
+)
+
+// 出力形式
+const (
+	FormatText  = "text"
+	FormatTable = "table"
+	FormatJSON  = "json"
+	FormatJSONL = "jsonl"
+	FormatCSV   = "csv"
+	FormatTSV   = "tsv"
+)
+
+// OutputOptions は結果の出力方法を指定します
+type OutputOptions struct {
+	Format string
+	Layout ReportLayout // テキスト形式の見出し・区切り線
+	File   *FileMeta    // 機械可読出力に含める入力ファイルの情報
+	// CoOccurrence が有効なら、クエリの組ごとの共起行数の行列を出力する
+	CoOccurrence bool
+	// GroupBy が指定されていれば、その名前付きグループの捕捉値ごとの順位表を出力する
+	GroupBy string
+	// CountOnly ならテキスト形式ではクエリごとの該当数のみを出力する(-c)
+	CountOnly bool
+	// InputPath は単一ファイルの検索時の入力パス(CSV/TSVのファイルの列、JSONの input に使う)
+	InputPath string
+	// Paths が nil でなければ、機械可読出力のパスを絶対パス(または基準ディレクトリからの相対パス)に揃える
+	Paths *PathResolver
+}
+
+// MachineReadable は出力形式が機械可読(JSON/JSONL)であるかを返します
+func (o OutputOptions) MachineReadable() bool {
+	return o.Format == FormatJSON || o.Format == FormatJSONL
+}
+
+// WriteFormatted は指定された形式で結果を出力します。
+// 書き込みに失敗した場合は、形式によらず最初の書き込みエラーを返します。
+func WriteFormatted(out io.Writer, results map[string]*SearchResult, queryOrder []string, opts OutputOptions) error {
+	w := &errorWriter{w: out}
+	if err := writeFormatted(w, results, queryOrder, opts); err != nil {
+		return err
+	}
+	return w.err
+}
+
+// errorWriter は最初の書き込みエラーを記録し、以降の書き込みを行いません。
+// テキスト形式等の出力は1行ごとにエラーを確認しないため、まとめて確認するのに使います。
+type errorWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (e *errorWriter) Write(p []byte) (int, error) {
+	if e.err != nil {
+		return 0, e.err
+	}
+	n, err := e.w.Write(p)
+	if err != nil {
+		e.err = err
+	}
+	return n, err
+}
+
+func writeFormatted(w io.Writer, results map[string]*SearchResult, queryOrder []string, opts OutputOptions) error {
+	switch opts.Format {
+	case "", FormatText:
+		if opts.File != nil && opts.File.Verified {
+			fmt.Fprintf(w, "SHA-256: %s (verified)\n", opts.File.SHA256)
+		}
+		if enc := detectedEncoding(results); enc != "" {
+			fmt.Fprintf(w, "文字コード: %s (自動判定)\n", enc)
+		}
+		for _, c := range resizedFiles(results, queryOrder) {
+			fmt.Fprintf(w, "サイズ変化: %s\n", c)
+		}
+		if opts.CountOnly {
+			WriteCounts(w, results, queryOrder)
+			return nil
+		}
+		WriteResultsWithLayout(w, results, queryOrder, opts.Layout)
+		if opts.GroupBy != "" {
+			WriteCaptureRanking(w, results, queryOrder, opts.GroupBy)
+		}
+		if opts.CoOccurrence {
+			WriteCoOccurrence(w, results, queryOrder)
+		}
+	case FormatTable:
+		// 集計指定時はスニペットの表に代えて順位表を出力する
+		if opts.GroupBy != "" {
+			WriteCaptureRanking(w, results, queryOrder, opts.GroupBy)
+		} else {
+			WriteTable(w, results, queryOrder)
+		}
+		if opts.CoOccurrence {
+			fmt.Fprintln(w)
+			WriteCoOccurrence(w, results, queryOrder)
+		}
+	case FormatCSV, FormatTSV:
+		return WriteDelimited(w, results, queryOrder, opts)
+	case FormatJSON:
+		return WriteJSON(w, results, queryOrder, opts)
+	case FormatJSONL:
+		return WriteJSONL(w, results, queryOrder, opts)
+	default:
+		return fmt.Errorf("unknown output format: %s", opts.Format)
+	}
+	return nil
+}
+
+// detectedEncoding は自動判定した文字コードを返します(判定していなければ空)
+func detectedEncoding(results map[string]*SearchResult) string {
+	for _, res := range results {
+		if res.Encoding != "" {
+			return res.Encoding
+		}
+	}
+	return ""
+}
+
+// resizedFiles は走査中にサイズが変わったファイルを返します(どの検索語の結果にも同じものが記録されている)
+func resizedFiles(results map[string]*SearchResult, queryOrder []string) []SizeChange {
+	for _, q := range queryOrder {
+		if res, ok := results[q]; ok {
+			return res.Resized
+		}
+	}
+	return nil
+}
+
+// JSONResult は機械可読出力における1つの検索語の結果です
+type JSONResult struct {
+	Schema     string                    `json:"schema,omitempty"` // JSONLの各行にのみ含める
+	File       *FileMeta                 `json:"file,omitempty"`   // JSONLの各行にのみ含める
+	Input      string                    `json:"input,omitempty"`  // JSONLの各行にのみ含める
+	Query      string                    `json:"query"`
+	Count      int                       `json:"count"`
+	Suppressed int                       `json:"suppressed,omitempty"` // 除外指定のある行のヒット行数
+	Estimate   *Estimate                 `json:"estimate,omitempty"`
+	Max        *int                      `json:"max,omitempty"`
+	Breached   bool                      `json:"breached,omitempty"`
+	Severity   string                    `json:"severity,omitempty"` // 上限超過時の重大度(error|warning|info)
+	Lines      int                       `json:"lines"`
+	Bytes      int64                     `json:"bytes"`
+	Variants   map[string]int            `json:"variants,omitempty"`
+	Captures   map[string]map[string]int `json:"captures,omitempty"`
+	CoOccur    map[string]int            `json:"cooccurrence,omitempty"` // 他のクエリと同じ行でヒットした行数
+	Encoding   string                    `json:"encoding,omitempty"`     // 自動判定した入力の文字コード
+	Resized    []SizeChange              `json:"resized,omitempty"`      // 走査中にサイズが変わったファイル
+	First      *Occurrence               `json:"first,omitempty"`
+	Last       *Occurrence               `json:"last,omitempty"`
+	Files      []FileCount               `json:"files,omitempty"` // ディレクトリ検索時のファイルごとの該当数
+	GroupBy    string                    `json:"group_by,omitempty"`
+	Groups     []CaptureGroup            `json:"groups,omitempty"` // 捕捉値ごとの該当数(多い順)
+	Snippets   []JSONSnippet             `json:"snippets"`
+}
+
+// JSONSnippet はスニペットをヒット部分とその前後に分けて表します
+type JSONSnippet struct {
+	Path    string `json:"path,omitempty"`
+	Pre     string `json:"pre"`
+	Match   string `json:"match"`
+	Post    string `json:"post"`
+	Line    int    `json:"line"`
+	Offset  int64  `json:"offset"` // 行頭のバイト位置
+	Col     int    `json:"col"`
+	Char    int    `json:"char,omitempty"` // -no-line-mode での入力全体の文字位置
+	Repeats int    `json:"repeats,omitempty"`
+	Folded  []int  `json:"folded_lines,omitempty"`
+	Hex     string `json:"hex,omitempty"`
+
+	Converted string      `json:"converted,omitempty"`
+	Decoded   bool        `json:"decoded,omitempty"` // エンコードされた部分の中でヒットした
+	Matches   []MatchSpan `json:"matches,omitempty"` // 複数クエリのヒットをまとめた場合の各ヒット部分
+	Class     string      `json:"class,omitempty"`   // ヒット部分の文字の分類(-anonymize の場合のみ)
+}
+
+// JSONReport は -format json の出力全体です
+type JSONReport struct {
+	Schema  string       `json:"schema"`
+	File    *FileMeta    `json:"file,omitempty"`
+	Input   string       `json:"input,omitempty"` // 単一ファイルの検索時の入力パス
+	Results []JSONResult `json:"results"`
+}
+
+// WriteJSON は結果を1つのJSON文書として出力します
+func WriteJSON(w io.Writer, results map[string]*SearchResult, queryOrder []string, opts OutputOptions) error {
+	report := JSONReport{Schema: SchemaVersion, File: resolveFileMeta(opts), Input: opts.Paths.Resolve(opts.InputPath), Results: make([]JSONResult, 0, len(queryOrder))}
+	for _, q := range queryOrder {
+		if res, ok := results[q]; ok {
+			report.Results = append(report.Results, newJSONResult(res, opts))
+		}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(report)
+}
+
+// WriteJSONL は結果を検索語ごとに1行のJSONとして出力します
+func WriteJSONL(w io.Writer, results map[string]*SearchResult, queryOrder []string, opts OutputOptions) error {
+	enc := json.NewEncoder(w)
+	file := resolveFileMeta(opts)
+	for _, q := range queryOrder {
+		res, ok := results[q]
+		if !ok {
+			continue
+		}
+		jr := newJSONResult(res, opts)
+		jr.Schema = SchemaVersion
+		jr.File = file
+		jr.Input = opts.Paths.Resolve(opts.InputPath)
+		if err := enc.Encode(jr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newJSONResult は検索結果を機械可読出力用の構造に変換します
+func newJSONResult(res *SearchResult, opts OutputOptions) JSONResult {
+	jr := JSONResult{
+		Query:      res.Query,
+		Count:      res.Count,
+		Suppressed: res.Suppressed,
+		Estimate:   res.Estimate,
+		Max:        res.Max,
+		Breached:   res.Breached(),
+		Severity:   res.Severity,
+		Lines:      res.Lines,
+		Bytes:      res.Bytes,
+		Variants:   res.Variants,
+		Captures:   res.Captures,
+		CoOccur:    res.CoOccur,
+		Encoding:   res.Encoding,
+		Resized:    res.Resized,
+		First:      resolveOccurrence(res.First, opts.Paths),
+		Last:       resolveOccurrence(res.Last, opts.Paths),
+		Files:      res.Files,
+		Snippets:   make([]JSONSnippet, 0, len(res.Snippets)),
+	}
+	if opts.Paths != nil && res.Resized != nil {
+		jr.Resized = make([]SizeChange, len(res.Resized))
+		for i, c := range res.Resized {
+			c.Path = opts.Paths.Resolve(c.Path)
+			jr.Resized[i] = c
+		}
+	}
+	if opts.Paths != nil && res.Files != nil {
+		jr.Files = make([]FileCount, len(res.Files))
+		for i, f := range res.Files {
+			f.Path = opts.Paths.Resolve(f.Path)
+			jr.Files[i] = f
+		}
+	}
+	if opts.GroupBy != "" && res.Captures[opts.GroupBy] != nil {
+		jr.GroupBy = opts.GroupBy
+		jr.Groups = GroupByCapture(res, opts.GroupBy)
+	}
+
+	for i, snippet := range res.Snippets {
+		js := JSONSnippet{Match: snippet}
+		if i < len(res.Infos) {
+			info := res.Infos[i]
+			js.Pre = snippet[:info.MatchStart]
+			js.Match = snippet[info.MatchStart:info.MatchEnd]
+			js.Post = snippet[info.MatchEnd:]
+			js.Path = opts.Paths.Resolve(info.Path)
+			js.Line = info.Line
+			js.Offset = info.Offset
+			js.Col = info.Col
+			js.Char = info.Char
+			if info.Repeats > 1 {
+				js.Repeats = info.Repeats
+				js.Folded = info.Lines
+			}
+			if info.Raw != nil {
+				js.Hex = hex.EncodeToString(info.Raw)
+			}
+			js.Converted = info.Converted
+			js.Decoded = info.Decoded
+			js.Matches = info.Matches
+			js.Class = info.Class
+		}
+		jr.Snippets = append(jr.Snippets, js)
+	}
+	return jr
+}
+
+// resolveFileMeta は入力ファイルの情報を、パスを揃えて返します(元の情報は変更しない)
+func resolveFileMeta(opts OutputOptions) *FileMeta {
+	if opts.File == nil || opts.Paths == nil {
+		return opts.File
+	}
+	file := *opts.File
+	file.Path = opts.Paths.Resolve(file.Path)
+	return &file
+}
+
+// resolveOccurrence はヒット位置を、パスを揃えて返します(元の位置は変更しない)
+func resolveOccurrence(o *Occurrence, paths *PathResolver) *Occurrence {
+	if o == nil || paths == nil || o.Path == "" {
+		return o
+	}
+	resolved := *o
+	resolved.Path = paths.Resolve(o.Path)
+	return &resolved
+}
+
+// WriteCounts はクエリごとの該当数を "クエリ: 件数" の形式で1行ずつ出力します
+func WriteCounts(w io.Writer, results map[string]*SearchResult, queryOrder []string) {
+	for _, q := range queryOrder {
+		if res, ok := results[q]; ok {
+			fmt.Fprintf(w, "%s: %d\n", q, res.Count)
+		}
+	}
+}
+
+// WriteTable は結果を端末向けの桁揃えした表として出力します。
+// 全角文字は2桁として幅を計算します。
+func WriteTable(w io.Writer, results map[string]*SearchResult, queryOrder []string) {
+	// ルールで重大度が付いた結果がある場合のみ重大度の列を設ける
+	withSeverity := false
+	for _, q := range queryOrder {
+		if res, ok := results[q]; ok && res.Severity != "" {
+			withSeverity = true
+		}
+	}
+	row := func(cells ...string) []string {
+		if !withSeverity {
+			return append(cells[:2:2], cells[3:]...)
+		}
+		return cells
+	}
+
+	rows := [][]string{row("クエリ", "該当数", "重大度", "No", "行", "スニペット")}
+
+	for _, q := range queryOrder {
+		res, ok := results[q]
+		if !ok {
+			continue
+		}
+
+		label, count, severity := res.Query, strconv.Itoa(res.Count), tableSeverity(res)
+		if len(res.Snippets) == 0 {
+			rows = append(rows, row(label, count, severity, "", "", ""))
+			continue
+		}
+		for i, snippet := range res.Snippets {
+			line := ""
+			if i < len(res.Infos) {
+				line = strconv.Itoa(res.Infos[i].Line)
+			}
+			rows = append(rows, row(label, count, severity, strconv.Itoa(i+1), line, tableCell(snippet+foldAnnotation(res, i)+decodedAnnotation(res, i)+classAnnotation(res, i))))
+			// 同じクエリの2行目以降はクエリ名と該当数を省略する
+			label, count, severity = "", "", ""
+		}
+	}
+	writeTableRows(w, rows)
+}
+
+// WriteDelimited はスニペットごとに1行のCSV(TSV)を出力します。
+// 列はファイル・クエリ・行番号・該当数・スニペットで、スニペットのないクエリも該当数を示す1行を出力します。
+// カンマ・タブ・改行・引用符を含む値は引用符で囲みます(TSVも同じ規則)。
+func WriteDelimited(w io.Writer, results map[string]*SearchResult, queryOrder []string, opts OutputOptions) error {
+	cw := csv.NewWriter(w)
+	if opts.Format == FormatTSV {
+		cw.Comma = '\t'
+	}
+	cw.Write([]string{"file", "query", "line", "count", "snippet"})
+	for _, q := range queryOrder {
+		res, ok := results[q]
+		if !ok {
+			continue
+		}
+		count := strconv.Itoa(res.Count)
+		if len(res.Snippets) == 0 {
+			cw.Write([]string{opts.InputPath, res.Query, "", count, ""})
+			continue
+		}
+		for i, snippet := range res.Snippets {
+			path, line := opts.InputPath, ""
+			if i < len(res.Infos) {
+				if res.Infos[i].Path != "" {
+					path = res.Infos[i].Path
+				}
+				line = strconv.Itoa(res.Infos[i].Line)
+			}
+			cw.Write([]string{path, res.Query, line, count, snippet})
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// tableSeverity は表形式の重大度の列の値を返します。上限を超えた場合は "error (超過)" のように示します。
+func tableSeverity(res *SearchResult) string {
+	if res.Severity == "" || !res.Breached() {
+		return res.Severity
+	}
+	return res.Severity + " (" + severityLabels[res.Severity] + ")"
+}
+
+// writeTableRows は先頭行を見出しとして、各列の表示幅を揃えた表を出力します
+func writeTableRows(w io.Writer, rows [][]string) {
+	widths := make([]int, len(rows[0]))
+	for _, row := range rows {
+		for i, cell := range row {
+			widths[i] = max(widths[i], DisplayWidth(cell))
+		}
+	}
+
+	writeRow := func(row []string) {
+		var sb strings.Builder
+		for i, cell := range row {
+			if i > 0 {
+				sb.WriteString(" | ")
+			}
+			sb.WriteString(cell)
+			// 最終列は末尾の空白を出力しない
+			if i < len(row)-1 {
+				sb.WriteString(strings.Repeat(" ", widths[i]-DisplayWidth(cell)))
+			}
+		}
+		fmt.Fprintln(w, sb.String())
+	}
+
+	writeRow(rows[0])
+	sep := make([]string, len(widths))
+	for i, n := range widths {
+		sep[i] = strings.Repeat("-", n)
+	}
+	fmt.Fprintln(w, strings.Join(sep, "-+-"))
+	for _, row := range rows[1:] {
+		writeRow(row)
+	}
+}
+
+// tableCell は表の桁揃えを崩す制御文字(タブ等)を空白に置き換えます
+func tableCell(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return ' '
+		}
+		return r
+	}, s)
+}
+
+// DisplayWidth は端末上での表示幅を返します。
+// 東アジアの全角・広幅文字は2桁、結合文字や書式制御文字は0桁として数えます。
+func DisplayWidth(s string) int {
+	n := 0
+	for _, r := range s {
+		switch {
+		case unicode.Is(unicode.Mn, r) || unicode.Is(unicode.Me, r) || unicode.Is(unicode.Cf, r):
+			// 幅を持たない
+		case isWide(r):
+			n += 2
+		default:
+			n++
+		}
+	}
+	return n
+}
+
+// isWide は文字が東アジアの全角(F)または広幅(W)であるかを返します
+func isWide(r rune) bool {
+	switch width.LookupRune(r).Kind() {
+	case width.EastAsianWide, width.EastAsianFullwidth:
+		return true
+	}
+	return false
+}
+
+// 不可視文字のエスケープ指定
+const (
+	EscapeNone         = iota
+	EscapeNonPrintable // 制御文字・ゼロ幅文字などの不可視文字をエスケープする
+	EscapeNonASCII     // 加えてASCII以外の全文字をエスケープする
+)
+
+// EscapeSnippet は不可視文字(および指定時は非ASCII文字)を \uXXXX 形式に置き換えます。
+// BMP外の文字は \UXXXXXXXX 形式で表します。
+func EscapeSnippet(s string, mode int) string {
+	if mode == EscapeNone {
+		return s
+	}
+
+	var sb strings.Builder
+	for _, r := range s {
+		if !needsEscape(r, mode) {
+			sb.WriteRune(r)
+			continue
+		}
+		if r > 0xFFFF {
+			fmt.Fprintf(&sb, "\\U%08X", r)
+		} else {
+			fmt.Fprintf(&sb, "\\u%04X", r)
+		}
+	}
+	return sb.String()
+}
+
+// needsEscape は文字をエスケープ表示すべきかを返します
+func needsEscape(r rune, mode int) bool {
+	if mode == EscapeNonASCII && r > 0x7E {
+		return true
+	}
+	// 制御文字(Cc)と、ゼロ幅スペースやBOMなどの書式制御文字(Cf)
+	return unicode.Is(unicode.Cc, r) || unicode.Is(unicode.Cf, r)
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// TestDisplayWidth は全角・半角・結合文字の表示幅を確認します
+func TestDisplayWidth(t *testing.T) {
+	tests := []struct {
+		in   string
+		want int
+	}{
+		{"abc", 3},
+		{"外字", 4},
+		{"ｶﾀｶﾅ", 4},
+		{"Ａ1", 3},
+		{"が", 2}, // 結合用濁点は幅0
+	}
+	for _, tt := range tests {
+		if got := DisplayWidth(tt.in); got != tt.want {
+			t.Errorf("DisplayWidth(%q) = %d, want %d", tt.in, got, tt.want)
+		}
+	}
+}
+
+// TestWriteTable は全角文字を含む列が揃うか確認します
+func TestWriteTable(t *testing.T) {
+	results, err := SearchStream(strings.NewReader("髙橋 様\nabc 髙橋\n"), []string{"髙橋", "x"}, 2)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	out := new(bytes.Buffer)
+	WriteTable(out, results, []string{"髙橋", "x"})
+
+	lines := strings.Split(strings.TrimRight(out.String(), "\n"), "\n")
+	if len(lines) != 5 {
+		t.Fatalf("Row count mismatch. got %d\n%s", len(lines), out.String())
+	}
+
+	// 2列目の区切りまでの表示幅が全行で一致すること
+	want := DisplayWidth(strings.SplitN(lines[0], " | ", 2)[0])
+	for _, line := range []string{lines[2], lines[3], lines[4]} {
+		if got := DisplayWidth(strings.SplitN(line, " | ", 2)[0]); got != want {
+			t.Errorf("Column not aligned. got width %d, want %d in %q", got, want, line)
+		}
+	}
+}
+
+// TestWriteDelimited はCSV・TSVでカンマ・タブ・改行・引用符を含むスニペットが引用符で囲まれるか確認します
+func TestWriteDelimited(t *testing.T) {
+	results := map[string]*SearchResult{
+		"髙橋": {Query: "髙橋", Count: 2, Snippets: []string{"a,髙橋", "\"髙橋\"\tb"}, Infos: []SnippetInfo{{Line: 1}, {Line: 3, Path: "sub/b.log"}}},
+		"x":  {Query: "x"},
+	}
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{FormatCSV, "file,query,line,count,snippet\n" +
+			"in.log,髙橋,1,2,\"a,髙橋\"\n" +
+			"sub/b.log,髙橋,3,2,\"\"\"髙橋\"\"\tb\"\n" +
+			"in.log,x,,0,\n"},
+		{FormatTSV, "file\tquery\tline\tcount\tsnippet\n" +
+			"in.log\t髙橋\t1\t2\ta,髙橋\n" +
+			"sub/b.log\t髙橋\t3\t2\t\"\"\"髙橋\"\"\tb\"\n" +
+			"in.log\tx\t\t0\t\n"},
+	}
+	for _, tt := range tests {
+		out := new(bytes.Buffer)
+		opts := OutputOptions{Format: tt.format, InputPath: "in.log"}
+		if err := WriteFormatted(out, results, []string{"髙橋", "x"}, opts); err != nil {
+			t.Fatal(err)
+		}
+		if out.String() != tt.want {
+			t.Errorf("%s output mismatch.\nGot:\n%s\nWant:\n%s", tt.format, out.String(), tt.want)
+		}
+	}
+}
+
+// TestEscapeSnippet は不可視文字・非ASCII文字のエスケープを確認します
+func TestEscapeSnippet(t *testing.T) {
+	in := "A\u200BB\t髙\U00020B9F"
+	if got, want := EscapeSnippet(in, EscapeNonPrintable), `A\u200BB\u0009髙`+"\U00020B9F"; got != want {
+		t.Errorf("EscapeNonPrintable mismatch. got %q, want %q", got, want)
+	}
+	if got, want := EscapeSnippet(in, EscapeNonASCII), `A\u200BB\u0009\u9AD9\U00020B9F`; got != want {
+		t.Errorf("EscapeNonASCII mismatch. got %q, want %q", got, want)
+	}
+}
+
+// TestWriteJSON はスニペットが pre/match/post に分割されて出力されるか確認します
+func TestWriteJSON(t *testing.T) {
+	results, err := SearchStream(strings.NewReader("ok\n担当 髙橋 様\n"), []string{"髙橋"}, 2)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	out := new(bytes.Buffer)
+	if err := WriteJSON(out, results, []string{"髙橋"}, OutputOptions{}); err != nil {
+		t.Fatalf("WriteJSON() error = %v", err)
+	}
+
+	var report JSONReport
+	if err := json.Unmarshal(out.Bytes(), &report); err != nil {
+		t.Fatalf("Output is not valid JSON: %v\n%s", err, out.String())
+	}
+
+	want := JSONSnippet{Pre: "当 ", Match: "髙橋", Post: " 様", Line: 2, Col: 4}
+	if got := report.Results[0].Snippets[0]; got.Pre != want.Pre || got.Match != want.Match || got.Post != want.Post || got.Line != want.Line || got.Col != want.Col {
+		t.Errorf("Snippet mismatch.\n got:  %+v\n want: %+v", got, want)
+	}
+}
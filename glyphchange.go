@@ -0,0 +1,103 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// jis2004GlyphChangeChars は JIS X 0213:2004 で例示字形が変更された168字です(屢 の第3水準を除き、JIS X 0208 の第1・第2水準の漢字)。
+// 表外漢字字体表の印刷標準字体に合わせたもので、JIS90 の字形のフォントから JIS2004 の字形のフォントに移行すると
+// 同じコードポイントのまま表示・印刷の字形が変わります(辻 の点の数、葛 の下部等)。
+const jis2004GlyphChangeChars = "" +
+	"逢芦飴溢茨鰯淫迂厩噂餌襖迦牙廻恢晦蟹葛鞄釜翰翫徽祇汲灸笈卿饗僅喰櫛屑粂祁隙倦捲牽鍵諺巷梗膏鵠甑叉榊薩鯖錆鮫餐" +
+	"杓灼酋楯薯藷哨鞘杖蝕訊逗摺撰煎煽穿箭詮噌遡揃遜腿蛸辿樽歎註瀦捗槌鎚辻挺鄭擢溺兎堵屠賭瀞遁謎灘楢禰牌這秤駁箸叛" +
+	"挽誹樋稗逼謬豹廟瀕斧蔽瞥蔑篇娩鞭庖蓬鱒迄儲餅籾爺鑓愈猷漣煉簾榔屢冤叟咬嘲囀徘扁棘橙狡甕甦疼祟竈筵篝腱艘芒虔蜃" +
+	"蠅訝靄靱騙鴉"
+
+// jis2004GlyphChanges は例示字形が変更された文字の集合です(初回利用時に構築する)
+var jis2004GlyphChanges = sync.OnceValue(func() map[rune]bool {
+	set := make(map[rune]bool, 168)
+	for _, r := range jis2004GlyphChangeChars {
+		set[r] = true
+	}
+	return set
+})
+
+// isJIS2004GlyphChange は JIS X 0213:2004 で例示字形が変更された文字であるかを返します
+func isJIS2004GlyphChange(r rune) bool {
+	return jis2004GlyphChanges()[r]
+}
+
+// GlyphChangeLine は例示字形が変更された文字を含む1行です
+type GlyphChangeLine struct {
+	Path  string   `json:"path"`
+	Line  int      `json:"line"`
+	Chars []string `json:"chars"` // 行に含まれる該当文字(出現順・重複なし)
+	Text  string   `json:"text"`
+}
+
+// ScanGlyphChanges は入力を走査し、例示字形が変更された文字を含む行をすべて返します
+func ScanGlyphChanges(r io.Reader, path string) ([]GlyphChangeLine, error) {
+	var lines []GlyphChangeLine
+	scanner := newLineReader(r)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := scanner.Text()
+		if lineNum == 1 {
+			line = strings.TrimPrefix(line, utf8BOM)
+		}
+		var chars []string
+		for _, c := range line {
+			if isJIS2004GlyphChange(c) && !slices.Contains(chars, string(c)) {
+				chars = append(chars, string(c))
+			}
+		}
+		if chars != nil {
+			lines = append(lines, GlyphChangeLine{Path: path, Line: lineNum, Chars: chars, Text: line})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading stream: %w", err)
+	}
+	return lines, nil
+}
+
+// WriteGlyphChanges は該当行の一覧を出力します。
+// テキスト形式では1行ごとに "a.txt:3: 辻 U+8FBB, 葛 U+845B: 行の内容" のように出力します。
+func WriteGlyphChanges(w io.Writer, lines []GlyphChangeLine, format string) error {
+	if format == FormatJSON || format == FormatJSONL {
+		enc := json.NewEncoder(w)
+		if format == FormatJSON {
+			enc.SetIndent("", "  ")
+			if lines == nil {
+				lines = []GlyphChangeLine{}
+			}
+			return enc.Encode(struct {
+				Schema string            `json:"schema"`
+				Lines  []GlyphChangeLine `json:"lines"`
+			}{SchemaVersion, lines})
+		}
+		for _, l := range lines {
+			if err := enc.Encode(l); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	for _, l := range lines {
+		chars := make([]string, len(l.Chars))
+		for i, c := range l.Chars {
+			chars[i] = c + " " + runeCodes(c)
+		}
+		if _, err := fmt.Fprintf(w, "%s:%d: %s: %s\n", l.Path, l.Line, strings.Join(chars, ", "), EscapeSnippet(l.Text, EscapeNonPrintable)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestJIS2004GlyphChangeChars(t *testing.T) {
+	if n := len(jis2004GlyphChanges()); n != 168 {
+		t.Errorf("len = %d, want 168", n)
+	}
+	for _, r := range jis2004GlyphChangeChars {
+		if !isJIS90(r) && classifyJIS2004(r) != AuditLevel3 {
+			t.Errorf("%q is neither in JIS X 0208 nor a level 3 kanji", r)
+		}
+	}
+}
+
+func TestScanGlyphChanges(t *testing.T) {
+	lines, err := ScanGlyphChanges(strings.NewReader("辻と葛と辻\n亜\n\xef\xbb\xbf逢\n"), "a.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lines) != 2 {
+		t.Fatalf("lines = %+v, want 2", lines)
+	}
+	if l := lines[0]; l.Line != 1 || strings.Join(l.Chars, "") != "辻葛" || l.Text != "辻と葛と辻" {
+		t.Errorf("lines[0] = %+v", l)
+	}
+	if l := lines[1]; l.Line != 3 || strings.Join(l.Chars, "") != "逢" {
+		t.Errorf("lines[1] = %+v", l)
+	}
+}
@@ -1,3 +1,10 @@
 module go-ObuJIS2004
 
 go 1.23.4
+
+require (
+	golang.org/x/term v0.27.0
+	golang.org/x/text v0.21.0
+)
+
+require golang.org/x/sys v0.28.0 // indirect
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+)
+
+// CaptureGroup は捕捉値ごとの該当数です
+type CaptureGroup struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
+
+// GroupByCapture は名前付きグループ name の捕捉値ごとに該当数を集計し、多い順に返します
+func GroupByCapture(res *SearchResult, name string) []CaptureGroup {
+	values := res.Captures[name]
+	groups := make([]CaptureGroup, 0, len(values))
+	for v, n := range values {
+		groups = append(groups, CaptureGroup{Value: v, Count: n})
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].Count != groups[j].Count {
+			return groups[i].Count > groups[j].Count
+		}
+		return groups[i].Value < groups[j].Value
+	})
+	return groups
+}
+
+// WriteCaptureRanking は捕捉値ごとの該当数を順位表として出力します。
+// 名前付きグループ name を持たないクエリは出力しません。
+func WriteCaptureRanking(w io.Writer, results map[string]*SearchResult, queryOrder []string, name string) {
+	rows := [][]string{{"クエリ", "順位", name, "該当数"}}
+	for _, q := range queryOrder {
+		res, ok := results[q]
+		if !ok {
+			continue
+		}
+		label := res.Query
+		for i, g := range GroupByCapture(res, name) {
+			rows = append(rows, []string{label, strconv.Itoa(i + 1), tableCell(g.Value), strconv.Itoa(g.Count)})
+			label = ""
+		}
+	}
+	if len(rows) == 1 {
+		fmt.Fprintf(w, "キャプチャ %s の値はありません\n", name)
+		return
+	}
+	writeTableRows(w, rows)
+}
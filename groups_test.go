@@ -0,0 +1,48 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// TestGroupByCapture は捕捉値ごとの順位表が表形式・JSONで出力されるか確認します
+func TestGroupByCapture(t *testing.T) {
+	input := "branch=010 髙\nbranch=020 髙\nbranch=010 髙\nbranch=030 髙\nbranch=010 髙\nbranch=020 髙\n"
+	query := `re:branch=(?P<branch>\d+) 髙`
+	results, err := SearchStreamWithOptions(strings.NewReader(input), []string{query}, SearchOptions{ContextSize: 2})
+	if err != nil {
+		t.Fatalf("SearchStreamWithOptions() error = %v", err)
+	}
+
+	out := new(bytes.Buffer)
+	opts := OutputOptions{Format: FormatTable, GroupBy: "branch"}
+	if err := WriteFormatted(out, results, []string{query}, opts); err != nil {
+		t.Fatalf("WriteFormatted() error = %v", err)
+	}
+	want := strings.Join([]string{
+		`クエリ                       | 順位 | branch | 該当数`,
+		`-----------------------------+------+--------+-------`,
+		`re:branch=(?P<branch>\d+) 髙 | 1    | 010    | 3`,
+		`                             | 2    | 020    | 2`,
+		`                             | 3    | 030    | 1`,
+	}, "\n") + "\n"
+	if out.String() != want {
+		t.Errorf("Ranking mismatch.\nGot:\n%s\nWant:\n%s", out.String(), want)
+	}
+
+	out.Reset()
+	opts.Format = FormatJSON
+	if err := WriteFormatted(out, results, []string{query}, opts); err != nil {
+		t.Fatalf("WriteFormatted() error = %v", err)
+	}
+	var report JSONReport
+	if err := json.Unmarshal(out.Bytes(), &report); err != nil {
+		t.Fatalf("Invalid JSON: %v", err)
+	}
+	groups := report.Results[0].Groups
+	if len(groups) != 3 || groups[0] != (CaptureGroup{Value: "010", Count: 3}) {
+		t.Errorf("Groups = %+v, want 010 ranked first with 3", groups)
+	}
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+)
+
+// 走査中にサイズが変わったファイルの扱い
+const (
+	ResizeStopped   = "stopped"   // 書き込み中で大きくなったが、走査開始時のサイズまでで止めた
+	ResizeContinued = "continued" // 書き込み中で大きくなり、-allow-growth により末尾まで走査した
+	ResizeTruncated = "truncated" // 走査中に切り詰められ、開始時のサイズより手前で終わった
+)
+
+// SizeChange は走査中にサイズが変わったファイルと、その扱いです。
+// 該当数は Scanned バイトまでの内容に対するもので、レポートを読む側がそれを判断できるよう記録します。
+type SizeChange struct {
+	Path    string `json:"path"`
+	Initial int64  `json:"initial"` // 走査開始時のサイズ
+	Final   int64  `json:"final"`   // 走査終了時のサイズ
+	Scanned int64  `json:"scanned"` // 実際に走査したバイト数
+	Action  string `json:"action"`  // stopped|continued|truncated
+}
+
+// String はテキスト形式のレポートに表示する説明を返します
+func (c SizeChange) String() string {
+	var action string
+	switch c.Action {
+	case ResizeStopped:
+		action = "開始時のサイズまで走査"
+	case ResizeContinued:
+		action = "末尾まで走査"
+	case ResizeTruncated:
+		action = "切り詰められた位置まで走査"
+	}
+	return fmt.Sprintf("%s %d → %d バイト (%s: %d バイト)", c.Path, c.Initial, c.Final, action, c.Scanned)
+}
+
+// sizeGuard は走査開始時のファイルのサイズを記録し、走査中のサイズの変化を検出します。
+// allowGrowth でなければ開始時のサイズを超えて読み込まないため、書き込み中のログでも該当数が走査の時点の内容と一致します。
+type sizeGuard struct {
+	file    interface{ Stat() (fs.FileInfo, error) }
+	r       io.Reader
+	initial int64
+	read    int64
+	allow   bool
+}
+
+// newSizeGuard は f が通常のファイルであれば sizeGuard で包みます(標準入力やパイプ等は nil を返します)
+func newSizeGuard(f io.Reader, allowGrowth bool) *sizeGuard {
+	file, ok := f.(interface{ Stat() (fs.FileInfo, error) })
+	if !ok {
+		return nil
+	}
+	info, err := file.Stat()
+	if err != nil || !info.Mode().IsRegular() {
+		return nil
+	}
+	g := &sizeGuard{file: file, r: f, initial: info.Size(), allow: allowGrowth}
+	if !allowGrowth {
+		g.r = io.LimitReader(f, info.Size())
+	}
+	return g
+}
+
+func (g *sizeGuard) Read(p []byte) (int, error) {
+	n, err := g.r.Read(p)
+	g.read += int64(n)
+	return n, err
+}
+
+// Change は走査を終えた時点でサイズが変わっていればその内容を返します(変わっていなければ nil)
+func (g *sizeGuard) Change(path string) *SizeChange {
+	if g == nil {
+		return nil
+	}
+	final := g.read
+	if info, err := g.file.Stat(); err == nil {
+		final = info.Size()
+	}
+	if final == g.initial && g.read == g.initial {
+		return nil
+	}
+	c := &SizeChange{Path: path, Initial: g.initial, Final: final, Scanned: g.read}
+	switch {
+	case g.read < g.initial:
+		c.Action = ResizeTruncated
+	case g.allow:
+		c.Action = ResizeContinued
+	default:
+		c.Action = ResizeStopped
+	}
+	return c
+}
+
+// Annotate はサイズの変化を各検索語の結果に記録します
+func (g *sizeGuard) Annotate(results map[string]*SearchResult, path string) {
+	c := g.Change(path)
+	if c == nil {
+		return
+	}
+	for _, res := range results {
+		res.Resized = append(res.Resized, *c)
+	}
+}
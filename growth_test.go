@@ -0,0 +1,112 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"io/fs"
+	"strings"
+	"testing"
+	"time"
+)
+
+// resizingFile は最初の読み込みの後にサイズが data の長さに変わるファイルです(書き込み中・切り詰め中のログを模す)
+type resizingFile struct {
+	data    []byte
+	initial int64
+	pos     int
+	started bool
+}
+
+func (f *resizingFile) Read(p []byte) (int, error) {
+	f.started = true
+	if f.pos >= len(f.data) {
+		return 0, io.EOF
+	}
+	n := copy(p, f.data[f.pos:])
+	f.pos += n
+	return n, nil
+}
+
+func (f *resizingFile) Close() error { return nil }
+
+func (f *resizingFile) Stat() (fs.FileInfo, error) {
+	size := f.initial
+	if f.started {
+		size = int64(len(f.data))
+	}
+	return resizingInfo(size), nil
+}
+
+type resizingInfo int64
+
+func (s resizingInfo) Name() string       { return "app.log" }
+func (s resizingInfo) Size() int64        { return int64(s) }
+func (s resizingInfo) Mode() fs.FileMode  { return 0o644 }
+func (s resizingInfo) ModTime() time.Time { return time.Time{} }
+func (s resizingInfo) IsDir() bool        { return false }
+func (s resizingInfo) Sys() any           { return nil }
+
+func TestSearchFile_Resized(t *testing.T) {
+	const before = "WARN 1\nWARN 2\n"
+	const appended = "WARN 3\n"
+	tests := []struct {
+		name        string
+		data        string
+		initial     int64
+		allowGrowth bool
+		wantCount   int
+		want        *SizeChange
+	}{
+		{"変化なし", before, int64(len(before)), false, 2, nil},
+		{"追記は開始時のサイズまで", before + appended, int64(len(before)), false, 2,
+			&SizeChange{Path: "app.log", Initial: 14, Final: 21, Scanned: 14, Action: ResizeStopped}},
+		{"-allow-growth は末尾まで", before + appended, int64(len(before)), true, 3,
+			&SizeChange{Path: "app.log", Initial: 14, Final: 21, Scanned: 21, Action: ResizeContinued}},
+		{"切り詰め", "WARN 1\n", int64(len(before)), false, 1,
+			&SizeChange{Path: "app.log", Initial: 14, Final: 7, Scanned: 7, Action: ResizeTruncated}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &resizingFile{data: []byte(tt.data), initial: tt.initial}
+			results, err := searchFile(f, "app.log", []string{"WARN"}, SearchOptions{ContextSize: 5, AllowGrowth: tt.allowGrowth}, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			res := results["WARN"]
+			if res.Count != tt.wantCount {
+				t.Errorf("Count = %d, want %d", res.Count, tt.wantCount)
+			}
+			if tt.want == nil {
+				if res.Resized != nil {
+					t.Errorf("Resized = %+v, want none", res.Resized)
+				}
+				return
+			}
+			if len(res.Resized) != 1 || res.Resized[0] != *tt.want {
+				t.Errorf("Resized = %+v, want %+v", res.Resized, *tt.want)
+			}
+		})
+	}
+}
+
+func TestRun_ResizedNote(t *testing.T) {
+	stdout := new(bytes.Buffer)
+	code := Run(AppContext{
+		Args:     []string{"app", "app.log"},
+		ExecPath: "app_WARN",
+		Stdout:   stdout,
+		Stderr:   io.Discard,
+		FileReader: func(string) (io.ReadCloser, error) {
+			return &resizingFile{data: []byte("WARN 1\nWARN 2\n"), initial: 7}, nil
+		},
+	})
+	if code != ExitMatch {
+		t.Fatalf("Run() exit code = %d", code)
+	}
+	if want := "サイズ変化: app.log 7 → 14 バイト (開始時のサイズまで走査: 7 バイト)\n"; !strings.Contains(stdout.String(), want) {
+		t.Errorf("output = %q, want it to contain %q", stdout.String(), want)
+	}
+	if !strings.Contains(stdout.String(), "WARN 1") || strings.Contains(stdout.String(), "WARN 2") {
+		t.Errorf("output = %q, want only the first line scanned", stdout.String())
+	}
+}
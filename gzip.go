@@ -0,0 +1,25 @@
+package main
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+// isGzipPath はパスがgzip圧縮されたファイルを指しているかを拡張子で判定します
+func isGzipPath(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), ".gz")
+}
+
+// SearchGzip はgzip圧縮された入力を展開しながら検索します。
+// 連結された複数のメンバーは(ローテート時の追記と同様に)1つのファイルの続きとして扱います。
+func SearchGzip(r io.Reader, queries []string, opts SearchOptions) (map[string]*SearchResult, error) {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("invalid gzip file: %w", err)
+	}
+	defer zr.Close()
+	return SearchStreamWithOptions(zr, queries, opts)
+}
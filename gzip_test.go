@@ -0,0 +1,48 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"strings"
+	"testing"
+)
+
+// gzipData は各内容を1つずつgzipのメンバーとして連結したデータを返します
+func gzipData(t *testing.T, members ...string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	for _, m := range members {
+		zw := gzip.NewWriter(&buf)
+		zw.Write([]byte(m))
+		if err := zw.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return buf.Bytes()
+}
+
+// TestRun_Gzip は .gz の入力を展開しながら検索し、連結されたメンバーを1つのファイルとして扱うか確認します
+func TestRun_Gzip(t *testing.T) {
+	data := gzipData(t, "INFO\nWARN a\n", "WARN b\n")
+	stdout := new(bytes.Buffer)
+	code := Run(AppContext{
+		Args:     []string{"app", "app.log.1.gz"},
+		ExecPath: "app_WARN",
+		Stdout:   stdout,
+		Stderr:   io.Discard,
+		FileReader: func(path string) (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(data)), nil
+		},
+	})
+	if code != 0 {
+		t.Fatalf("Run() exit code = %d", code)
+	}
+	if out := stdout.String(); !strings.Contains(out, "該当数: 2\n") || !strings.Contains(out, "line 3: WARN b") {
+		t.Errorf("Output should include hits from both members.\n Output: %s", out)
+	}
+
+	if _, err := SearchGzip(strings.NewReader("WARN plain\n"), []string{"WARN"}, SearchOptions{}); err == nil {
+		t.Error("SearchGzip() should reject uncompressed input")
+	}
+}
@@ -0,0 +1,10 @@
+//go:build !unix
+
+package main
+
+import "os"
+
+// fileInode はinode番号を持たない環境では常に0を返します(サイズのみで切り詰めを判定する)
+func fileInode(os.FileInfo) uint64 {
+	return 0
+}
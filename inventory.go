@@ -0,0 +1,139 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding"
+)
+
+// CharLocation は文字が最初に現れた位置です(行・桁は1始まり、桁は文字単位)
+type CharLocation struct {
+	Path string `json:"path"`
+	Line int    `json:"line"`
+	Col  int    `json:"col"`
+}
+
+// InventoryEntry は変換先の文字コードで表現できない1文字の出現状況です
+type InventoryEntry struct {
+	Char  string         `json:"char"`
+	Code  string         `json:"code"`
+	Count int            `json:"count"`
+	Files []CharLocation `json:"files"` // ファイルごとの初出位置(走査順)
+}
+
+// CharInventory は複数ファイルを跨いで問題文字の一覧を蓄積します。
+// 各文字は一度だけ記録し、ファイルごとの初出位置のみを保持するため、入力の量によらずメモリ使用量は文字の種類数で抑えられます。
+type CharInventory struct {
+	encoder  *encoding.Encoder
+	checked  map[rune]bool // 文字ごとの判定結果のキャッシュ(true なら表現できない)
+	entries  map[rune]*InventoryEntry
+	lastPath map[rune]string // 文字ごとに最後に初出を記録したファイル
+}
+
+// NewCharInventory は enc で表現できない文字を集める CharInventory を生成します
+func NewCharInventory(enc encoding.Encoding) *CharInventory {
+	return &CharInventory{
+		encoder:  enc.NewEncoder(),
+		checked:  make(map[rune]bool),
+		entries:  make(map[rune]*InventoryEntry),
+		lastPath: make(map[rune]string),
+	}
+}
+
+// Scan は1ファイル分の入力を走査して問題文字を記録します
+func (inv *CharInventory) Scan(r io.Reader, path string) error {
+	scanner := newLineReader(r)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := scanner.Text()
+		if lineNum == 1 {
+			line = strings.TrimPrefix(line, utf8BOM)
+		}
+		col := 0
+		for _, c := range line {
+			col++
+			if c < utf8.RuneSelf || !inv.isProblem(c) {
+				continue
+			}
+
+			entry, ok := inv.entries[c]
+			if !ok {
+				entry = &InventoryEntry{Char: string(c), Code: fmt.Sprintf("U+%04X", c)}
+				inv.entries[c] = entry
+			}
+			entry.Count++
+			if inv.lastPath[c] != path {
+				inv.lastPath[c] = path
+				entry.Files = append(entry.Files, CharLocation{Path: path, Line: lineNum, Col: col})
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("error reading stream: %w", err)
+	}
+	return nil
+}
+
+// isProblem は文字が変換先の文字コードで表現できないかを返します
+func (inv *CharInventory) isProblem(c rune) bool {
+	problem, ok := inv.checked[c]
+	if !ok {
+		_, err := inv.encoder.String(string(c))
+		problem = err != nil
+		inv.checked[c] = problem
+	}
+	return problem
+}
+
+// Entries は記録した文字をコードポイント順に返します
+func (inv *CharInventory) Entries() []*InventoryEntry {
+	runes := make([]rune, 0, len(inv.entries))
+	for c := range inv.entries {
+		runes = append(runes, c)
+	}
+	sort.Slice(runes, func(i, j int) bool { return runes[i] < runes[j] })
+
+	entries := make([]*InventoryEntry, len(runes))
+	for i, c := range runes {
+		entries[i] = inv.entries[c]
+	}
+	return entries
+}
+
+// WriteInventory は問題文字の一覧を出力します。
+// テキスト形式では1文字1行で "U+9AD9 髙 3件: a.log:1:5, b.log:2:1" のように出力します。
+func WriteInventory(w io.Writer, entries []*InventoryEntry, format string) error {
+	if format == FormatJSON || format == FormatJSONL {
+		enc := json.NewEncoder(w)
+		if format == FormatJSON {
+			enc.SetIndent("", "  ")
+			return enc.Encode(struct {
+				Schema string            `json:"schema"`
+				Chars  []*InventoryEntry `json:"chars"`
+			}{SchemaVersion, entries})
+		}
+		for _, e := range entries {
+			if err := enc.Encode(e); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	for _, e := range entries {
+		locs := make([]string, len(e.Files))
+		for i, loc := range e.Files {
+			locs[i] = fmt.Sprintf("%s:%d:%d", loc.Path, loc.Line, loc.Col)
+		}
+		if _, err := fmt.Fprintf(w, "%s %s %d件: %s\n", e.Code, e.Char, e.Count, strings.Join(locs, ", ")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
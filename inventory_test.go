@@ -0,0 +1,30 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"golang.org/x/text/encoding/japanese"
+)
+
+// TestCharInventory は問題文字が一度だけ、ファイルごとの初出位置付きで記録されるか確認します
+func TestCharInventory(t *testing.T) {
+	inv := NewCharInventory(japanese.ShiftJIS)
+	if err := inv.Scan(strings.NewReader("高橋\n鷗外 𠮷\n鷗\n"), "a.log"); err != nil {
+		t.Fatal(err)
+	}
+	if err := inv.Scan(strings.NewReader("x 鷗\n"), "b.log"); err != nil {
+		t.Fatal(err)
+	}
+
+	out := new(bytes.Buffer)
+	if err := WriteInventory(out, inv.Entries(), FormatText); err != nil {
+		t.Fatal(err)
+	}
+	want := "U+9DD7 鷗 3件: a.log:2:1, b.log:1:3\n" +
+		"U+20BB7 𠮷 1件: a.log:2:4\n"
+	if out.String() != want {
+		t.Errorf("Inventory mismatch.\nGot:\n%s\nWant:\n%s", out.String(), want)
+	}
+}
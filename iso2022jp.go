@@ -0,0 +1,272 @@
+package main
+
+import (
+	"bytes"
+	"sync"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/transform"
+)
+
+// ISO2022JP は ISO-2022-JP (メールのアーカイブ等で使われる7ビットのJIS)です。
+// 復号は ASCII・JIS X 0201 (ローマ字・カタカナ、SO/SI を含む)・JIS X 0208・JIS X 0212 に加え、
+// ISO-2022-JP-2004 の JIS X 0213 第1面・第2面(ESC $ ( Q / O / P)に対応します。
+// エスケープシーケンスによる文字集合の切り替えは行の中で引き継ぎ、改行で ASCII に戻します(RFC 1468 と同じく)。
+// 符号化は JIS X 0208 までの ISO-2022-JP として行います。
+var ISO2022JP encoding.Encoding = iso2022jpEncoding{}
+
+type iso2022jpEncoding struct{}
+
+func (iso2022jpEncoding) NewDecoder() *encoding.Decoder {
+	return &encoding.Decoder{Transformer: &iso2022jpDecoder{}}
+}
+
+func (iso2022jpEncoding) NewEncoder() *encoding.Encoder {
+	return japanese.ISO2022JP.NewEncoder()
+}
+
+func (iso2022jpEncoding) String() string { return "ISO-2022-JP" }
+
+// iso2022jpState はエスケープシーケンスで指示された文字集合です
+type iso2022jpState int
+
+const (
+	jisASCII iso2022jpState = iota
+	jisRoman
+	jisKatakana
+	jis0208
+	jis0212
+	jis0213Plane1
+	jis0213Plane2
+)
+
+// iso2022jpEscapes はエスケープシーケンスと、それが指示する文字集合です
+var iso2022jpEscapes = []struct {
+	seq   []byte
+	state iso2022jpState
+}{
+	{[]byte("\x1b(B"), jisASCII},
+	{[]byte("\x1b(J"), jisRoman},
+	{[]byte("\x1b(I"), jisKatakana},
+	{[]byte("\x1b$@"), jis0208},
+	{[]byte("\x1b$B"), jis0208},
+	{[]byte("\x1b$(B"), jis0208},
+	{[]byte("\x1b$(D"), jis0212},
+	{[]byte("\x1b$(O"), jis0213Plane1},
+	{[]byte("\x1b$(Q"), jis0213Plane1},
+	{[]byte("\x1b$(P"), jis0213Plane2},
+}
+
+const (
+	jisSO = 0x0E // 半角カタカナへの一時的な切り替え
+	jisSI = 0x0F // SO の解除
+)
+
+type iso2022jpDecoder struct {
+	state   iso2022jpState
+	shifted bool // SO で半角カタカナに切り替えている
+}
+
+func (d *iso2022jpDecoder) Reset() {
+	d.state, d.shifted = jisASCII, false
+}
+
+func (d *iso2022jpDecoder) Transform(dst, src []byte, atEOF bool) (nDst, nSrc int, err error) {
+	for nSrc < len(src) {
+		b := src[nSrc]
+		var runes [2]rune
+		n, size := 1, 1
+
+		switch {
+		case b == 0x1B:
+			seq, state, ok := matchJISEscape(src[nSrc:])
+			if !ok && !atEOF && partialJISEscape(src[nSrc:]) {
+				return nDst, nSrc, transform.ErrShortSrc
+			}
+			if ok {
+				d.state = state
+				nSrc += len(seq)
+				continue
+			}
+			// 未知のエスケープシーケンスはそのまま出力する
+			runes[0] = rune(b)
+		case b == '\n' || b == '\r':
+			d.Reset()
+			runes[0] = rune(b)
+		case b == jisSO || b == jisSI:
+			d.shifted = b == jisSO
+			nSrc++
+			continue
+		case b < 0x21 || b == 0x7F:
+			// 空白と制御文字はどの文字集合でもそのまま出力する
+			runes[0] = rune(b)
+		case b >= utf8.RuneSelf:
+			runes[0] = utf8.RuneError
+		case d.shifted || d.state == jisKatakana:
+			if b <= 0x5F {
+				runes[0] = 0xFF61 + rune(b-0x21)
+			} else {
+				runes[0] = utf8.RuneError
+			}
+		case d.state == jisASCII:
+			runes[0] = rune(b)
+		case d.state == jisRoman:
+			runes[0] = jisRomanRune(b)
+		default:
+			if nSrc+1 >= len(src) {
+				if !atEOF {
+					return nDst, nSrc, transform.ErrShortSrc
+				}
+				runes[0] = utf8.RuneError
+				break
+			}
+			trail := src[nSrc+1]
+			if trail < 0x21 || trail > 0x7E {
+				runes[0] = utf8.RuneError
+				break
+			}
+			size = 2
+			runes, n = d.lookup(b, trail)
+		}
+
+		need := 0
+		for _, r := range runes[:n] {
+			need += utf8.RuneLen(r)
+		}
+		if nDst+need > len(dst) {
+			return nDst, nSrc, transform.ErrShortDst
+		}
+		for _, r := range runes[:n] {
+			nDst += utf8.EncodeRune(dst[nDst:], r)
+		}
+		nSrc += size
+	}
+	return nDst, nSrc, nil
+}
+
+// lookup は2バイト文字集合の文字(区・点は 0x21〜0x7E)を返します。JIS X 0213 の一部は2文字の組み合わせになります。
+func (d *iso2022jpDecoder) lookup(b1, b2 byte) ([2]rune, int) {
+	var runes [2]rune
+	switch d.state {
+	case jis0208, jis0212:
+		jisTablesOnce.Do(buildJISTables)
+		table := jis0208Table
+		if d.state == jis0212 {
+			table = jis0212Table
+		}
+		runes[0] = table[int(b1-0x21)*94+int(b2-0x21)]
+	default:
+		lead, trail, ok := jis0213ToSJIS(d.state == jis0213Plane2, int(b1-0x20), int(b2-0x20))
+		if !ok {
+			break
+		}
+		if pair, found := sjis2004Pairs[uint16(lead)<<8|uint16(trail)]; found {
+			return pair, 2
+		}
+		if i := sjis2004Index(lead, trail); i >= 0 {
+			runes[0] = sjis2004Table[i]
+		}
+	}
+	if runes[0] == 0 {
+		runes[0] = utf8.RuneError
+	}
+	return runes, 1
+}
+
+// matchJISEscape は src の先頭のエスケープシーケンスを返します
+func matchJISEscape(src []byte) ([]byte, iso2022jpState, bool) {
+	for _, esc := range iso2022jpEscapes {
+		if bytes.HasPrefix(src, esc.seq) {
+			return esc.seq, esc.state, true
+		}
+	}
+	return nil, jisASCII, false
+}
+
+// partialJISEscape は src がエスケープシーケンスの途中までであるか(続きを読む必要があるか)を返します
+func partialJISEscape(src []byte) bool {
+	for _, esc := range iso2022jpEscapes {
+		if len(src) < len(esc.seq) && bytes.HasPrefix(esc.seq, src) {
+			return true
+		}
+	}
+	return false
+}
+
+// jisRomanRune は JIS X 0201 ローマ字の文字を返します(ASCIIとは円記号とオーバーラインのみ異なる)
+func jisRomanRune(b byte) rune {
+	switch b {
+	case 0x5C:
+		return '¥'
+	case 0x7E:
+		return '‾'
+	}
+	return rune(b)
+}
+
+// jis0213ToSJIS は JIS X 0213 の面・区・点を Shift_JIS-2004 の符号位置に変換します。
+// 第2面は Shift_JIS-2004 に割り当てのある区(1, 3〜5, 8, 12〜15, 78〜94)のみ変換できます。
+func jis0213ToSJIS(plane2 bool, row, cell int) (lead, trail byte, ok bool) {
+	if row < 1 || row > 94 || cell < 1 || cell > 94 {
+		return 0, 0, false
+	}
+	switch {
+	case !plane2 && row <= 62:
+		lead = byte((row + 0x101) >> 1)
+	case !plane2:
+		lead = byte((row + 0x181) >> 1)
+	case row == 1 || row == 8:
+		lead = 0xF0
+	case row == 3 || row == 4:
+		lead = 0xF1
+	case row == 5 || row == 12:
+		lead = 0xF2
+	case row == 13 || row == 14:
+		lead = 0xF3
+	case row == 15 || row == 78:
+		lead = 0xF4
+	case row >= 79:
+		lead = byte((row + 0x19B) >> 1)
+	default:
+		return 0, 0, false
+	}
+	switch {
+	case row%2 == 0:
+		trail = byte(cell + 0x9E)
+	case cell < 64:
+		trail = byte(cell + 0x3F)
+	default:
+		trail = byte(cell + 0x40)
+	}
+	return lead, trail, true
+}
+
+var (
+	jisTablesOnce sync.Once
+	jis0208Table  []rune // 区・点の順の94×94の表(0 は未定義)
+	jis0212Table  []rune
+)
+
+// buildJISTables は JIS X 0208・JIS X 0212 の表を、同じ文字集合を持つ EUC-JP の復号器から作成します
+func buildJISTables() {
+	jis0208Table = make([]rune, 94*94)
+	jis0212Table = make([]rune, 94*94)
+	decoder := japanese.EUCJP.NewDecoder()
+	for i := range 94 * 94 {
+		b1, b2 := byte(0xA1+i/94), byte(0xA1+i%94)
+		for _, t := range []struct {
+			table []rune
+			src   []byte
+		}{
+			{jis0208Table, []byte{b1, b2}},
+			{jis0212Table, []byte{0x8F, b1, b2}},
+		} {
+			decoded, err := decoder.Bytes(t.src)
+			if r, _ := utf8.DecodeRune(decoded); err == nil && r != utf8.RuneError {
+				t.table[i] = r
+			}
+		}
+	}
+}
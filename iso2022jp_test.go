@@ -0,0 +1,77 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/text/encoding/japanese"
+)
+
+// TestISO2022JPDecoder は行の中のエスケープシーケンスによる切り替えと、改行での ASCII への復帰を確認します
+func TestISO2022JPDecoder(t *testing.T) {
+	tests := []struct {
+		name, in, want string
+	}{
+		{"JIS X 0208", "\x1b$B$3$s\x1b(B ok\n", "こん ok\n"},
+		{"Reset at newline", "\x1b$B$3\n$3\x1b(B\n", "こ\n$3\n"},
+		{"Switch within line", "\x1b$B$3\x1b(I1\x1b$(Q\x7e\x65\x1b(B!\n", "こｱ鷗!\n"},
+		{"SO/SI", "a\x0e1\x0fb\n", "aｱb\n"},
+		{"JIS X 0201 Roman", "\x1b(J\\~\x1b(B\\\n", "¥‾\\\n"},
+		{"Combining pair", "\x1b$(Q\x24\x77\x1b(B\n", "か゚\n"},
+	}
+	for _, tt := range tests {
+		got, err := ISO2022JP.NewDecoder().String(tt.in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tt.want {
+			t.Errorf("%s: decoded %q, want %q", tt.name, got, tt.want)
+		}
+	}
+
+	// JIS X 0208 の範囲は x/text の ISO-2022-JP と同じ結果になる
+	text := "外字の監査ログです。～＼ｱ\n"
+	encoded, _ := japanese.ISO2022JP.NewEncoder().String(text)
+	if got, _ := ISO2022JP.NewDecoder().String(encoded); got != text {
+		t.Errorf("Round trip = %q, want %q", got, text)
+	}
+}
+
+// TestJIS0213ToSJIS は JIS X 0213 の面・区・点と Shift_JIS-2004 の符号位置の対応を確認します
+func TestJIS0213ToSJIS(t *testing.T) {
+	tests := []struct {
+		plane2      bool
+		row, cell   int
+		lead, trail byte
+	}{
+		{false, 1, 1, 0x81, 0x40},
+		{false, 2, 94, 0x81, 0xFC},
+		{false, 63, 64, 0xE0, 0x80},
+		{false, 94, 69, 0xEF, 0xE3},
+		{true, 1, 1, 0xF0, 0x40},
+		{true, 8, 1, 0xF0, 0x9F},
+		{true, 78, 94, 0xF4, 0xFC},
+		{true, 94, 94, 0xFC, 0xFC},
+	}
+	for _, tt := range tests {
+		lead, trail, ok := jis0213ToSJIS(tt.plane2, tt.row, tt.cell)
+		if !ok || lead != tt.lead || trail != tt.trail {
+			t.Errorf("jis0213ToSJIS(%t, %d, %d) = %02X %02X %t, want %02X %02X", tt.plane2, tt.row, tt.cell, lead, trail, ok, tt.lead, tt.trail)
+		}
+	}
+	if _, _, ok := jis0213ToSJIS(true, 2, 1); ok {
+		t.Error("Plane 2 row 2 has no Shift_JIS-2004 code")
+	}
+}
+
+// TestSearchISO2022JP は -enc auto で JIS X 0213 の文字を含むメールの本文を検索できるか確認します
+func TestSearchISO2022JP(t *testing.T) {
+	input := "Subject: test\n\x1b$B?9\x1b$(Q\x7e\x65\x1b$B30\x1b(B\n"
+	results, err := SearchStreamWithOptions(strings.NewReader(input), []string{"森鷗外"}, SearchOptions{InputEncoding: EncodingAuto})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res := results["森鷗外"]; res.Count != 1 || res.Encoding != "iso2022jp" {
+		t.Errorf("Result = %d %s, want 1 hit detected as iso2022jp", res.Count, res.Encoding)
+	}
+}
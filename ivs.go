@@ -0,0 +1,189 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// isVariationSelector は異体字セレクタ(IVS の U+E0100〜U+E01EF、SVS の U+FE00〜U+FE0F 等)であるかを返します
+func isVariationSelector(r rune) bool {
+	return unicode.Is(unicode.Variation_Selector, r)
+}
+
+// needsIVSStrip は行に異体字セレクタが含まれるかを返します
+func needsIVSStrip(line string) bool {
+	return strings.IndexFunc(line, isVariationSelector) >= 0
+}
+
+// stripVariationSelectors は異体字セレクタを取り除いた行と、取り除いた後の位置を元の行の位置へ戻すための区間の一覧を返します(-ignore-ivs)。
+// セレクタは直前の文字の区間に含めるため、基底の文字にヒットした場合はセレクタも含めた範囲が元の行のヒット位置になります。
+func stripVariationSelectors(line string) (string, []normSegment) {
+	var sb strings.Builder
+	sb.Grow(len(line))
+	var segs []normSegment
+	for i := 0; i < len(line); {
+		r, size := utf8.DecodeRuneInString(line[i:])
+		end := i + size
+		for end < len(line) {
+			vs, vsSize := utf8.DecodeRuneInString(line[end:])
+			if !isVariationSelector(vs) {
+				break
+			}
+			end += vsSize
+		}
+		if !isVariationSelector(r) {
+			segs = append(segs, normSegment{norm: sb.Len(), orig: i, origEnd: end})
+			sb.WriteRune(r)
+		}
+		i = end
+	}
+	return sb.String(), segs
+}
+
+// ivsMatcher は行から異体字セレクタを取り除いた写しで照合し、ヒット位置は元の行の位置で返します。
+// スニペットは元の行から切り出されます。
+type ivsMatcher struct {
+	inner matcher // セレクタを取り除いたクエリで照合するmatcher
+}
+
+func (m ivsMatcher) find(line string) []int {
+	if !needsIVSStrip(line) {
+		return m.inner.find(line)
+	}
+	text, segs := stripVariationSelectors(line)
+	loc := m.inner.find(text)
+	if loc == nil {
+		return nil
+	}
+	return origRange(segs, loc)
+}
+
+func (m ivsMatcher) findAll(line string) [][]int {
+	if !needsIVSStrip(line) {
+		return m.inner.findAll(line)
+	}
+	text, segs := stripVariationSelectors(line)
+	locs := m.inner.findAll(text)
+	for i, loc := range locs {
+		locs[i] = origRange(segs, loc)
+	}
+	return locs
+}
+
+func (m ivsMatcher) alwaysRedact() bool { return m.inner.alwaysRedact() }
+
+// IVSEntry は1つの異体字シーケンス(基底の文字と IVS の組)の出現状況です
+type IVSEntry struct {
+	Sequence string         `json:"sequence"`
+	Base     string         `json:"base"` // 基底の文字(行頭等で直前に文字がなければ空)
+	Code     string         `json:"code"` // "U+8FBB U+E0100" の形式
+	Selector string         `json:"selector"`
+	Count    int            `json:"count"`
+	Files    []CharLocation `json:"files"` // ファイルごとの初出位置(走査順)
+}
+
+// IVSReport は複数ファイルを跨いで異体字シーケンスの使用箇所を集計します(-ivs-report)。
+// CharInventory と同じく、各シーケンスはファイルごとの初出位置のみを保持します。
+type IVSReport struct {
+	entries  map[string]*IVSEntry
+	lastPath map[string]string
+}
+
+// NewIVSReport は IVSReport を生成します
+func NewIVSReport() *IVSReport {
+	return &IVSReport{entries: make(map[string]*IVSEntry), lastPath: make(map[string]string)}
+}
+
+// Scan は1ファイル分の入力を走査して異体字シーケンスを記録します
+func (rep *IVSReport) Scan(r io.Reader, path string) error {
+	scanner := newLineReader(r)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := scanner.Text()
+		if lineNum == 1 {
+			line = strings.TrimPrefix(line, utf8BOM)
+		}
+		col := 0
+		var prev rune = -1
+		for _, c := range line {
+			col++
+			if !isIVS(c) {
+				prev = c
+				continue
+			}
+
+			base, baseCol := "", col
+			if prev >= 0 && !isVariationSelector(prev) {
+				base, baseCol = string(prev), col-1
+			}
+			seq := base + string(c)
+			entry, ok := rep.entries[seq]
+			if !ok {
+				entry = &IVSEntry{Sequence: seq, Base: base, Code: runeCodes(seq), Selector: fmt.Sprintf("U+%04X", c)}
+				rep.entries[seq] = entry
+			}
+			entry.Count++
+			if rep.lastPath[seq] != path {
+				rep.lastPath[seq] = path
+				entry.Files = append(entry.Files, CharLocation{Path: path, Line: lineNum, Col: baseCol})
+			}
+			prev = c
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("error reading stream: %w", err)
+	}
+	return nil
+}
+
+// Entries は記録したシーケンスを基底の文字・セレクタの順に返します
+func (rep *IVSReport) Entries() []*IVSEntry {
+	entries := make([]*IVSEntry, 0, len(rep.entries))
+	for _, e := range rep.entries {
+		entries = append(entries, e)
+	}
+	// UTF-8 のバイト順はコードポイント順と一致する
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Sequence < entries[j].Sequence })
+	return entries
+}
+
+// WriteIVSReport は異体字シーケンスの一覧を出力します。
+// テキスト形式では1シーケンス1行で "U+8FBB U+E0100 辻󠄀 3件: a.txt:1:5, b.txt:2:1" のように出力します。
+func WriteIVSReport(w io.Writer, entries []*IVSEntry, format string) error {
+	if format == FormatJSON || format == FormatJSONL {
+		enc := json.NewEncoder(w)
+		if format == FormatJSON {
+			enc.SetIndent("", "  ")
+			if entries == nil {
+				entries = []*IVSEntry{}
+			}
+			return enc.Encode(struct {
+				Schema    string      `json:"schema"`
+				Sequences []*IVSEntry `json:"sequences"`
+			}{SchemaVersion, entries})
+		}
+		for _, e := range entries {
+			if err := enc.Encode(e); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	for _, e := range entries {
+		locs := make([]string, len(e.Files))
+		for i, loc := range e.Files {
+			locs[i] = fmt.Sprintf("%s:%d:%d", loc.Path, loc.Line, loc.Col)
+		}
+		if _, err := fmt.Fprintf(w, "%s %s %d件: %s\n", e.Code, e.Sequence, e.Count, strings.Join(locs, ", ")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
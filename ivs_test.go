@@ -0,0 +1,71 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestStripVariationSelectors(t *testing.T) {
+	line := "葛\U000E0100城と辻︀"
+	text, segs := stripVariationSelectors(line)
+	if text != "葛城と辻" {
+		t.Errorf("text = %q", text)
+	}
+	// 基底の文字のヒット位置はセレクタを含む
+	if got := origRange(segs, []int{0, 3}); got[0] != 0 || got[1] != len("葛\U000E0100") {
+		t.Errorf("origRange(葛) = %v", got)
+	}
+}
+
+func TestSearchIgnoreIVS(t *testing.T) {
+	input := "葛\U000E0100飾区\n葛飾区\n葛\U000E0101飾区\n"
+	for _, tt := range []struct {
+		query     string
+		ignoreIVS bool
+		want      int
+	}{
+		{"葛飾", false, 1},
+		{"葛飾", true, 3},
+		{"葛\U000E0100飾", true, 3},
+		{"@class:ivs", true, 2}, // 文字の種類のクエリはセレクタを取り除かずに判定する
+	} {
+		results, err := SearchStreamWithOptions(strings.NewReader(input), []string{tt.query}, SearchOptions{ContextSize: 1, IgnoreIVS: tt.ignoreIVS})
+		if err != nil {
+			t.Fatal(err)
+		}
+		res := results[tt.query]
+		if res.Count != tt.want {
+			t.Errorf("%q ignoreIVS=%v: Count = %d, want %d", tt.query, tt.ignoreIVS, res.Count, tt.want)
+		}
+		if tt.query == "葛飾" && tt.ignoreIVS && res.Snippets[0] != "葛\U000E0100飾区" {
+			t.Errorf("snippet = %q, want the original line with the selector", res.Snippets[0])
+		}
+	}
+}
+
+func TestIVSReport(t *testing.T) {
+	rep := NewIVSReport()
+	if err := rep.Scan(strings.NewReader("葛\U000E0100城\n\U000E0100と葛\U000E0100\n"), "a.txt"); err != nil {
+		t.Fatal(err)
+	}
+	if err := rep.Scan(strings.NewReader("辻\U000E0101\n"), "b.txt"); err != nil {
+		t.Fatal(err)
+	}
+	entries := rep.Entries()
+	if len(entries) != 3 {
+		t.Fatalf("Entries() = %+v", entries)
+	}
+
+	buf := new(bytes.Buffer)
+	if err := WriteIVSReport(buf, entries, FormatText); err != nil {
+		t.Fatal(err)
+	}
+	// 基底の文字がないセレクタ(行頭)はセレクタのみのシーケンスとして最後に並ぶ
+	want := "U+845B U+E0100 葛\U000E0100 2件: a.txt:1:1\n" +
+		"U+8FBB U+E0101 辻\U000E0101 1件: b.txt:1:1\n" +
+		"U+E0100 \U000E0100 1件: a.txt:2:1\n"
+	if buf.String() != want {
+		t.Errorf("output = %q, want %q", buf.String(), want)
+	}
+}
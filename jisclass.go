@@ -0,0 +1,157 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/japanese"
+)
+
+// jisRegexpClasses は正規表現クエリの中で \p{名前} として使える、JISの水準等による文字の種類です。
+// 値は文字クラスの中身(コードポイントの範囲の並び)を返します。
+var jisRegexpClasses = map[string]func() string{
+	"JIS3":     sync.OnceValue(func() string { return runeRanges(jisLevelRunes(false)) }),
+	"JIS4":     sync.OnceValue(func() string { return runeRanges(jisLevelRunes(true)) }),
+	"CP932EXT": sync.OnceValue(func() string { return runeRanges(cp932ExtRunes()) }),
+}
+
+// sjis2004Plane2Row は sjis2004Table で第2面(先頭バイト 0xF0 以降)が始まる行です
+const sjis2004Plane2Row = 0xF0 - 0xE0 + 0x1F
+
+// jisLevelRunes はJIS X 0213の第3水準(plane2 が偽)または第4水準(真)の漢字を返します。
+// 第3水準は第1面の漢字のうち JIS X 0208 に含まれないもの、第4水準は第2面の漢字です。
+func jisLevelRunes(plane2 bool) []rune {
+	var runes []rune
+	for i, r := range sjis2004Table {
+		if r == 0 || (i/0xBD >= sjis2004Plane2Row) != plane2 || !unicode.Is(unicode.Han, r) {
+			continue
+		}
+		if !plane2 && jis90Set()[r] {
+			continue
+		}
+		runes = append(runes, r)
+	}
+	return runes
+}
+
+// cp932ExtRunes はCP932(Windows-31J)で JIS X 0208 に追加された文字(NEC特殊文字・NEC選定IBM拡張文字・IBM拡張文字)を返します。
+// 外字領域(私用領域に対応する)は含めません。
+func cp932ExtRunes() []rune {
+	dec := japanese.ShiftJIS.NewDecoder()
+	var runes []rune
+	for lead := 0x81; lead <= 0xFC; lead++ {
+		if lead > 0x9F && lead < 0xE0 {
+			continue
+		}
+		for trail := 0x40; trail <= 0xFC; trail++ {
+			if trail == 0x7F {
+				continue
+			}
+			s, err := dec.Bytes([]byte{byte(lead), byte(trail)})
+			if err != nil {
+				continue
+			}
+			r, _ := utf8.DecodeRune(s)
+			if r == utf8.RuneError || r < utf8.RuneSelf || unicode.Is(unicode.Co, r) || isJIS90(r) {
+				continue
+			}
+			runes = append(runes, r)
+		}
+	}
+	return runes
+}
+
+// runeRanges は文字の集合を正規表現の文字クラスの中身(\x{4E00}-\x{4E05}... の形式)にします
+func runeRanges(runes []rune) string {
+	slices.Sort(runes)
+	runes = slices.Compact(runes)
+	var sb strings.Builder
+	for i := 0; i < len(runes); {
+		j := i
+		for j+1 < len(runes) && runes[j+1] == runes[j]+1 {
+			j++
+		}
+		fmt.Fprintf(&sb, `\x{%X}`, runes[i])
+		if j > i {
+			fmt.Fprintf(&sb, `-\x{%X}`, runes[j])
+		}
+		i = j + 1
+	}
+	return sb.String()
+}
+
+// expandJISClasses は正規表現中の \p{JIS3} 等の独自の文字の種類を、コードポイントの範囲の文字クラスに書き換えます。
+// 文字クラスの中([...])では \p{...} は使えますが、否定の \P{...} は使えません。
+// それ以外の \p{Han} 等はそのまま残します。
+func expandJISClasses(expr string) (string, error) {
+	var sb strings.Builder
+	inClass := false
+	for i := 0; i < len(expr); i++ {
+		c := expr[i]
+		switch {
+		case c == '\\' && i+1 < len(expr):
+			if ranges, n, ok := jisClassAt(expr[i:]); ok {
+				negate := expr[i+1] == 'P'
+				switch {
+				case inClass && negate:
+					return "", fmt.Errorf("%s cannot be used inside a bracketed class", expr[i:i+n])
+				case inClass:
+					sb.WriteString(ranges)
+				case negate:
+					sb.WriteString("[^" + ranges + "]")
+				default:
+					sb.WriteString("[" + ranges + "]")
+				}
+				i += n - 1
+				continue
+			}
+			sb.WriteString(expr[i : i+2])
+			i++
+			continue
+		case c == '[' && !inClass:
+			inClass = true
+			sb.WriteByte(c)
+			// 先頭の ] は文字として扱われる
+			if strings.HasPrefix(expr[i+1:], "^") {
+				sb.WriteByte('^')
+				i++
+			}
+			if strings.HasPrefix(expr[i+1:], "]") {
+				sb.WriteByte(']')
+				i++
+			}
+			continue
+		case c == '[' && strings.HasPrefix(expr[i:], "[:"):
+			// [[:alpha:]] 等のASCIIクラスは閉じ括弧ごと写す
+			if end := strings.Index(expr[i:], ":]"); end >= 0 {
+				sb.WriteString(expr[i : i+end+2])
+				i += end + 1
+				continue
+			}
+		case c == ']' && inClass:
+			inClass = false
+		}
+		sb.WriteByte(c)
+	}
+	return sb.String(), nil
+}
+
+// jisClassAt は s の先頭が \p{名前} または \P{名前} の独自の文字の種類であれば、その範囲と長さを返します
+func jisClassAt(s string) (ranges string, n int, ok bool) {
+	if !strings.HasPrefix(s, `\p{`) && !strings.HasPrefix(s, `\P{`) {
+		return "", 0, false
+	}
+	end := strings.IndexByte(s, '}')
+	if end < 0 {
+		return "", 0, false
+	}
+	class, found := jisRegexpClasses[s[3:end]]
+	if !found {
+		return "", 0, false
+	}
+	return class(), end + 1, true
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestJISRegexpClasses は \p{JIS3} 等がJISの水準・CP932の拡張文字に一致するか確認します
+func TestJISRegexpClasses(t *testing.T) {
+	tests := []struct {
+		expr string
+		r    rune
+		want bool
+	}{
+		{`\p{JIS3}`, '俱', true}, // 1-14-1
+		{`\p{JIS3}`, '亜', false},
+		{`\p{JIS3}`, '𠂉', false}, // 2-1-1(第4水準)
+		{`\p{JIS4}`, '𠂉', true},
+		{`\p{JIS4}`, '俱', false},
+		{`\p{CP932EXT}`, '髙', true}, // IBM拡張文字
+		{`\p{CP932EXT}`, '①', true}, // NEC特殊文字
+		{`\p{CP932EXT}`, '亜', false},
+		{`\P{JIS3}`, '亜', true},
+		{`[\p{JIS4}\p{CP932EXT}]`, '髙', true},
+		{`[^\p{JIS3}]`, '俱', false},
+		{`[[:alpha:]\p{JIS3}]`, 'x', true},
+		{`\p{Han}`, '亜', true},
+	}
+	for _, tt := range tests {
+		re, err := compileQueryRegexp("^" + tt.expr + "$")
+		if err != nil {
+			t.Fatalf("compileQueryRegexp(%q) error = %v", tt.expr, err)
+		}
+		if got := re.MatchString(string(tt.r)); got != tt.want {
+			t.Errorf("%s matches %q = %v, want %v", tt.expr, tt.r, got, tt.want)
+		}
+	}
+
+	if _, err := compileQueryRegexp(`[\P{JIS3}]`); err == nil {
+		t.Error("compileQueryRegexp() should reject a negated class inside brackets")
+	}
+	if got, _ := expandJISClasses(`a\\p{JIS3}[]\p{X}]`); got != `a\\p{JIS3}[]\p{X}]` {
+		t.Errorf("expandJISClasses() should leave escaped and unknown classes alone, got %q", got)
+	}
+	if got := runeRanges([]rune{'c', 'a', 'b', 'x', 'a'}); got != `\x{61}-\x{63}\x{78}` {
+		t.Errorf("runeRanges() = %s", got)
+	}
+}
+
+// TestSearchStream_Extended は -E で文字列のクエリも正規表現として照合するか確認します
+func TestSearchStream_Extended(t *testing.T) {
+	queries := []string{`髙橋|齋藤`, `\p{JIS3}+`, "@class:pua"}
+	results, err := SearchStreamWithOptions(strings.NewReader("齋藤 俱楽部\n髙橋\n"), queries, SearchOptions{Extended: true})
+	if err != nil {
+		t.Fatalf("SearchStreamWithOptions() error = %v", err)
+	}
+	if got := results[`髙橋|齋藤`].Count; got != 2 {
+		t.Errorf("alternation Count = %d, want 2", got)
+	}
+	if got := results[`\p{JIS3}+`].Count; got != 1 {
+		t.Errorf(`\p{JIS3} Count = %d, want 1`, got)
+	}
+	if err := ValidateQueries([]string{"(unclosed"}, true, ""); err == nil {
+		t.Error("ValidateQueries() should check plain queries as expressions with -E")
+	}
+	if err := ValidateQueries([]string{"(unclosed"}, false, ""); err != nil {
+		t.Errorf("ValidateQueries() without -E error = %v", err)
+	}
+}
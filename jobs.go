@@ -0,0 +1,383 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ジョブの状態
+const (
+	JobQueued  = "queued"
+	JobRunning = "running"
+	JobDone    = "done"
+	JobFailed  = "failed"
+)
+
+// 既定のジョブキュー設定
+const (
+	DefaultJobQueueSize  = 64
+	DefaultJobRetention  = time.Hour
+	DefaultJobMaxMatches = 100000 // 1ジョブあたりに保持するヒットの上限
+)
+
+// ヒット一覧の1ページあたりの件数
+const (
+	DefaultMatchPageSize = 100
+	MaxMatchPageSize     = 1000
+)
+
+// Job はサーバーで受け付けた1件の走査ジョブです
+type Job struct {
+	ID       string                   `json:"id"`
+	Status   string                   `json:"status"`
+	Path     string                   `json:"path"`
+	Error    string                   `json:"error,omitempty"`
+	Created  time.Time                `json:"created"`
+	Finished *time.Time               `json:"finished,omitempty"`
+	Results  map[string]*SearchResult `json:"results,omitempty"`
+	// MatchesTruncated はヒットが保持する上限を超え、以降のヒットを破棄したことを表します(該当数は Results にすべて含まれる)
+	MatchesTruncated bool `json:"matches_truncated,omitempty"`
+
+	// matches は保持しているヒットです。件数が多くなり得るため GET /jobs/{id}/matches でページ単位に返す
+	matches []Match
+}
+
+// MatchPage はヒット一覧の1ページです。NextOffset は続きがある場合のみ設定されます。
+// Truncated はジョブのヒットが保持する上限を超えたため、Total が全件ではないことを表します。
+type MatchPage struct {
+	Total      int     `json:"total"`
+	Offset     int     `json:"offset"`
+	NextOffset *int    `json:"next_offset,omitempty"`
+	Truncated  bool    `json:"truncated,omitempty"`
+	Matches    []Match `json:"matches"`
+}
+
+// JobQueue は走査ジョブを受け付け、同時実行数を制限しながら順に処理します。
+// 完了したジョブの結果は保持期間が過ぎると破棄されます。
+type JobQueue struct {
+	open       func(string) (io.ReadCloser, error)
+	mux        *http.ServeMux
+	slots      chan struct{} // 同時実行数の上限
+	maxQueued  int           // 実行待ちジョブ数の上限
+	maxMatches int           // 1ジョブあたりに保持するヒットの上限(0なら無制限)
+	retention  time.Duration
+	now        func() time.Time
+
+	mu     sync.Mutex
+	jobs   map[string]*Job
+	queued int
+	wg     sync.WaitGroup
+}
+
+// NewJobQueue は同時実行数 concurrency、待ち行列の長さ maxQueued のジョブキューを生成します。
+// 完了したジョブは保持期間が過ぎるまでヒットをメモリに保持するため、1ジョブあたり maxMatches 件(0なら無制限)までに制限します。
+// open には WorkerHandler と同じく、ルートディレクトリの下のみを開く関数(RootOpener)を渡します。
+func NewJobQueue(open func(string) (io.ReadCloser, error), concurrency, maxQueued, maxMatches int, retention time.Duration) *JobQueue {
+	q := &JobQueue{
+		open:       open,
+		slots:      make(chan struct{}, max(concurrency, 1)),
+		maxQueued:  maxQueued,
+		maxMatches: maxMatches,
+		retention:  retention,
+		now:        time.Now,
+		jobs:       make(map[string]*Job),
+	}
+	q.mux = q.routes()
+	return q
+}
+
+// Submit はジョブを登録し、実行枠が空き次第走査を開始します。
+// 待ち行列が上限に達している場合、パスがルートディレクトリの外を指す場合はエラーを返します。
+func (q *JobQueue) Submit(req ScanRequest) (*Job, error) {
+	if err := checkWorkerPath(req.Path); err != nil {
+		return nil, err
+	}
+	if err := req.Options.validate(req.Queries); err != nil {
+		return nil, fmt.Errorf("%w: %v", errInvalidJob, err)
+	}
+	id, err := newJobID()
+	if err != nil {
+		return nil, err
+	}
+
+	q.mu.Lock()
+	q.cleanup()
+	if q.queued >= q.maxQueued {
+		q.mu.Unlock()
+		return nil, errQueueFull
+	}
+	job := &Job{ID: id, Status: JobQueued, Path: req.Path, Created: q.now()}
+	q.jobs[id] = job
+	q.queued++
+	snapshot := *job
+	q.mu.Unlock()
+
+	q.wg.Add(1)
+	go q.run(job, req)
+	return &snapshot, nil
+}
+
+// ジョブを受け付けられない理由
+var (
+	errQueueFull  = errors.New("job queue is full")
+	errInvalidJob = errors.New("invalid request")
+)
+
+// run は実行枠を確保してからジョブを処理します
+func (q *JobQueue) run(job *Job, req ScanRequest) {
+	defer q.wg.Done()
+	q.slots <- struct{}{}
+	defer func() { <-q.slots }()
+
+	q.mu.Lock()
+	q.queued--
+	job.Status = JobRunning
+	q.mu.Unlock()
+
+	var matches []Match
+	truncated := false
+	results, err := q.scan(req, func(m Match) {
+		if q.maxMatches > 0 && len(matches) >= q.maxMatches {
+			truncated = true
+			return
+		}
+		matches = append(matches, m)
+	})
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	finished := q.now()
+	job.Finished = &finished
+	if err != nil {
+		job.Status = JobFailed
+		job.Error = err.Error()
+		return
+	}
+	job.Status = JobDone
+	job.Results = results
+	job.matches = matches
+	job.MatchesTruncated = truncated
+}
+
+// scan は1件の走査要求を実行し、ヒットするたびに onMatch を呼び出します。
+// POST /scan と同じく圧縮ファイルも展開して検索します。
+func (q *JobQueue) scan(req ScanRequest, onMatch func(Match)) (map[string]*SearchResult, error) {
+	f, err := q.open(req.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", req.Path, err)
+	}
+	defer f.Close()
+	opts := req.Options.SearchOptions()
+	opts.OnMatch = onMatch
+	return searchFile(f, req.Path, req.Queries, opts, func() (string, error) {
+		return "", errors.New("encrypted zip entries are not supported by workers")
+	})
+}
+
+// Matches は完了したジョブのヒットを offset 件目から最大 limit 件返します。
+// query が空でなければその検索語のヒットのみを対象にします。
+func (q *JobQueue) Matches(id, query string, offset, limit int) (MatchPage, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.cleanup()
+	job, ok := q.jobs[id]
+	if !ok {
+		return MatchPage{}, errJobNotFound
+	}
+	if job.Status != JobDone {
+		return MatchPage{}, errJobNotDone
+	}
+
+	matches := job.matches
+	if query != "" {
+		matches = nil
+		for _, m := range job.matches {
+			if m.Query == query {
+				matches = append(matches, m)
+			}
+		}
+	}
+
+	page := MatchPage{Total: len(matches), Offset: offset, Truncated: job.MatchesTruncated, Matches: []Match{}}
+	if offset < len(matches) {
+		end := min(offset+limit, len(matches))
+		page.Matches = matches[offset:end]
+		if end < len(matches) {
+			page.NextOffset = &end
+		}
+	}
+	return page, nil
+}
+
+// ジョブの参照に失敗した理由
+var (
+	errJobNotFound = errors.New("job not found")
+	errJobNotDone  = errors.New("job has not finished successfully")
+)
+
+// Get はジョブの現在の状態の複製を返します
+func (q *JobQueue) Get(id string) (Job, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.cleanup()
+	job, ok := q.jobs[id]
+	if !ok {
+		return Job{}, false
+	}
+	return *job, true
+}
+
+// Delete は完了したジョブを結果ごと破棄します。実行中・実行待ちのジョブは破棄できません。
+func (q *JobQueue) Delete(id string) (found, deleted bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	job, ok := q.jobs[id]
+	if !ok {
+		return false, false
+	}
+	if job.Finished == nil {
+		return true, false
+	}
+	delete(q.jobs, id)
+	return true, true
+}
+
+// Wait は登録済みのすべてのジョブの完了を待ちます
+func (q *JobQueue) Wait() {
+	q.wg.Wait()
+}
+
+// cleanup は保持期間を過ぎた完了済みジョブを破棄します(q.mu を保持して呼ぶこと)
+func (q *JobQueue) cleanup() {
+	if q.retention <= 0 {
+		return
+	}
+	now := q.now()
+	for id, job := range q.jobs {
+		if job.Finished != nil && now.Sub(*job.Finished) > q.retention {
+			delete(q.jobs, id)
+		}
+	}
+}
+
+// ServeHTTP はジョブの登録(POST /jobs)、状態確認(GET /jobs/{id})、
+// ヒット一覧のページ単位の取得(GET /jobs/{id}/matches?query=&offset=&limit=)、破棄(DELETE /jobs/{id})を処理します
+func (q *JobQueue) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	q.mux.ServeHTTP(w, r)
+}
+
+// routes は ServeHTTP で処理する経路を登録した ServeMux を返します
+func (q *JobQueue) routes() *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /jobs", func(w http.ResponseWriter, r *http.Request) {
+		var req ScanRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, fmt.Sprintf("invalid request: %v", err), http.StatusBadRequest)
+			return
+		}
+
+		job, err := q.Submit(req)
+		if errors.Is(err, errOutsideRoot) {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		if errors.Is(err, errInvalidJob) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if errors.Is(err, errQueueFull) {
+			w.Header().Set("Retry-After", "5")
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Location", "/jobs/"+job.ID)
+		writeJob(w, http.StatusAccepted, *job)
+	})
+	mux.HandleFunc("GET /jobs/{id}", func(w http.ResponseWriter, r *http.Request) {
+		job, ok := q.Get(r.PathValue("id"))
+		if !ok {
+			http.Error(w, "job not found", http.StatusNotFound)
+			return
+		}
+		writeJob(w, http.StatusOK, job)
+	})
+	mux.HandleFunc("GET /jobs/{id}/matches", func(w http.ResponseWriter, r *http.Request) {
+		offset, err := pageParam(r, "offset", 0)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		limit, err := pageParam(r, "limit", DefaultMatchPageSize)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		limit = min(max(limit, 1), MaxMatchPageSize)
+
+		page, err := q.Matches(r.PathValue("id"), r.URL.Query().Get("query"), offset, limit)
+		switch {
+		case errors.Is(err, errJobNotFound):
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		case errors.Is(err, errJobNotDone):
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(page)
+	})
+	mux.HandleFunc("DELETE /jobs/{id}", func(w http.ResponseWriter, r *http.Request) {
+		found, deleted := q.Delete(r.PathValue("id"))
+		switch {
+		case !found:
+			http.Error(w, "job not found", http.StatusNotFound)
+		case !deleted:
+			http.Error(w, "job is still running", http.StatusConflict)
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+	return mux
+}
+
+// pageParam はページ指定のクエリパラメータを0以上の整数として読み取ります(未指定なら def)
+func pageParam(r *http.Request, name string, def int) (int, error) {
+	v := r.URL.Query().Get(name)
+	if v == "" {
+		return def, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid %s: %s", name, v)
+	}
+	return n, nil
+}
+
+// writeJob はジョブの状態をJSONで返します
+func writeJob(w http.ResponseWriter, status int, job Job) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(job)
+}
+
+// newJobID は推測されにくいジョブIDを生成します
+func newJobID() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate job id: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
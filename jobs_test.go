@@ -0,0 +1,203 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestJobQueue はジョブの登録・状態確認・上限・保持期間による破棄を確認します
+func TestJobQueue(t *testing.T) {
+	release := make(chan struct{})
+	open := func(path string) (io.ReadCloser, error) {
+		if path == "slow.log" {
+			<-release
+		}
+		return io.NopCloser(strings.NewReader("WARN a\nWARN b\n")), nil
+	}
+
+	q := NewJobQueue(open, 1, 1, 0, time.Minute)
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	q.now = func() time.Time { return now }
+
+	srv := httptest.NewServer(q)
+	defer srv.Close()
+
+	submit := func(path string) *http.Response {
+		body := `{"path":"` + path + `","queries":["WARN"],"options":{"context_size":2}}`
+		resp, err := http.Post(srv.URL+"/jobs", "application/json", strings.NewReader(body))
+		if err != nil {
+			t.Fatalf("POST /jobs error = %v", err)
+		}
+		return resp
+	}
+
+	// 1件目が実行枠を占有し、2件目が待ち行列に入り、3件目は拒否される
+	first := submit("slow.log")
+	var running Job
+	json.NewDecoder(first.Body).Decode(&running)
+	first.Body.Close()
+	if first.StatusCode != http.StatusAccepted || running.ID == "" {
+		t.Fatalf("First submit = %d %+v, want 202 with an id", first.StatusCode, running)
+	}
+	waitForStatus(t, q, running.ID, JobRunning)
+
+	second := submit("fast.log")
+	second.Body.Close()
+	if second.StatusCode != http.StatusAccepted {
+		t.Fatalf("Second submit status = %d, want 202", second.StatusCode)
+	}
+	third := submit("fast.log")
+	third.Body.Close()
+	if third.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("Third submit status = %d, want 503 when the queue is full", third.StatusCode)
+	}
+
+	// 実行中のジョブは破棄できない
+	req, _ := http.NewRequest(http.MethodDelete, srv.URL+"/jobs/"+running.ID, nil)
+	if resp, err := http.DefaultClient.Do(req); err != nil || resp.StatusCode != http.StatusConflict {
+		t.Errorf("DELETE running job = %v %v, want 409", resp.StatusCode, err)
+	}
+
+	close(release)
+	q.Wait()
+
+	resp, err := http.Get(srv.URL + "/jobs/" + running.ID)
+	if err != nil {
+		t.Fatalf("GET /jobs/{id} error = %v", err)
+	}
+	var done Job
+	json.NewDecoder(resp.Body).Decode(&done)
+	resp.Body.Close()
+	if done.Status != JobDone || done.Results["WARN"].Count != 2 {
+		t.Errorf("Finished job = %+v, want done with 2 hits", done)
+	}
+
+	// 保持期間を過ぎた結果は破棄される
+	now = now.Add(2 * time.Minute)
+	if _, ok := q.Get(running.ID); ok {
+		t.Error("Job should be removed after the retention period")
+	}
+}
+
+// waitForStatus はジョブが指定の状態になるまで待ちます
+func waitForStatus(t *testing.T, q *JobQueue, id, status string) {
+	t.Helper()
+	for range 100 {
+		if job, _ := q.Get(id); job.Status == status {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("Job %s did not reach status %s", id, status)
+}
+
+// TestJobMatchesPagination はヒット一覧がページ単位で取得できるか確認します
+func TestJobMatchesPagination(t *testing.T) {
+	open := func(string) (io.ReadCloser, error) {
+		return io.NopCloser(strings.NewReader("WARN 1\nERROR 2\nWARN 3\nWARN 4\nWARN 5\n")), nil
+	}
+	q := NewJobQueue(open, 1, 1, 0, time.Minute)
+	srv := httptest.NewServer(q)
+	defer srv.Close()
+
+	job, err := q.Submit(ScanRequest{Path: "app.log", Queries: []string{"WARN", "ERROR"}, Options: RemoteOptions{ContextSize: 2}})
+	if err != nil {
+		t.Fatalf("Submit() error = %v", err)
+	}
+	q.Wait()
+
+	get := func(params string) (int, MatchPage) {
+		resp, err := http.Get(srv.URL + "/jobs/" + job.ID + "/matches?" + params)
+		if err != nil {
+			t.Fatalf("GET matches error = %v", err)
+		}
+		defer resp.Body.Close()
+		var page MatchPage
+		json.NewDecoder(resp.Body).Decode(&page)
+		return resp.StatusCode, page
+	}
+
+	_, first := get("query=WARN&limit=3")
+	if first.Total != 4 || len(first.Matches) != 3 || first.NextOffset == nil || *first.NextOffset != 3 {
+		t.Fatalf("First page = %+v, want 3 of 4 with next_offset 3", first)
+	}
+	if m := first.Matches[1]; m.Line != 3 || m.Offset != 15 || m.Snippet != "WARN 3" {
+		t.Errorf("Match = %+v", m)
+	}
+	_, last := get("query=WARN&limit=3&offset=3")
+	if len(last.Matches) != 1 || last.NextOffset != nil || last.Matches[0].Line != 5 {
+		t.Errorf("Last page = %+v, want the remaining hit without next_offset", last)
+	}
+	if _, all := get(""); all.Total != 5 {
+		t.Errorf("Total without query = %d, want 5", all.Total)
+	}
+	if status, _ := get("limit=-1"); status != http.StatusBadRequest {
+		t.Errorf("Invalid limit status = %d, want 400", status)
+	}
+}
+
+// TestJobQueueInputs はルートディレクトリの外を指すジョブが拒否され、圧縮ファイルは展開して走査されるか確認します
+func TestJobQueueInputs(t *testing.T) {
+	var gz bytes.Buffer
+	zw := gzip.NewWriter(&gz)
+	zw.Write([]byte("WARN a\nINFO\nWARN b\n"))
+	zw.Close()
+	open := func(path string) (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(gz.Bytes())), nil
+	}
+	q := NewJobQueue(open, 1, 4, 0, time.Minute)
+	srv := httptest.NewServer(q)
+	defer srv.Close()
+
+	for _, path := range []string{"../etc/passwd", "/etc/passwd"} {
+		resp, err := http.Post(srv.URL+"/jobs", "application/json", strings.NewReader(`{"path":"`+path+`","queries":["WARN"]}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusForbidden {
+			t.Errorf("POST /jobs %s status = %d, want 403", path, resp.StatusCode)
+		}
+	}
+
+	job, err := q.Submit(ScanRequest{Path: "app.log.gz", Queries: []string{"WARN"}})
+	if err != nil {
+		t.Fatalf("Submit() error = %v", err)
+	}
+	q.Wait()
+	if done, _ := q.Get(job.ID); done.Status != JobDone || done.Results["WARN"].Count != 2 || done.Results["WARN"].Lines != 3 {
+		t.Errorf("Job = %+v, want 2 hits in 3 decompressed lines", done)
+	}
+}
+
+// TestJobMatchesLimit は保持するヒットが上限で打ち切られ、そのことが示されるか確認します
+func TestJobMatchesLimit(t *testing.T) {
+	open := func(string) (io.ReadCloser, error) {
+		return io.NopCloser(strings.NewReader(strings.Repeat("WARN\n", 10))), nil
+	}
+	q := NewJobQueue(open, 1, 1, 3, time.Minute)
+	job, err := q.Submit(ScanRequest{Path: "app.log", Queries: []string{"WARN"}})
+	if err != nil {
+		t.Fatalf("Submit() error = %v", err)
+	}
+	q.Wait()
+
+	done, _ := q.Get(job.ID)
+	if !done.MatchesTruncated || done.Results["WARN"].Count != 10 {
+		t.Errorf("Job = %+v, want truncated matches with all 10 hits counted", done)
+	}
+	page, err := q.Matches(job.ID, "", 0, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if page.Total != 3 || len(page.Matches) != 3 || !page.Truncated {
+		t.Errorf("Page = %+v, want 3 kept matches marked as truncated", page)
+	}
+}
@@ -0,0 +1,32 @@
+package main
+
+import "strings"
+
+// foldKanaRune はカタカナを対応するひらがなに置き換えます(-fold-kana)。
+// ひらがなのないカタカナ(ヷ等)と長音記号はそのまま残します。
+func foldKanaRune(r rune) rune {
+	switch {
+	case r >= 'ァ' && r <= 'ヶ':
+		return r - ('ァ' - 'ぁ')
+	case r == 'ヽ' || r == 'ヾ':
+		return r - ('ヽ' - 'ゝ')
+	}
+	return r
+}
+
+// foldKana は文字列のカタカナをひらがなに置き換えます。置き換える文字はどちらも3バイトのため、バイト位置は変わりません。
+func foldKana(s string) string {
+	return strings.Map(foldKanaRune, s)
+}
+
+// kanaMatcher はカタカナをひらがなに揃えた行の写しで照合します。
+// 揃えてもバイト位置は変わらないため、ヒット位置はそのまま元の行の位置として使えます。
+type kanaMatcher struct {
+	inner matcher // ひらがなに揃えたクエリで照合するmatcher
+}
+
+func (m kanaMatcher) find(line string) []int { return m.inner.find(foldKana(line)) }
+
+func (m kanaMatcher) findAll(line string) [][]int { return m.inner.findAll(foldKana(line)) }
+
+func (m kanaMatcher) alwaysRedact() bool { return m.inner.alwaysRedact() }
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestSearchStream_FoldKana は -fold-kana でひらがなのクエリがカタカナの表記にもヒットし、文字の範囲のクエリは元の文字で判定するか確認します
+func TestSearchStream_FoldKana(t *testing.T) {
+	content := "カタログ請求\nかたろぐ送付\nｶﾀﾛｸﾞ\nヴァイオリン\n"
+	queries := []string{"かたろぐ", "ヴぁいおりん", "U+3041..U+3096"}
+
+	results, err := SearchStreamWithOptions(strings.NewReader(content), queries, SearchOptions{ContextSize: 1, FoldKana: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res := results["かたろぐ"]; res.Count != 2 || res.Snippets[0] != "カタログ請" {
+		t.Errorf("かたろぐ: count = %d, snippets = %q", res.Count, res.Snippets)
+	}
+	if res := results["ヴぁいおりん"]; res.Count != 1 {
+		t.Errorf("ヴぁいおりん: count = %d, want 1", res.Count)
+	}
+	if res := results["U+3041..U+3096"]; res.Count != 1 {
+		t.Errorf("@class:hiragana: count = %d, want 1 (katakana lines should not be folded)", res.Count)
+	}
+
+	// -fold-width と組み合わせると半角カタカナにもヒットする
+	results, err = SearchStreamWithOptions(strings.NewReader(content), queries[:1], SearchOptions{ContextSize: 1, FoldKana: true, FoldWidth: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res := results["かたろぐ"]; res.Count != 3 || res.Snippets[2] != "ｶﾀﾛｸﾞ" {
+		t.Errorf("With -fold-width: count = %d, snippets = %q", res.Count, res.Snippets)
+	}
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ReportLayout はテキスト形式レポートの見出し・ラベル・区切り線を保持します。
+// 既存の出力を取り込む下流のパーサ向けに、目印となる文字列を宣言的に変更できます。
+type ReportLayout struct {
+	Header          string // 検索語ごとの見出し({query} を検索語に置換)
+	CountLabel      string
+	SuppressedLabel string
+	EstimateLabel   string
+	MaxLabel        string
+	DensityLabel    string
+	VariantsLabel   string
+	CaptureLabel    string
+	RangeLabel      string
+	FilesLabel      string
+	ConvertLabel    string
+	SnippetPrefix   string // スニペットの前置き({line} を行番号、{offset} を行頭のバイト位置、{n} を通し番号に置換)
+	Separator       string
+	Color           bool // スニペットのヒット部分をANSIエスケープシーケンスで色付けする(-color、レイアウトファイルでは指定しない)
+}
+
+// DefaultLayout は従来どおりのレポート形式です
+var DefaultLayout = ReportLayout{
+	Header:          "[{query}]",
+	CountLabel:      "該当数",
+	SuppressedLabel: "除外",
+	EstimateLabel:   "推定該当数",
+	MaxLabel:        "上限",
+	DensityLabel:    "密度",
+	VariantsLabel:   "表記別",
+	CaptureLabel:    "キャプチャ",
+	RangeLabel:      "出現範囲",
+	FilesLabel:      "ファイル別",
+	ConvertLabel:    "  変換後",
+	SnippetPrefix:   "line {line}: ",
+	Separator:       "-----------------------",
+}
+
+// layoutFields はレイアウトファイルのキーと対応するフィールドを返します
+func (l *ReportLayout) layoutFields() map[string]*string {
+	return map[string]*string{
+		"header":           &l.Header,
+		"count_label":      &l.CountLabel,
+		"suppressed_label": &l.SuppressedLabel,
+		"estimate_label":   &l.EstimateLabel,
+		"max_label":        &l.MaxLabel,
+		"density_label":    &l.DensityLabel,
+		"variants_label":   &l.VariantsLabel,
+		"capture_label":    &l.CaptureLabel,
+		"range_label":      &l.RangeLabel,
+		"files_label":      &l.FilesLabel,
+		"convert_label":    &l.ConvertLabel,
+		"snippet_prefix":   &l.SnippetPrefix,
+		"separator":        &l.Separator,
+	}
+}
+
+// Set はキーに対応するレイアウト要素を設定します
+func (l *ReportLayout) Set(key, value string) error {
+	field, ok := l.layoutFields()[key]
+	if !ok {
+		return fmt.Errorf("unknown layout key: %s", key)
+	}
+	*field = value
+	return nil
+}
+
+// LoadLayout は `key = value` 形式のレイアウトファイルを読み込み、base を上書きした結果を返します。
+// 値の前後の空白は無視されるため、空白を含めたい場合は "..." で囲みます(Goの文字列リテラルとして解釈)。
+func LoadLayout(r io.Reader, base ReportLayout) (ReportLayout, error) {
+	layout := base
+
+	scanner := bufio.NewScanner(r)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return layout, fmt.Errorf("layout line %d: expected key = value", lineNum)
+		}
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		if strings.HasPrefix(value, `"`) {
+			unquoted, err := strconv.Unquote(value)
+			if err != nil {
+				return layout, fmt.Errorf("layout line %d: invalid quoted value: %s", lineNum, value)
+			}
+			value = unquoted
+		}
+
+		if err := layout.Set(key, value); err != nil {
+			return layout, fmt.Errorf("layout line %d: %w", lineNum, err)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return layout, fmt.Errorf("error reading layout: %w", err)
+	}
+	return layout, nil
+}
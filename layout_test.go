@@ -0,0 +1,55 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// TestLoadLayout はレイアウトファイルで見出し・ラベル・区切り線を変更できるか確認します
+func TestLoadLayout(t *testing.T) {
+	src := "# site A\nheader = ## {query}\ncount_label = hits\nseparator = \"=== end ===\"\n"
+	layout, err := LoadLayout(strings.NewReader(src), DefaultLayout)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	results, err := SearchStream(strings.NewReader("WARN x\n"), []string{"WARN"}, 1)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	out := new(bytes.Buffer)
+	WriteResultsWithLayout(out, results, []string{"WARN"}, layout)
+	for _, want := range []string{"## WARN\nhits: 1\n", "\n=== end ===\n"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("Output should contain %q.\n Output: %s", want, out.String())
+		}
+	}
+
+	if _, err := LoadLayout(strings.NewReader("footer = x"), DefaultLayout); err == nil {
+		t.Error("LoadLayout() should fail on unknown key")
+	}
+}
+
+// TestWriteResults_SnippetPrefix はスニペットの前置きに行番号と行頭のバイト位置を表示できるか確認します
+func TestWriteResults_SnippetPrefix(t *testing.T) {
+	results, err := SearchStream(strings.NewReader("INFO start\nWARN x\nINFO\nWARN y\n"), []string{"WARN"}, 1)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	out := new(bytes.Buffer)
+	WriteResults(out, results, []string{"WARN"})
+	if !strings.Contains(out.String(), "\nline 2: WARN \nline 4: WARN \n") {
+		t.Errorf("Default output should prefix snippets with line numbers.\n Output: %s", out.String())
+	}
+
+	layout := DefaultLayout
+	layout.SnippetPrefix = "{n}) line {line} @{offset}: "
+	out.Reset()
+	WriteResultsWithLayout(out, results, []string{"WARN"}, layout)
+	if !strings.Contains(out.String(), "\n1) line 2 @11: WARN \n2) line 4 @23: WARN \n") {
+		t.Errorf("Custom prefix mismatch.\n Output: %s", out.String())
+	}
+}
@@ -0,0 +1,220 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/transform"
+)
+
+// lineReaderBufferSize は lineReader が一度に読み込むバイト数です
+const lineReaderBufferSize = 64 << 10
+
+// lineReader は入力を1行ずつ読み込みます。bufio.Scanner と同じ使い方で、行末の改行を取り除いた行を返します。
+// 改行は LF・CRLF・CRのみ(古いMac形式)のいずれも行の区切りとし、混在していても構いません。
+// そのため Windows と Unix のどちらで作られたファイルでも行数が一致し、行に CR が残ることはありません。
+// bufio.Scanner と違い行の長さに上限がなく、改行のない巨大な行(圧縮されたJSON等)もそのまま読み込みます。
+type lineReader struct {
+	br      *bufio.Reader
+	line    []byte
+	rawLen  int    // 直前の行の改行を含むバイト数
+	pending []byte // 読み込み済みで、まだ返していない部分(LFで終わる塊の中の、CRで区切られた残りの行)
+	long    []byte // バッファに収まらない行を組み立てる領域(次の長い行で再利用する)
+	eof     bool
+	err     error
+}
+
+// newLineReader は r から行を読み込む lineReader を生成します
+func newLineReader(r io.Reader) *lineReader {
+	return &lineReader{br: bufio.NewReaderSize(r, lineReaderBufferSize)}
+}
+
+// Scan は次の行を読み込み、行があれば真を返します
+func (lr *lineReader) Scan() bool {
+	if len(lr.pending) == 0 && (lr.eof || lr.err != nil || !lr.fill()) {
+		return false
+	}
+
+	chunk := lr.pending
+	i := bytes.IndexAny(chunk, "\r\n")
+	switch {
+	case i < 0:
+		// 改行で終わらない最終行
+		lr.line, lr.rawLen = chunk, len(chunk)
+	case chunk[i] == '\r' && i+1 < len(chunk) && chunk[i+1] == '\n':
+		lr.line, lr.rawLen = chunk[:i], i+2
+	default:
+		lr.line, lr.rawLen = chunk[:i], i+1
+	}
+	lr.pending = chunk[lr.rawLen:]
+	return true
+}
+
+// fill はLFまで(またはファイルの終わりまで)を pending に読み込みます
+func (lr *lineReader) fill() bool {
+	chunk, err := lr.br.ReadSlice('\n')
+	if errors.Is(err, bufio.ErrBufferFull) {
+		// バッファより長い行は、改行が見つかるまで読み足す
+		lr.long = append(lr.long[:0], chunk...)
+		for errors.Is(err, bufio.ErrBufferFull) {
+			chunk, err = lr.br.ReadSlice('\n')
+			lr.long = append(lr.long, chunk...)
+		}
+		chunk = lr.long
+	}
+	if err == io.EOF {
+		lr.eof = true
+	} else if err != nil {
+		lr.err = err
+		return false
+	}
+	lr.pending = chunk
+	return len(chunk) > 0
+}
+
+// Bytes は読み込んだ行を返します。次の Scan で上書きされます。
+func (lr *lineReader) Bytes() []byte { return lr.line }
+
+// Text は読み込んだ行を文字列で返します
+func (lr *lineReader) Text() string { return string(lr.line) }
+
+// RawLen は直前の行の、改行を含む元のバイト数を返します(次の行の行頭のバイト位置の計算に使う)
+func (lr *lineReader) RawLen() int { return lr.rawLen }
+
+// Err は読み込み中に発生したエラーを返します(入力の終わりはエラーとしない)
+func (lr *lineReader) Err() error { return lr.err }
+
+// lineScanner は lineReader と utf16LineReader に共通の、1行ずつ読み込む操作です
+type lineScanner interface {
+	Scan() bool
+	Bytes() []byte
+	RawLen() int
+	Err() error
+}
+
+// newRawLineReader は文字コード inputEncoding の改行で、変換前の入力を行に分けるリーダーを返します。
+// Shift_JIS・EUC-JP・ISO-2022-JP では2バイト目に CR・LF のバイトが現れないため、UTF-8 と同じく lineReader で分けられます。
+func newRawLineReader(r io.Reader, inputEncoding string) lineScanner {
+	if IsUTF16(inputEncoding) {
+		return &utf16LineReader{br: bufio.NewReaderSize(r, lineReaderBufferSize), bigEndian: isBigEndian(inputEncoding)}
+	}
+	return newLineReader(r)
+}
+
+// utf16LineReader は UTF-16 の入力を、2バイト単位の LF・CRLF・CR で行に分けます(改行は取り除く)
+type utf16LineReader struct {
+	br        *bufio.Reader
+	bigEndian bool
+	line      []byte
+	rawLen    int
+	err       error
+}
+
+// unit は2バイトを UTF-16 の符号単位として読みます
+func (lr *utf16LineReader) unit(b0, b1 byte) uint16 {
+	if lr.bigEndian {
+		return uint16(b0)<<8 | uint16(b1)
+	}
+	return uint16(b1)<<8 | uint16(b0)
+}
+
+// Scan は次の行を読み込み、行があれば真を返します
+func (lr *utf16LineReader) Scan() bool {
+	if lr.err != nil {
+		return false
+	}
+	lr.line = lr.line[:0]
+	for {
+		b, err := lr.br.Peek(2)
+		if len(b) < 2 {
+			// 改行で終わらない最終行(奇数バイトの端数も含める)
+			if len(b) == 1 {
+				lr.line = append(lr.line, b[0])
+				lr.br.Discard(1)
+			}
+			if err != nil && err != io.EOF {
+				lr.err = err
+			}
+			lr.rawLen = len(lr.line)
+			return len(lr.line) > 0
+		}
+		u := lr.unit(b[0], b[1])
+		lr.br.Discard(2)
+		switch u {
+		case '\n':
+			lr.rawLen = len(lr.line) + 2
+			return true
+		case '\r':
+			lr.rawLen = len(lr.line) + 2
+			if next, _ := lr.br.Peek(2); len(next) == 2 && lr.unit(next[0], next[1]) == '\n' {
+				lr.br.Discard(2)
+				lr.rawLen += 2
+			}
+			return true
+		}
+		lr.line = append(lr.line, b[0], b[1])
+	}
+}
+
+// Bytes は読み込んだ行を返します。次の Scan で上書きされます。
+func (lr *utf16LineReader) Bytes() []byte { return lr.line }
+
+// RawLen は直前の行の、改行を含む元のバイト数を返します
+func (lr *utf16LineReader) RawLen() int { return lr.rawLen }
+
+// Err は読み込み中に発生したエラーを返します(入力の終わりはエラーとしない)
+func (lr *utf16LineReader) Err() error { return lr.err }
+
+// textLineReader は変換前の入力を行に分けてから、行ごとに UTF-8 に変換します。
+// Raw と RawLen は入力のバイト列のままなので、行頭のバイト位置・16進表示・-lines-out・-passthrough には入力の値を使えます。
+// 行をまたいで状態を持ち越さない(ISO-2022-JP も行末でASCIIに戻る)前提で、行ごとに変換器を初期化します。
+type textLineReader struct {
+	lineScanner
+	dec  *encoding.Decoder // nil なら変換しない(UTF-8)
+	line []byte
+	err  error
+}
+
+// newTextLineReader は文字コード inputEncoding の入力を1行ずつ UTF-8 に変換して読むリーダーを返します
+func newTextLineReader(r io.Reader, inputEncoding string) (*textLineReader, error) {
+	t := &textLineReader{lineScanner: newRawLineReader(r, inputEncoding)}
+	if !isPlainUTF8(inputEncoding) {
+		enc, err := LookupEncoding(inputEncoding)
+		if err != nil {
+			return nil, err
+		}
+		t.dec = enc.NewDecoder()
+	}
+	return t, nil
+}
+
+// Scan は次の行を読み込んで変換し、行があれば真を返します
+func (t *textLineReader) Scan() bool {
+	if t.err != nil || !t.lineScanner.Scan() {
+		return false
+	}
+	if t.dec == nil {
+		t.line = t.lineScanner.Bytes()
+		return true
+	}
+	t.dec.Reset()
+	t.line, _, t.err = transform.Append(t.dec, t.line[:0], t.lineScanner.Bytes())
+	return t.err == nil
+}
+
+// Bytes は UTF-8 に変換した行を返します。次の Scan で上書きされます。
+func (t *textLineReader) Bytes() []byte { return t.line }
+
+// Raw は変換前の行(改行を除く)を返します。次の Scan で上書きされます。
+func (t *textLineReader) Raw() []byte { return t.lineScanner.Bytes() }
+
+// Err は読み込み・変換中に発生したエラーを返します
+func (t *textLineReader) Err() error {
+	if t.err != nil {
+		return t.err
+	}
+	return t.lineScanner.Err()
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestLineReader はバッファより長い行や改行で終わらない最終行も、改行を除いてそのまま読み込むか確認します
+func TestLineReader(t *testing.T) {
+	long := strings.Repeat("x", 3*lineReaderBufferSize+5)
+	input := "a\r\n" + long + "\n\nb"
+	lr := newLineReader(strings.NewReader(input))
+	var lines []string
+	for lr.Scan() {
+		lines = append(lines, lr.Text())
+	}
+	if err := lr.Err(); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"a", long, "", "b"}
+	if len(lines) != len(want) {
+		t.Fatalf("Line count = %d, want %d", len(lines), len(want))
+	}
+	for i := range want {
+		if lines[i] != want[i] {
+			t.Errorf("Line %d = %.20q (len %d), want %.20q (len %d)", i+1, lines[i], len(lines[i]), want[i], len(want[i]))
+		}
+	}
+}
+
+// TestSearchStream_LongLine は改行のない巨大な行(圧縮されたJSON等)も打ち切らずに検索するか確認します
+func TestSearchStream_LongLine(t *testing.T) {
+	line := strings.Repeat(`{"k":"v"},`, 200000) + `{"msg":"外字"}`
+	results, err := SearchStream(strings.NewReader(line+"\nWARN 外字\n"), []string{"外字"}, 3)
+	if err != nil {
+		t.Fatalf("SearchStream() error = %v", err)
+	}
+	res := results["外字"]
+	if res.Count != 2 || res.Lines != 2 || res.Snippets[0] != `":"外字"}` {
+		t.Errorf("Result = %d hits in %d lines, snippets %q", res.Count, res.Lines, res.Snippets)
+	}
+}
+
+// TestSearchStream_LineEndings は LF・CRLF・CRのみ・それらの混在で行数が一致し、スニペットに CR が残らず、
+// 行頭のバイト位置が元のファイルどおりになるか確認します
+func TestSearchStream_LineEndings(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		offsets []int64
+	}{
+		{"LF", "a\nWARN b\nc\nWARN d\n", []int64{2, 11}},
+		{"CRLF", "a\r\nWARN b\r\nc\r\nWARN d\r\n", []int64{3, 14}},
+		{"CR", "a\rWARN b\rc\rWARN d\r", []int64{2, 11}},
+		{"Mixed", "a\r\nWARN b\rc\nWARN d", []int64{3, 12}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := SearchStream(strings.NewReader(tt.input), []string{"WARN"}, 5)
+			if err != nil {
+				t.Fatal(err)
+			}
+			res := results["WARN"]
+			if res.Count != 2 || res.Lines != 4 || res.Bytes != int64(len(tt.input)) {
+				t.Fatalf("Result = %d hits, %d lines, %d bytes", res.Count, res.Lines, res.Bytes)
+			}
+			for i, info := range res.Infos {
+				if strings.ContainsRune(res.Snippets[i], '\r') {
+					t.Errorf("Snippet %q contains CR", res.Snippets[i])
+				}
+				if info.Line != 2*i+2 || info.Offset != tt.offsets[i] {
+					t.Errorf("Snippet %d at line %d offset %d, want line %d offset %d", i, info.Line, info.Offset, 2*i+2, tt.offsets[i])
+				}
+			}
+		})
+	}
+}
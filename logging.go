@@ -0,0 +1,121 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+)
+
+// エラーの種類を表す識別子です。機械可読出力(-format json/jsonl)のエラーに code として含めます。
+// メッセージの文言を変えても変わらないよう、エラーを出力する箇所ごとに指定します。
+const (
+	CodeUsage         = "usage"          // フラグの誤り・併用できないオプション・必須の指定の不足
+	CodeInvalidOption = "invalid_option" // オプションの値が不正
+	CodeInvalidQuery  = "invalid_query"  // クエリ・クエリファイルが不正
+	CodeInvalidConfig = "invalid_config" // 設定・ルール・レイアウト・異体字表のファイルが不正
+	CodeOpenFailed    = "open_failed"    // ファイルを開けない
+	CodeReadFailed    = "read_failed"    // 読み込みに失敗した
+	CodeSearchFailed  = "search_failed"  // 走査中に失敗した
+	CodeWriteFailed   = "write_failed"   // 出力に失敗した
+	CodeVerifyFailed  = "verify_failed"  // 入力・レポートの検証に失敗した
+	CodeRemoteFailed  = "remote_failed"  // 分散走査(ワーカー・コーディネータ)で失敗した
+	CodeError         = "error"          // 上記以外(code を指定していないエラー)
+)
+
+// newTextLogger は通常時に使う、テキストでログを出力するロガーを返します。
+// code は機械可読出力のための識別子なので、テキストには含めません。
+func newTextLogger(w io.Writer) *slog.Logger {
+	return slog.New(slog.NewTextHandler(w, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if len(groups) == 0 && a.Key == "code" {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+}
+
+// newJSONLogger は機械可読出力の選択時に使う、JSONでエラーを出力するロガーを返します。
+// 各行には message と、エラーの箇所で指定した識別子 code(CodeUsage 等)を含めます。
+// 入力中の位置が分かるエラーには offset(バイト位置)を加えます。path などの付随情報はそのままのキーで出力します。
+func newJSONLogger(w io.Writer) *slog.Logger {
+	handler := slog.NewJSONHandler(w, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if len(groups) == 0 && a.Key == slog.MessageKey {
+				a.Key = "message"
+			}
+			return a
+		},
+	})
+	return slog.New(codeHandler{handler})
+}
+
+// codeHandler はエラーのレコードに、指定のない code と、エラーから分かる offset を付け加えます
+type codeHandler struct {
+	slog.Handler
+}
+
+func (h codeHandler) Handle(ctx context.Context, r slog.Record) error {
+	if r.Level < slog.LevelError {
+		return h.Handler.Handle(ctx, r)
+	}
+	hasCode, hasOffset := false, false
+	var offset int64 = -1
+	r.Attrs(func(a slog.Attr) bool {
+		switch a.Key {
+		case "code":
+			hasCode = true
+		case "offset":
+			hasOffset = true
+		}
+		if err, ok := a.Value.Any().(error); ok && offset < 0 {
+			if o, ok := errorOffset(err); ok {
+				offset = o
+			}
+		}
+		return true
+	})
+	if !hasCode {
+		r.AddAttrs(slog.String("code", CodeError))
+	}
+	if !hasOffset && offset >= 0 {
+		r.AddAttrs(slog.Int64("offset", offset))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h codeHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return codeHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h codeHandler) WithGroup(name string) slog.Handler {
+	return codeHandler{h.Handler.WithGroup(name)}
+}
+
+// offsetError は入力中のバイト位置が分かっているエラーです
+type offsetError struct {
+	offset int64
+	err    error
+}
+
+func (e *offsetError) Error() string { return e.err.Error() }
+
+func (e *offsetError) Unwrap() error { return e.err }
+
+// errorOffset はエラーの原因となった入力中のバイト位置を返します(JSONの構文・型の誤りを含む)
+func errorOffset(err error) (int64, bool) {
+	var oe *offsetError
+	var se *json.SyntaxError
+	var te *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &oe):
+		return oe.offset, true
+	case errors.As(err, &se):
+		return se.Offset, true
+	case errors.As(err, &te):
+		return te.Offset, true
+	}
+	return 0, false
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+// TestRun_JSONErrors は -format json のとき、エラーがJSONで標準エラー出力に書かれるか確認します
+func TestRun_JSONErrors(t *testing.T) {
+	stderr := new(bytes.Buffer)
+	ctx := AppContext{
+		Args:     []string{"app", "-format", "json", "missing.log"},
+		ExecPath: "app_TARGET",
+		Stdout:   io.Discard,
+		Stderr:   stderr,
+		FileReader: func(string) (io.ReadCloser, error) {
+			return nil, errors.New("no such file")
+		},
+	}
+
+	if code := Run(ctx); code != ExitError {
+		t.Errorf("Run() exit code = %d, want %d", code, ExitError)
+	}
+
+	var entry map[string]any
+	if err := json.Unmarshal(stderr.Bytes(), &entry); err != nil {
+		t.Fatalf("Stderr is not JSON: %v\n%s", err, stderr.String())
+	}
+	if entry["code"] != "search_failed" || entry["path"] != "missing.log" || entry["message"] != "Search failed" {
+		t.Errorf("Error entry = %v", entry)
+	}
+}
+
+// failingReader は読み込みの途中でエラーを返す入力です
+type failingReader struct{ data *strings.Reader }
+
+func (r failingReader) Read(p []byte) (int, error) {
+	if r.data.Len() == 0 {
+		return 0, errors.New("device error")
+	}
+	return r.data.Read(p)
+}
+
+// TestRun_JSONErrorCodes はエラーの箇所で指定した code と、読み込みに失敗した位置の offset が出力されるか確認します
+func TestRun_JSONErrorCodes(t *testing.T) {
+	run := func(format string, args ...string) (int, string) {
+		stderr := new(bytes.Buffer)
+		code := Run(AppContext{
+			Args:     append([]string{"app", "-format", format}, args...),
+			ExecPath: "app_WARN",
+			Stdout:   io.Discard,
+			Stderr:   stderr,
+			FileReader: func(string) (io.ReadCloser, error) {
+				return io.NopCloser(failingReader{strings.NewReader("WARN a\nINFO\n")}), nil
+			},
+		})
+		return code, stderr.String()
+	}
+
+	tests := []struct {
+		args   []string
+		code   string
+		offset any
+	}{
+		{[]string{"-anchor", "middle", "in.log"}, CodeInvalidOption, nil},
+		{[]string{"-q", "re:(", "in.log"}, CodeInvalidQuery, nil},
+		{[]string{"in.log"}, CodeSearchFailed, float64(12)},
+	}
+	for _, tt := range tests {
+		code, stderr := run("jsonl", tt.args...)
+		var entry map[string]any
+		if err := json.Unmarshal([]byte(stderr), &entry); err != nil {
+			t.Fatalf("Run(%v) stderr is not JSON: %v\n%s", tt.args, err, stderr)
+		}
+		if code != ExitError || entry["code"] != tt.code || entry["offset"] != tt.offset {
+			t.Errorf("Run(%v) = %d, entry %v, want code %q offset %v", tt.args, code, entry, tt.code, tt.offset)
+		}
+	}
+
+	// テキストのログには code を含めない
+	if _, stderr := run("text", "-anchor", "middle", "in.log"); strings.Contains(stderr, "code=") || !strings.Contains(stderr, "Invalid anchor") {
+		t.Errorf("Text log = %q", stderr)
+	}
+}
@@ -1,288 +1,2266 @@
-package main
-
-import (
-	"bufio"
-	"errors"
-	"flag"
-	"fmt"
-	"io"
-	"log/slog"
-	"os"
-	"path/filepath"
-	"strings"
-)
-
-// ==========================================
-// 1. Domain Types & Constants
-// ==========================================
-
-const (
-	MaxSnippets        = 10
-	DefaultContextSize = 20 // デフォルトを20文字に変更
-)
-
-// SearchResult は1つの検索語に対する結果を保持します
-type SearchResult struct {
-	Query    string
-	Count    int
-	Snippets []string
-}
-
-// Config は実行時の設定を保持します
-type Config struct {
-	InputFilePath string
-	Queries       []string
-	ContextSize   int // コンテキスト文字数を保持するフィールドを追加
-}
-
-// ==========================================
-// 2. Business Logic (Pure Functions)
-// ==========================================
-
-// ParseArgs は実行引数と実行ファイル名から設定を生成します。
-func ParseArgs(args []string, execPath string) (*Config, error) {
-	if len(args) < 1 {
-		return nil, errors.New("input file path is required")
-	}
-
-	inputFile := args[0]
-	baseName := filepath.Base(execPath)
-	ext := filepath.Ext(baseName)
-	nameWithoutExt := baseName[:len(baseName)-len(ext)]
-
-	// アンダースコアで分割 (例: AppName_Query1_Query2)
-	parts := strings.Split(nameWithoutExt, "_")
-
-	if len(parts) < 2 {
-		return nil, fmt.Errorf("invalid executable name format: %s (expected: AppName_Query1_Query2...)", baseName)
-	}
-
-	// 先頭(アプリ名)を除外した残りが検索クエリ
-	queries := parts[1:]
-
-	// 有効なクエリのみ抽出
-	validQueries := make([]string, 0, len(queries))
-	for _, q := range queries {
-		if q != "" {
-			validQueries = append(validQueries, q)
-		}
-	}
-
-	if len(validQueries) == 0 {
-		return nil, errors.New("no search queries found in executable name")
-	}
-
-	// ContextSizeはここではデフォルト値を入れるか、呼び出し元で上書きする設計とする
-	// ここでは構造体の初期化のみ行う
-	return &Config{
-		InputFilePath: inputFile,
-		Queries:       validQueries,
-		ContextSize:   DefaultContextSize,
-	}, nil
-}
-
-// SearchStream はストリームから文字列を検索します。contextSizeを受け取るように変更
-func SearchStream(r io.Reader, queries []string, contextSize int) (map[string]*SearchResult, error) {
-	results := make(map[string]*SearchResult)
-	for _, q := range queries {
-		results[q] = &SearchResult{Query: q}
-	}
-
-	scanner := bufio.NewScanner(r)
-
-	for scanner.Scan() {
-		lineText := scanner.Text()
-
-		// 最適化: ルーン変換はコストが高いため、いずれかのクエリがヒットした場合のみ行う
-		// nilのままなら変換していない状態
-		var lineRunes []rune
-
-		for _, q := range queries {
-			// 高速なバイト検索で事前チェック
-			if !strings.Contains(lineText, q) {
-				continue
-			}
-
-			res := results[q]
-			res.Count++ // 行単位でカウント
-
-			// スニペットが必要な場合のみルーン変換して抽出処理を行う
-			if len(res.Snippets) < MaxSnippets {
-				// 遅延初期化: この行で初めてスニペット抽出が必要になった時だけ変換
-				if lineRunes == nil {
-					lineRunes = []rune(lineText)
-				}
-				// contextSizeを渡す
-				snippet := extractSnippet(lineRunes, q, contextSize)
-				res.Snippets = append(res.Snippets, snippet)
-			}
-		}
-	}
-
-	if err := scanner.Err(); err != nil {
-		return nil, fmt.Errorf("error reading stream: %w", err)
-	}
-
-	return results, nil
-}
-
-// extractSnippet は指定されたcontextSizeに基づいて文字を切り出します
-func extractSnippet(lineRunes []rune, query string, contextSize int) string {
-	queryRunes := []rune(query)
-	qLen := len(queryRunes)
-	lineLen := len(lineRunes)
-
-	// ルーン単位での位置特定
-	idx := -1
-	for i := 0; i <= lineLen-qLen; i++ {
-		match := true
-		for j := 0; j < qLen; j++ {
-			if lineRunes[i+j] != queryRunes[j] {
-				match = false
-				break
-			}
-		}
-		if match {
-			idx = i
-			break
-		}
-	}
-
-	if idx == -1 {
-		return "" // 事前のContainsチェックがあるため通常は到達しない
-	}
-
-	// 定数ContextCharsではなく、引数contextSizeを使用
-	start := idx - contextSize
-	if start < 0 {
-		start = 0
-	}
-
-	end := idx + qLen + contextSize
-	if end > lineLen {
-		end = lineLen
-	}
-
-	return string(lineRunes[start:end])
-}
-
-// WriteResults は結果を指定されたWriterに出力します
-func WriteResults(w io.Writer, results map[string]*SearchResult, queryOrder []string) {
-	for _, q := range queryOrder {
-		res, ok := results[q]
-		if !ok {
-			continue
-		}
-
-		fmt.Fprintf(w, "[%s]\n", res.Query)
-		fmt.Fprintf(w, "該当数: %d\n", res.Count)
-
-		for i, snippet := range res.Snippets {
-			fmt.Fprintf(w, "%d:%s\n", i+1, snippet)
-		}
-		fmt.Fprintln(w, "-----------------------")
-	}
-}
-
-// ==========================================
-// 3. Application Wiring
-// ==========================================
-
-type AppContext struct {
-	Args        []string
-	ExecPath    string
-	Stdout      io.Writer
-	Stderr      io.Writer
-	FileReader  func(string) (io.ReadCloser, error)
-	FileCreator func(string) (io.WriteCloser, error)
-}
-
-func Run(ctx AppContext) int {
-	logger := slog.New(slog.NewTextHandler(ctx.Stderr, nil))
-
-	args := make([]string, len(ctx.Args))
-	copy(args, ctx.Args)
-	if len(args) > 0 {
-		args = args[1:]
-	}
-
-	fs := flag.NewFlagSet("app", flag.ContinueOnError)
-	outputFile := fs.String("o", "", "Output file path (optional)")
-	// コンテキストサイズを指定するフラグ -n を追加
-	contextSize := fs.Int("n", DefaultContextSize, "Number of context characters (default 20)")
-
-	if err := fs.Parse(args); err != nil {
-		logger.Error("Flag parse error", "error", err)
-		return 1
-	}
-
-	// 負の値が指定された場合のガード
-	if *contextSize < 0 {
-		logger.Error("Context size cannot be negative")
-		return 1
-	}
-
-	remainingArgs := fs.Args()
-	config, err := ParseArgs(remainingArgs, ctx.ExecPath)
-	if err != nil {
-		logger.Error("Configuration error", "error", err)
-		return 1
-	}
-
-	// フラグで指定された値をConfigに適用
-	config.ContextSize = *contextSize
-
-	var outWriter io.Writer
-
-	if *outputFile != "" {
-		f, err := ctx.FileCreator(*outputFile)
-		if err != nil {
-			logger.Error("Failed to create output file", "path", *outputFile, "error", err)
-			return 1
-		}
-		defer f.Close()
-		outWriter = io.MultiWriter(ctx.Stdout, f)
-	} else {
-		outWriter = ctx.Stdout
-	}
-
-	f, err := ctx.FileReader(config.InputFilePath)
-	if err != nil {
-		logger.Error("Failed to open input file", "path", config.InputFilePath, "error", err)
-		return 1
-	}
-	defer f.Close()
-
-	// 検索実行時にコンテキストサイズを渡す
-	results, err := SearchStream(f, config.Queries, config.ContextSize)
-	if err != nil {
-		logger.Error("Search failed", "error", err)
-		return 1
-	}
-
-	WriteResults(outWriter, results, config.Queries)
-
-	return 0
-}
-
-func main() {
-	exe, err := os.Executable()
-	if err != nil {
-		exe = os.Args[0]
-	}
-
-	ctx := AppContext{
-		Args:     os.Args,
-		ExecPath: exe,
-		Stdout:   os.Stdout,
-		Stderr:   os.Stderr,
-		FileReader: func(path string) (io.ReadCloser, error) {
-			return os.Open(path)
-		},
-		FileCreator: func(path string) (io.WriteCloser, error) {
-			return os.Create(path)
-		},
-	}
-
-	os.Exit(Run(ctx))
-}
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"math"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/term"
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/transform"
+)
+
+// ==========================================
+// 1. Domain Types & Constants
+// ==========================================
+
+const (
+	MaxSnippets        = 10  // 検索語ごとに保持するスニペットの既定の上限件数
+	DefaultContextSize = 20  // デフォルトを20文字に変更
+	MaxFoldedLines     = 5   // 折り畳み時に表示する行番号の上限
+	StdinPath          = "-" // 入力パスに指定すると標準入力を検索する
+)
+
+// Run の終了コードです。grep と同じく、ヒットの有無とエラーを区別します。
+const (
+	ExitMatch         = 0 // いずれかの検索語がヒットした(検索以外のモードでは成功)
+	ExitNoMatch       = 1 // どの検索語もヒットしなかった
+	ExitError         = 2
+	ExitRuleViolation = 3 // ルールの閾値を超過した
+	ExitWriteError    = 4 // 走査後にレポートを書き込めなかった(該当数は標準エラーに記録する)
+)
+
+// queryPresets は "@名前" 形式でクエリに指定できる組み込みのクエリ集合です
+var queryPresets = map[string][]string{
+	"@loglevel": {"FATAL", "ERROR", "WARN", "INFO"},
+	"@pii":      {"@pii:phone", "@pii:mynumber", "@pii:postal"},
+}
+
+// SearchResult は1つの検索語に対する結果を保持します
+type SearchResult struct {
+	Query    string
+	Count    int
+	Snippets []string
+	Infos    []SnippetInfo             // Snippetsと同じ添字で対応する付随情報
+	Estimate *Estimate                 // サンプリング時のみ設定される推定値
+	Max      *int                      // ルールで指定された許容上限(nilなら上限なし)
+	Lines    int                       // 走査した総行数
+	Bytes    int64                     // 走査した総バイト数(改行を含む)
+	Variants map[string]int            // 異体字展開時、実際にヒットした表記ごとの該当数
+	Captures map[string]map[string]int // 正規表現クエリの名前付きグループごとの捕捉値と件数
+	CoOccur  map[string]int            // 共起集計時、他のクエリと同じ行でヒットした行数
+	Encoding string                    // 文字コードを自動判定した場合の判定結果
+	First    *Occurrence               // 最初にヒットした行
+	Last     *Occurrence               // 最後にヒットした行
+	Files    []FileCount               // ディレクトリ検索時、ヒットのあったファイルごとの該当数
+	Severity string                    // ルールで指定された上限超過時の重大度(error|warning|info)
+	// Suppressed は除外指定(objis:allow)のある行で見つかり、該当数に含めなかったヒット行数です
+	Suppressed int
+	// Resized は走査中にサイズが変わったファイルです(書き込み中のログ・切り詰め)
+	Resized []SizeChange
+}
+
+// Occurrence はヒットした行の位置です。Offset は行頭のバイト位置(0始まり)です。
+type Occurrence struct {
+	Path   string `json:"path,omitempty"`
+	Line   int    `json:"line"`
+	Offset int64  `json:"offset"`
+}
+
+// Match は1件のヒットです。スニペットの件数上限を超えて全件を扱う場合に使います。
+type Match struct {
+	Query   string `json:"query"`
+	Line    int    `json:"line"`
+	Offset  int64  `json:"offset"`
+	Snippet string `json:"snippet"`
+}
+
+// Density は1MBあたり・1万行あたりの該当数を返します。
+// サンプリング時は推定該当数を用います。
+func (r *SearchResult) Density() (perMB, per10kLines float64) {
+	hits := float64(r.Count)
+	if r.Estimate != nil {
+		hits = float64(r.Estimate.Count)
+	}
+	if r.Bytes > 0 {
+		perMB = hits / (float64(r.Bytes) / (1 << 20))
+	}
+	if r.Lines > 0 {
+		per10kLines = hits / (float64(r.Lines) / 10000)
+	}
+	return perMB, per10kLines
+}
+
+// Breached は該当数が許容上限を超えているかを返します
+func (r *SearchResult) Breached() bool {
+	return r.Max != nil && r.Count > *r.Max
+}
+
+// Estimate はサンプリング結果から全体へ外挿した推定該当数を保持します
+type Estimate struct {
+	Count        int `json:"count"`         // 全体に外挿した推定該当数
+	Margin       int `json:"margin"`        // 95%信頼区間の半幅
+	SampledLines int `json:"sampled_lines"` // 照合した行数
+	TotalLines   int `json:"total_lines"`   // 読み込んだ総行数
+}
+
+// SnippetInfo はスニペットの出現位置などの付随情報を保持します
+type SnippetInfo struct {
+	Line    int    // 最初に見つかった行番号(1始まり)
+	Offset  int64  // 最初に見つかった行の行頭のバイト位置(0始まり)
+	Repeats int    // 同一行の出現回数(折り畳み時のみ2以上になる)
+	Lines   []int  // 出現した行番号(先頭からMaxFoldedLines件まで)
+	Raw     []byte // スニペット範囲の元のバイト列(--hex-snippets時のみ)
+	Path    string // 複数ファイルの結果を統合した場合の出典ファイル
+	Col     int    // ヒット位置の桁(1始まり、ルーン単位)
+	Char    int    // 入力全体でのヒット位置(1始まり、ルーン単位。-no-line-mode の場合のみ)
+
+	Converted string      // 比較用文字コードへ変換した場合のスニペット
+	Decoded   bool        // 前処理で復号した部分の中でヒットした
+	Matches   []MatchSpan // 複数クエリのヒットをまとめた場合の各ヒット部分
+	Class     string      // ヒット部分の文字の分類(-anonymize の場合のみ)
+
+	// スニペット文字列中のヒット部分のバイト範囲 [MatchStart, MatchEnd)
+	MatchStart int
+	MatchEnd   int
+}
+
+// SearchOptions は検索処理の挙動を指定します
+type SearchOptions struct {
+	ContextSize    int
+	FoldDuplicates bool // 同一内容の行を1つのスニペットに折り畳む
+	SampleEvery    int  // 2以上ならN行ごとに1行だけ照合する
+
+	// PassThrough が設定されている場合、PassMatched に一致する行(ヒット行または非ヒット行)をそのまま書き出す
+	PassThrough io.Writer
+	PassMatched bool
+
+	RedactMask rune // 0以外ならスニペット中のヒット文字列をこの文字で伏せる
+	Escape     int  // スニペット中の不可視文字のエスケープ指定(EscapeNone等)
+	KeepRaw    bool // スニペット範囲の元のバイト列を保持する(16進表示用)
+
+	ContextAlign string // コンテキストの境界合わせ(ContextAlignChar等)
+	ContextUnit  string // コンテキストの単位(ContextUnitChar等)
+	SentenceMax  int    // 文単位の場合のスニペットの最大文字数
+
+	// Variants が設定されている場合、クエリの各文字をその異体字(およびIVS付きの表記)にも一致させる
+	Variants VariantTable
+	Romaji   bool   // ローマ字のクエリをかな・漢字表記にも一致させる
+	Anchor   string // ヒット位置を行頭・行末・行全体に固定する(AnchorStart等、空なら固定しない)
+
+	// CompareEncoding が設定されている場合、スニペットをその文字コードへ変換した場合の表示も記録する
+	CompareEncoding encoding.Encoding
+
+	// InputEncoding は入力の文字コード名です(空ならUTF-8)。読み込み時にUTF-8へ変換してから照合します。
+	InputEncoding string
+	// CombineLines は1行で複数のクエリがヒットした場合に、スニペットを最初のクエリの1件にまとめます
+	CombineLines bool
+	// CoOccurrence はクエリの組ごとに同じ行でヒットした行数を数えます
+	CoOccurrence bool
+	// Filters は照合前に各行へ適用する前処理の名前です(パススルーや行の書き出しには元の行を使う)
+	Filters []string
+	// LineSinks はクエリごとにヒット行全体(元のバイト列)を書き出す先です
+	LineSinks map[string]io.Writer
+	// OnMatch が設定されている場合、スニペットの上限に関係なくすべてのヒットについて呼び出す
+	OnMatch func(Match)
+	// SuppressMarker が含まれる行とその次の行のヒットは該当数に含めない(空なら除外しない)
+	SuppressMarker string
+	// IgnoreCase は大文字・小文字を区別せずに照合します(Unicodeの大文字・小文字の対応に従う)
+	IgnoreCase bool
+	// Normalize はクエリと各行をこの形式(nfc|nfkc)で正規化してから照合します(スニペットは元の行から切り出す)
+	Normalize string
+	// FoldWidth は半角・全角の違いを無視して照合します(ｶﾀﾛｸﾞ と カタログ を同じとみなす。スニペットは元の行から切り出す)
+	FoldWidth bool
+	// FoldKana はひらがなとカタカナの違いを無視して照合します(スニペットは元の行から切り出す)
+	FoldKana bool
+	// IgnoreIVS は異体字セレクタ(IVS・SVS)を取り除いて照合します(辻󠄀 に 辻 でヒットする。スニペットは元の行から切り出す)
+	IgnoreIVS bool
+	// Engine は文字列クエリの事前照合の方法です(EngineAuto等、空なら事前照合しない)。結果は変わりません。
+	Engine string
+	// UnicodeVersion は版によって判定が変わる文字の種類(@class:unassigned 等)に使うUnicodeの版です(空なら最新)
+	UnicodeVersion string
+	// Extended は文字の種類・範囲のクエリ以外をすべて正規表現として照合します(-E)
+	Extended bool
+	// WholeText は行をつなげた1つのテキストとして照合し、ヒットした箇所ごとに数えます(-no-line-mode)
+	WholeText bool
+	// MaxTextBytes は WholeText で読み込む入力(UTF-8に変換後)の上限(バイト)です。超える入力はエラーにします(0なら制限しない)
+	MaxTextBytes int64
+	// CountOnly はスニペットを抽出せず、該当数のみを数えます(-c)
+	CountOnly bool
+	// MaxSnippetBytes はスニペットと -lines-out に書き出す行の上限(バイト)です。超える部分は切り詰めます(0なら制限しない)
+	MaxSnippetBytes int
+	// MaxSnippets は検索語ごとに保持するスニペットの上限件数です(0なら既定の MaxSnippets 件、負なら無制限)
+	MaxSnippets int
+	// AllowGrowth は走査中に大きくなったファイルを末尾まで走査します(既定では走査開始時のサイズまでで止める)
+	AllowGrowth bool
+	// Trace が設定されている場合、検索を読み込み・復号と照合の段階に分けてこのスパンの子として記録する
+	Trace *Span
+	// decodeTime が設定されている場合、入力の読み込みと UTF-8 への変換に掛かった時間を加算する(traceSearch が使う)
+	decodeTime *time.Duration
+}
+
+// Config は実行時の設定を保持します
+type Config struct {
+	InputFilePath string
+	Queries       []string
+	ContextSize   int // コンテキスト文字数を保持するフィールドを追加
+	Options       SearchOptions
+	// ZipPassword は暗号化ZIPのパスワードを返します(暗号化エントリがあった場合のみ呼ばれる)
+	ZipPassword func() (string, error)
+	// InputIsDir は入力パスがディレクトリであることを表します(配下のファイルを再帰的に検索する)
+	InputIsDir bool
+	// InputPaths は指定されたすべての入力パスです(先頭は InputFilePath と同じ)
+	InputPaths []string
+	Walk       WalkOptions
+}
+
+// MultiInput は複数のファイルを検索するか(ディレクトリまたは複数の入力パスが指定されたか)を返します
+func (c *Config) MultiInput() bool {
+	return c.InputIsDir || len(c.InputPaths) > 1
+}
+
+// ==========================================
+// 2. Business Logic (Pure Functions)
+// ==========================================
+
+// ParseArgs は実行引数と実行ファイル名から設定を生成します。
+func ParseArgs(args []string, execPath string) (*Config, error) {
+	return ParseArgsWithQueries(args, execPath, nil, false)
+}
+
+// ParseArgsWithQueries は実行ファイル名のクエリに extra(設定ファイル・-f・-q のクエリ)を加えて設定を生成します。
+// replace が真なら実行ファイル名のクエリは使いません。
+// extra がある場合は、実行ファイル名にクエリが含まれていなくても構いません。
+func ParseArgsWithQueries(args []string, execPath string, extra []string, replace bool) (*Config, error) {
+	if len(args) < 1 {
+		return nil, errors.New("input file path is required")
+	}
+
+	var queries []string
+	if !replace {
+		var err error
+		queries, err = execNameQueries(execPath)
+		if err != nil && len(extra) == 0 {
+			return nil, err
+		}
+	}
+	queries = append(queries, extra...)
+	if len(queries) == 0 {
+		return nil, errors.New("no search queries found")
+	}
+
+	// プリセット(@loglevel等)を展開する
+	queries = expandPresets(queries)
+
+	// ContextSizeはここではデフォルト値を入れるか、呼び出し元で上書きする設計とする
+	// ここでは構造体の初期化のみ行う
+	return &Config{
+		InputFilePath: args[0],
+		InputPaths:    args,
+		Queries:       queries,
+		ContextSize:   DefaultContextSize,
+	}, nil
+}
+
+// execNameQueries は実行ファイル名(AppName_Query1_Query2...)から検索クエリを取り出します
+func execNameQueries(execPath string) ([]string, error) {
+	baseName := filepath.Base(execPath)
+	ext := filepath.Ext(baseName)
+	nameWithoutExt := baseName[:len(baseName)-len(ext)]
+
+	// アンダースコアで分割 (例: AppName_Query1_Query2)
+	parts := strings.Split(nameWithoutExt, "_")
+
+	if len(parts) < 2 {
+		return nil, fmt.Errorf("invalid executable name format: %s (expected: AppName_Query1_Query2...)", baseName)
+	}
+
+	// 先頭(アプリ名)を除外した残りが検索クエリ
+	queries := parts[1:]
+
+	// 有効なクエリのみ抽出
+	validQueries := make([]string, 0, len(queries))
+	for _, q := range queries {
+		if q != "" {
+			validQueries = append(validQueries, q)
+		}
+	}
+
+	if len(validQueries) == 0 {
+		return nil, errors.New("no search queries found in executable name")
+	}
+	return validQueries, nil
+}
+
+// expandPresets はプリセット名を対応するクエリ群に展開します。
+// 重複したクエリは最初の出現のみ残します。
+func expandPresets(queries []string) []string {
+	seen := make(map[string]bool, len(queries))
+	expanded := make([]string, 0, len(queries))
+	for _, q := range queries {
+		terms, ok := queryPresets[q]
+		if !ok {
+			terms = []string{q}
+		}
+		for _, t := range terms {
+			if seen[t] {
+				continue
+			}
+			seen[t] = true
+			expanded = append(expanded, t)
+		}
+	}
+	return expanded
+}
+
+// SearchStream はストリームから文字列を検索します。contextSizeを受け取るように変更
+func SearchStream(r io.Reader, queries []string, contextSize int) (map[string]*SearchResult, error) {
+	return SearchStreamWithOptions(r, queries, SearchOptions{ContextSize: contextSize})
+}
+
+// SearchStreamWithOptions はオプションを指定してストリームから文字列を検索します
+func SearchStreamWithOptions(r io.Reader, queries []string, opts SearchOptions) (map[string]*SearchResult, error) {
+	if opts.Trace != nil {
+		return traceSearch(r, queries, opts)
+	}
+
+	if opts.WholeText {
+		r, inputEncoding, err := decodeInput(r, opts.InputEncoding)
+		if err != nil {
+			return nil, err
+		}
+		if opts.decodeTime != nil {
+			r = timedReader{r, opts.decodeTime}
+		}
+		results, err := searchWholeText(r, queries, opts)
+		if err == nil && inputEncoding != opts.InputEncoding {
+			for _, res := range results {
+				res.Encoding = inputEncoding
+			}
+		}
+		return results, err
+	}
+
+	results := make(map[string]*SearchResult)
+	matchers := make([]matcher, len(queries))
+	for i, q := range queries {
+		results[q] = &SearchResult{Query: q}
+		matchers[i] = newMatcher(q, opts)
+	}
+
+	// 折り畳み用: クエリごとに行内容からスニペットの添字を引く
+	var folded map[string]map[string]int
+	if opts.FoldDuplicates {
+		folded = make(map[string]map[string]int, len(queries))
+		for _, q := range queries {
+			folded[q] = make(map[string]int)
+		}
+	}
+
+	var passWriter *bufio.Writer
+	if opts.PassThrough != nil {
+		passWriter = bufio.NewWriter(opts.PassThrough)
+	}
+
+	filters := resolveFilters(opts.Filters)
+
+	sinks := make(map[string]*bufio.Writer, len(opts.LineSinks))
+	for q, w := range opts.LineSinks {
+		sinks[q] = bufio.NewWriter(w)
+	}
+
+	// 事前照合でヒットし得ないと分かったクエリは照合せず、どのクエリもヒットし得ない行は文字列に変換しない
+	pre := newPrefilter(opts.Engine, matchers, opts)
+	possible := make([]bool, len(queries))
+	for i := range possible {
+		possible[i] = true
+	}
+	// スニペットは行ごとに確保せず、ブロックにまとめて確保する
+	var arena snippetArena
+
+	var marker []byte
+	if opts.SuppressMarker != "" {
+		marker = []byte(opts.SuppressMarker)
+	}
+
+	// 行頭のバイト位置・16進表示・-lines-out・-passthrough には、変換前の入力のバイト列を使う
+	decodeStart := time.Now()
+	inputEncoding := opts.InputEncoding
+	if inputEncoding == EncodingAuto || inputEncoding == "" {
+		br := bufio.NewReaderSize(r, DetectSampleSize)
+		inputEncoding = sniffEncoding(br, inputEncoding)
+		r = br
+	}
+	scanner, err := newTextLineReader(r, inputEncoding)
+	if err != nil {
+		return nil, err
+	}
+	// 読み込みと変換は行ごとに Scan の中で行うため、その時間を計る
+	scan := scanner.Scan
+	if opts.decodeTime != nil {
+		*opts.decodeTime += time.Since(decodeStart)
+		scan = func() bool {
+			start := time.Now()
+			ok := scanner.Scan()
+			*opts.decodeTime += time.Since(start)
+			return ok
+		}
+	}
+	newline := encodedNewline(inputEncoding)
+	lineNum := 0
+	sampled := 0
+	var totalBytes int64
+	var allow, prevAllow *suppression // この行と前の行の除外指定
+
+	for scan() {
+		lineBytes := scanner.Bytes()
+		lineNum++
+		lineOffset := totalBytes
+		totalBytes += int64(scanner.RawLen())
+		if lineNum == 1 {
+			lineBytes = bytes.TrimPrefix(lineBytes, []byte(utf8BOM))
+		}
+		// 除外指定は前処理前の元の行(ソース中のコメント)から読み取る
+		prevAllow, allow = allow, nil
+		if marker != nil && bytes.Contains(lineBytes, marker) {
+			allow = parseSuppression(string(lineBytes), opts.SuppressMarker)
+		}
+
+		// サンプリング時は対象外の行を読み飛ばす
+		if opts.SampleEvery > 1 && (lineNum-1)%opts.SampleEvery != 0 {
+			continue
+		}
+		sampled++
+
+		if pre != nil && !pre.candidates(lineBytes, possible) {
+			if passWriter != nil && !opts.PassMatched {
+				passWriter.Write(scanner.Raw())
+				if _, err := passWriter.Write(newline); err != nil {
+					return nil, fmt.Errorf("error writing passthrough line: %w", err)
+				}
+			}
+			continue
+		}
+
+		lineText := string(lineBytes)
+		rawText := lineText
+		source := rawLine{raw: scanner.Raw(), text: rawText, encoding: inputEncoding}
+		if filters != nil {
+			lineText = applyFilters(lineText, filters)
+		}
+
+		// 最適化: ルーン変換はコストが高いため、いずれかのクエリがヒットした場合のみ行う
+		// nilのままなら変換していない状態
+		var lineRunes []rune
+		matched := false
+		var hitQueries []int // 共起集計用: この行でヒットしたクエリの添字
+		var combined *combinedSnippet
+
+		for qi, q := range queries {
+			if !possible[qi] {
+				continue
+			}
+			loc := matchers[qi].find(lineText)
+			if loc == nil {
+				continue
+			}
+
+			res := results[q]
+			if allow.covers(q) || prevAllow.covers(q) {
+				res.Suppressed++
+				continue
+			}
+			matched = true
+			res.Count++ // 行単位でカウント
+			res.Last = &Occurrence{Line: lineNum, Offset: lineOffset}
+			if res.First == nil {
+				res.First = res.Last
+			}
+			if opts.OnMatch != nil {
+				opts.OnMatch(Match{Query: q, Line: lineNum, Offset: lineOffset, Snippet: lineSnippet(lineText, loc, matchers[qi], opts)})
+			}
+			if opts.CoOccurrence {
+				hitQueries = append(hitQueries, qi)
+			}
+
+			if opts.Variants != nil {
+				if res.Variants == nil {
+					res.Variants = make(map[string]int)
+				}
+				res.Variants[lineText[loc[0]:loc[1]]]++
+			}
+			if rm, line, ok := captureSource(matchers[qi], lineText); ok {
+				recordCaptures(res, rm.re, line)
+			}
+
+			if sink, ok := sinks[q]; ok {
+				sink.Write(source.clamp(opts.MaxSnippetBytes))
+				if _, err := sink.Write(newline); err != nil {
+					return nil, fmt.Errorf("error writing matched line for %q: %w", q, err)
+				}
+			}
+
+			// 件数のみの場合はスニペットを作らない(行をルーンに変換しない)
+			if opts.CountOnly {
+				continue
+			}
+
+			// 既出の行であれば出現情報のみ更新する
+			if folded != nil {
+				if i, ok := folded[q][lineText]; ok {
+					info := &res.Infos[i]
+					info.Repeats++
+					if len(info.Lines) < MaxFoldedLines {
+						info.Lines = append(info.Lines, lineNum)
+					}
+					continue
+				}
+			}
+
+			// スニペットが必要な場合のみルーン変換して抽出処理を行う
+			if !snippetsFull(len(res.Snippets), opts.MaxSnippets) {
+				// 遅延初期化: この行で初めてスニペット抽出が必要になった時だけ変換
+				if lineRunes == nil {
+					lineRunes = []rune(lineText)
+				}
+				src := lineRunes
+				if mask := redactMask(matchers[qi], opts); mask != 0 {
+					// 伏せ字は文字数を変えないため、元の行と同じ範囲を切り出せる
+					src = redactRunes(lineRunes, lineText, matchers[qi], mask)
+				}
+				start, end := runeRange(lineText, loc)
+				from, to := contextRange(lineRunes, start, end, opts)
+				// 同じ行で既に他のクエリがスニペットを作っていれば、そこにヒット部分を加える
+				combinable := opts.CombineLines && redactMask(matchers[qi], opts) == 0
+				if combinable && combined != nil {
+					combined.add(q, start, end, from, to, opts)
+					continue
+				}
+				// ヒット部分の位置を保持するため前・ヒット・後ろに分けてエスケープする
+				pre := EscapeSnippet(string(src[from:start]), opts.Escape)
+				hit := EscapeSnippet(string(src[start:end]), opts.Escape)
+				post := EscapeSnippet(string(src[end:to]), opts.Escape)
+				pre, hit, post = clampSnippet(pre, hit, post, opts.MaxSnippetBytes)
+				snippet := arena.join(pre, hit, post)
+				res.Snippets = append(res.Snippets, snippet)
+				info := SnippetInfo{
+					Line:       lineNum,
+					Offset:     lineOffset,
+					Repeats:    1,
+					Lines:      []int{lineNum},
+					Col:        start + 1,
+					MatchStart: len(pre),
+					MatchEnd:   len(pre) + len(hit),
+				}
+				// 元の行ではヒットしない場合は、エンコードされた部分の中にあったことを記録する
+				if rawText != lineText && matchers[qi].find(rawText) == nil {
+					info.Decoded = true
+				}
+				if opts.CompareEncoding != nil {
+					info.Converted = SimulateConversion(snippet, opts.CompareEncoding)
+				}
+				// 伏せ字にしたスニペットは元のバイト列を残さない
+				if opts.KeepRaw && redactMask(matchers[qi], opts) == 0 {
+					info.Raw = clampRaw(source.span(lineText, from, to), opts.MaxSnippetBytes)
+				}
+				res.Infos = append(res.Infos, info)
+				if folded != nil {
+					folded[q][lineText] = len(res.Snippets) - 1
+				}
+				if combinable {
+					combined = &combinedSnippet{
+						res: res, idx: len(res.Snippets) - 1, lineText: lineText, source: source, src: src,
+						from: from, to: to, spans: []runeSpan{{q, start, end}},
+					}
+				}
+			}
+		}
+
+		if len(hitQueries) > 1 {
+			recordCoOccurrence(results, queries, hitQueries)
+		}
+
+		if passWriter != nil && matched == opts.PassMatched {
+			passWriter.Write(scanner.Raw())
+			if _, err := passWriter.Write(newline); err != nil {
+				return nil, fmt.Errorf("error writing passthrough line: %w", err)
+			}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, &offsetError{totalBytes, fmt.Errorf("error reading stream: %w", err)}
+	}
+
+	if passWriter != nil {
+		if err := passWriter.Flush(); err != nil {
+			return nil, fmt.Errorf("error writing passthrough line: %w", err)
+		}
+	}
+	for q, sink := range sinks {
+		if err := sink.Flush(); err != nil {
+			return nil, fmt.Errorf("error writing matched line for %q: %w", q, err)
+		}
+	}
+
+	for _, res := range results {
+		res.Lines = lineNum
+		res.Bytes = totalBytes
+		if inputEncoding != opts.InputEncoding {
+			res.Encoding = inputEncoding
+		}
+		if opts.SampleEvery > 1 {
+			res.Estimate = estimateCount(res.Count, sampled, lineNum)
+		}
+	}
+
+	return results, nil
+}
+
+// redactMask はクエリのスニペットに適用する伏せ字を返します(伏せない場合は0)
+func redactMask(m matcher, opts SearchOptions) rune {
+	if opts.RedactMask != 0 {
+		return opts.RedactMask
+	}
+	if m.alwaysRedact() {
+		return DefaultRedactMask
+	}
+	return 0
+}
+
+// LinesOutPath はクエリごとのヒット行出力先を決定します。
+// パスに {query} が含まれていれば置換し、なければ拡張子の前に "_クエリ" を挿入します。
+// name にはクエリそのものではなく、QueryFileNames でファイル名に使えるようにしたものを渡します。
+func LinesOutPath(pattern, name string) string {
+	if strings.Contains(pattern, "{query}") {
+		return strings.ReplaceAll(pattern, "{query}", name)
+	}
+	ext := filepath.Ext(pattern)
+	return pattern[:len(pattern)-len(ext)] + "_" + name + ext
+}
+
+// NewResults は検索語ごとに空の結果を用意します
+func NewResults(queries []string) map[string]*SearchResult {
+	results := make(map[string]*SearchResult, len(queries))
+	for _, q := range queries {
+		results[q] = &SearchResult{Query: q}
+	}
+	return results
+}
+
+// MergeResults は src の結果を dst に加算します。
+// スニペットは maxSnippets 件(SearchOptions.MaxSnippets と同じ指定)まで追加し、出典として path を記録します。
+func MergeResults(dst, src map[string]*SearchResult, path string, maxSnippets int) {
+	for q, s := range src {
+		d, ok := dst[q]
+		if !ok {
+			continue
+		}
+		d.Count += s.Count
+		d.Suppressed += s.Suppressed
+		for v, n := range s.Variants {
+			if d.Variants == nil {
+				d.Variants = make(map[string]int)
+			}
+			d.Variants[v] += n
+		}
+		d.Encoding = mergeEncoding(d.Encoding, s.Encoding)
+		d.Resized = append(d.Resized, s.Resized...)
+		// ファイルは統合する順に並んでいるものとして、最初と最後のヒットを更新する
+		if s.First != nil && d.First == nil {
+			d.First = &Occurrence{Path: path, Line: s.First.Line, Offset: s.First.Offset}
+		}
+		if s.Last != nil {
+			d.Last = &Occurrence{Path: path, Line: s.Last.Line, Offset: s.Last.Offset}
+		}
+		for other, n := range s.CoOccur {
+			addCoOccurrence(d, other, n)
+		}
+		for name, values := range s.Captures {
+			for v, n := range values {
+				addCapture(d, name, v, n)
+			}
+		}
+		d.Lines += s.Lines
+		d.Bytes += s.Bytes
+		if s.Estimate != nil {
+			d.Estimate = mergeEstimate(d.Estimate, s.Estimate)
+		}
+
+		for i, snippet := range s.Snippets {
+			if snippetsFull(len(d.Snippets), maxSnippets) {
+				break
+			}
+			var info SnippetInfo
+			if i < len(s.Infos) {
+				info = s.Infos[i]
+			}
+			// ZIP内のエントリのように、既に詳しい出典があればそれを残す
+			if info.Path == "" {
+				info.Path = path
+			}
+			d.Snippets = append(d.Snippets, snippet)
+			d.Infos = append(d.Infos, info)
+		}
+	}
+}
+
+// snippetsFull はスニペットが n 件あれば上限 limit(SearchOptions.MaxSnippets の指定)に達しているかを返します
+func snippetsFull(n, limit int) bool {
+	switch {
+	case limit < 0:
+		return false
+	case limit == 0:
+		return n >= MaxSnippets
+	}
+	return n >= limit
+}
+
+// String は位置を "a.log:12行目 (offset 345)" の形式で返します
+func (o *Occurrence) String() string {
+	s := fmt.Sprintf("%d行目 (offset %d)", o.Line, o.Offset)
+	if o.Path != "" {
+		s = o.Path + ":" + s
+	}
+	return s
+}
+
+// mergeEncoding はファイルごとの判定結果を重複なく "sjis, utf-8" の形式でまとめます
+func mergeEncoding(a, b string) string {
+	if a == "" {
+		return b
+	}
+	if b == "" || slices.Contains(strings.Split(a, ", "), b) {
+		return a
+	}
+	return a + ", " + b
+}
+
+// mergeEstimate は独立な標本からの推定値を合算します(信頼区間の半幅は二乗和の平方根)
+func mergeEstimate(a, b *Estimate) *Estimate {
+	if a == nil {
+		c := *b
+		return &c
+	}
+	return &Estimate{
+		Count:        a.Count + b.Count,
+		Margin:       int(math.Round(math.Hypot(float64(a.Margin), float64(b.Margin)))),
+		SampledLines: a.SampledLines + b.SampledLines,
+		TotalLines:   a.TotalLines + b.TotalLines,
+	}
+}
+
+// estimateCount は標本中の該当行数から全体の該当行数と95%信頼区間を推定します。
+// 系統抽出を単純無作為抽出とみなし、有限母集団修正を適用します。
+func estimateCount(hits, sampled, total int) *Estimate {
+	est := &Estimate{SampledLines: sampled, TotalLines: total}
+	if sampled == 0 {
+		return est
+	}
+
+	p := float64(hits) / float64(sampled)
+	est.Count = int(math.Round(p * float64(total)))
+
+	if total > 1 {
+		fpc := float64(total-sampled) / float64(total-1)
+		se := math.Sqrt(p * (1 - p) / float64(sampled) * fpc)
+		est.Margin = int(math.Round(1.96 * se * float64(total)))
+	}
+	return est
+}
+
+// ParseSampleSpec はサンプリング指定("1%" または N行ごとの "N")を照合間隔に変換します
+func ParseSampleSpec(spec string) (int, error) {
+	if pct, ok := strings.CutSuffix(spec, "%"); ok {
+		v, err := strconv.ParseFloat(pct, 64)
+		if err != nil || v <= 0 || v > 100 {
+			return 0, fmt.Errorf("invalid sample percentage: %s", spec)
+		}
+		return int(math.Round(100 / v)), nil
+	}
+
+	n, err := strconv.Atoi(spec)
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("invalid sample interval: %s", spec)
+	}
+	return n, nil
+}
+
+// snippetRange はスニペットとして切り出す範囲をルーン単位で返します
+func snippetRange(lineLen, start, end, contextSize int) (from, to int) {
+	// 定数ContextCharsではなく、引数contextSizeを使用
+	from = start - contextSize
+	if from < 0 {
+		from = 0
+	}
+
+	to = end + contextSize
+	if to > lineLen {
+		to = lineLen
+	}
+
+	return from, to
+}
+
+// byteOffset はルーン位置 runeIdx に対応する行中のバイト位置を返します
+func byteOffset(line string, runeIdx int) int {
+	n := 0
+	for i := range line {
+		if n == runeIdx {
+			return i
+		}
+		n++
+	}
+	return len(line)
+}
+
+// redactRunes は行中のすべてのヒット箇所を同じ文字数の mask で置き換えたコピーを返します
+func redactRunes(lineRunes []rune, lineText string, m matcher, mask rune) []rune {
+	redacted := append([]rune(nil), lineRunes...)
+	for _, loc := range m.findAll(lineText) {
+		start, end := runeRange(lineText, loc)
+		for i := start; i < end; i++ {
+			redacted[i] = mask
+		}
+	}
+	return redacted
+}
+
+// WriteResults は結果を指定されたWriterに出力します
+func WriteResults(w io.Writer, results map[string]*SearchResult, queryOrder []string) {
+	WriteResultsWithLayout(w, results, queryOrder, DefaultLayout)
+}
+
+// WriteResultsWithLayout は見出しやラベルを指定して結果を出力します
+func WriteResultsWithLayout(w io.Writer, results map[string]*SearchResult, queryOrder []string, layout ReportLayout) {
+	for _, q := range queryOrder {
+		res, ok := results[q]
+		if !ok {
+			continue
+		}
+
+		fmt.Fprintln(w, strings.ReplaceAll(layout.Header, "{query}", res.Query))
+		fmt.Fprintf(w, "%s: %d\n", layout.CountLabel, res.Count)
+		if res.Suppressed > 0 {
+			fmt.Fprintf(w, "%s: %d\n", layout.SuppressedLabel, res.Suppressed)
+		}
+		if est := res.Estimate; est != nil {
+			fmt.Fprintf(w, "%s: %d (±%d, 95%%信頼区間, 標本 %d/%d 行)\n",
+				layout.EstimateLabel, est.Count, est.Margin, est.SampledLines, est.TotalLines)
+		}
+		if res.Max != nil {
+			status := "OK"
+			if res.Breached() {
+				if label, ok := severityLabels[res.Severity]; ok {
+					status = label
+				}
+			}
+			fmt.Fprintf(w, "%s: %d (%s)\n", layout.MaxLabel, *res.Max, status)
+		}
+		if res.Lines > 0 {
+			perMB, per10k := res.Density()
+			fmt.Fprintf(w, "%s: %.2f 件/MB, %.2f 件/1万行\n", layout.DensityLabel, perMB, per10k)
+		}
+		if res.First != nil {
+			fmt.Fprintf(w, "%s: %s 〜 %s\n", layout.RangeLabel, res.First, res.Last)
+		}
+		if len(res.Variants) > 0 {
+			fmt.Fprintf(w, "%s: %s\n", layout.VariantsLabel, formatVariants(res.Variants))
+		}
+		for _, name := range captureNames(res.Captures) {
+			fmt.Fprintf(w, "%s(%s): %s\n", layout.CaptureLabel, name, formatCaptures(res.Captures[name]))
+		}
+		if len(res.Files) > 0 {
+			fmt.Fprintf(w, "%s:\n", layout.FilesLabel)
+			for _, fc := range res.Files {
+				if fc.Ownership != nil {
+					fmt.Fprintf(w, "  %s: %d (%s)\n", fc.Path, fc.Count, fc.Ownership)
+				} else {
+					fmt.Fprintf(w, "  %s: %d\n", fc.Path, fc.Count)
+				}
+			}
+		}
+
+		for i, snippet := range res.Snippets {
+			if i < len(res.Infos) && layout.Color {
+				snippet = colorizeSnippet(snippet, res.Infos[i])
+			} else if i < len(res.Infos) {
+				snippet = highlightSnippet(snippet, res.Infos[i].Matches)
+			}
+			fmt.Fprintf(w, "%s%s%s%s%s\n", snippetPrefix(layout.SnippetPrefix, res, i), snippet, foldAnnotation(res, i), decodedAnnotation(res, i), classAnnotation(res, i))
+			if i < len(res.Infos) && res.Infos[i].Converted != "" {
+				fmt.Fprintf(w, "%s:%s\n", layout.ConvertLabel, res.Infos[i].Converted)
+			}
+			if i < len(res.Infos) && res.Infos[i].Raw != nil {
+				writeHexDump(w, res.Infos[i].Raw)
+			}
+		}
+		fmt.Fprintln(w, layout.Separator)
+	}
+}
+
+// snippetPrefix はスニペットの前に付ける "line 123: " 等の文字列を返します。
+// {n} はスニペットの通し番号、{line} は行番号、{offset} は行頭のバイト位置に置換します。
+func snippetPrefix(format string, res *SearchResult, i int) string {
+	var info SnippetInfo
+	if i < len(res.Infos) {
+		info = res.Infos[i]
+	}
+	return strings.NewReplacer(
+		"{n}", strconv.Itoa(i+1),
+		"{line}", strconv.Itoa(info.Line),
+		"{offset}", strconv.FormatInt(info.Offset, 10),
+		"{char}", strconv.Itoa(info.Char),
+	).Replace(format)
+}
+
+// writeHexDump はスニペットの元のバイト列を字下げした16進ダンプとして出力します
+func writeHexDump(w io.Writer, raw []byte) {
+	dump := strings.TrimRight(hex.Dump(raw), "\n")
+	for _, line := range strings.Split(dump, "\n") {
+		fmt.Fprintf(w, "    %s\n", line)
+	}
+}
+
+// formatVariants は表記ごとの該当数を件数の多い順に "髙橋=3, 高橋=1" の形式で返します
+func formatVariants(variants map[string]int) string {
+	keys := make([]string, 0, len(variants))
+	for v := range variants {
+		keys = append(keys, v)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if variants[keys[i]] != variants[keys[j]] {
+			return variants[keys[i]] > variants[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+
+	parts := make([]string, len(keys))
+	for i, v := range keys {
+		parts[i] = fmt.Sprintf("%s=%d", v, variants[v])
+	}
+	return strings.Join(parts, ", ")
+}
+
+// decodedAnnotation は前処理で復号した部分の中でヒットしたスニペットの注記を返します
+func decodedAnnotation(res *SearchResult, i int) string {
+	if i >= len(res.Infos) || !res.Infos[i].Decoded {
+		return ""
+	}
+	return fmt.Sprintf(" (エンコード部分内, %d行目)", res.Infos[i].Line)
+}
+
+// foldAnnotation は折り畳まれたスニペットの注記(例: " ×3 (lines 10, 20, 30)")を返します
+func foldAnnotation(res *SearchResult, i int) string {
+	if i >= len(res.Infos) || res.Infos[i].Repeats < 2 {
+		return ""
+	}
+	info := res.Infos[i]
+
+	lines := make([]string, 0, len(info.Lines)+1)
+	for _, n := range info.Lines {
+		lines = append(lines, strconv.Itoa(n))
+	}
+	if info.Repeats > len(info.Lines) {
+		lines = append(lines, "…")
+	}
+	return fmt.Sprintf(" ×%d (lines %s)", info.Repeats, strings.Join(lines, ", "))
+}
+
+// ==========================================
+// 3. Application Wiring
+// ==========================================
+
+type AppContext struct {
+	Args        []string
+	ExecPath    string
+	Stdout      io.Writer
+	Stderr      io.Writer
+	Stdin       io.Reader // nilでなければパスワードの入力に使う
+	FileReader  func(string) (io.ReadCloser, error)
+	FileCreator func(string) (io.WriteCloser, error)
+	Rename      func(string, string) error // nilでなければ -o を一時ファイルに書き込み、成功時にこの関数で置き換える
+	Remove      func(string) error         // 失敗時に一時ファイルを削除する
+	Pager       func([]byte) error         // nilでなければ標準出力向けのレポートをこの関数経由で表示する
+	FileStat    func(string) (os.FileInfo, error)
+	StdinIsPipe bool                // 標準入力が端末ではなくパイプやファイルにつながっている
+	StdoutIsTTY bool                // 標準出力が端末につながっている(-color auto で色を付ける)
+	DirFS       func(string) fs.FS  // nilでなければディレクトリの入力を再帰的に検索する
+	Getenv      func(string) string // 環境変数の参照(OTEL_* のトレース設定、ワーカーのトークン等)。nilなら未設定として扱う
+}
+
+// getenv は環境変数の値を返します(ctx.Getenv が nil なら空)
+func (ctx AppContext) getenv(key string) string {
+	if ctx.Getenv == nil {
+		return ""
+	}
+	return ctx.Getenv(key)
+}
+
+// Run はアプリケーションを実行し、終了コードを返します。
+// -legacy-exit を指定した場合は、ヒットの有無によらず成功時は0、エラー時は1を返します。
+func Run(ctx AppContext) (code int) {
+	logger := newTextLogger(ctx.Stderr)
+
+	args := make([]string, len(ctx.Args))
+	copy(args, ctx.Args)
+	if len(args) > 0 {
+		args = args[1:]
+	}
+	if len(args) > 0 && args[0] == SimulateCommand {
+		return runSimulate(ctx, logger, args[1:])
+	}
+	if len(args) > 0 && args[0] == CheckQueriesCommand {
+		return runCheckQueries(ctx, logger, args[1:])
+	}
+
+	// OTEL_* の環境変数が指定されていれば、実行全体・ファイルごと・段階ごとのスパンを終了時に送信する
+	tracer, err := NewTracerFromEnv(ctx.Getenv)
+	if err != nil {
+		logger.Warn("Tracing is disabled", "error", err)
+	}
+	root := tracer.Start("run")
+	defer func() {
+		root.SetAttributes(intAttr("process.exit.code", code))
+		root.End()
+		if err := tracer.Flush(); err != nil {
+			logger.Warn("Failed to export traces", "error", err)
+		}
+	}()
+
+	fs := flag.NewFlagSet("app", flag.ContinueOnError)
+	outputFile := fs.String("o", "", "Output file path (optional)")
+	var extraOutputs outputList
+	fs.Var(&extraOutputs, "output", "Additional report written from the same scan (fmt=json,path=a.json); may be repeated")
+	// コンテキストサイズを指定するフラグ -n を追加
+	contextSize := fs.Int("n", DefaultContextSize, "Number of context characters (default 20)")
+	legacyExit := fs.Bool("legacy-exit", false, "Exit with 0 whether or not anything matched and 1 on errors, instead of grep-style 0 (match), 1 (no match), 2 (error)")
+	defer func() {
+		if *legacyExit {
+			code = legacyExitCode(code)
+		}
+	}()
+	foldDuplicates := fs.Bool("fold-duplicates", false, "Fold identical matched lines into one snippet")
+	configFile := fs.String("config", "", "Config file (TOML key = value) setting queries and flag defaults; app.toml next to the executable is loaded when omitted")
+	queryFile := fs.String("f", "", "Read additional queries from this file, one per line (# starts a comment line)")
+	queriesStdin := fs.Bool("queries-stdin", false, "Read additional queries from stdin, one per line as with -f; the input must then be given as a file path")
+	queriesEnc := fs.String("queries-enc", "", "Encoding of the queries read by -queries-stdin (sjis|eucjp|utf-16le|...); default UTF-8")
+	var flagQueries queryList
+	fs.Var(&flagQueries, "q", "Search query; may be repeated (added to the queries in the executable name)")
+	replaceQueries := fs.Bool("replace-queries", false, "Use only the queries from -f and -q, ignoring those in the executable name and config file")
+	rulesFile := fs.String("rules", "", "Rules file declaring per-query thresholds (query \"WARN\" max=100)")
+	passthrough := fs.String("passthrough", "", "Write matched or unmatched raw lines to stdout (matched|unmatched); the report goes to -o only")
+	linesOut := fs.String("lines-out", "", "Write full matched lines per query to files (path may contain {query})")
+	queryFileNames := fs.String("query-filenames", QueryNamesReadable, "How queries become -lines-out file names (readable: replace illegal symbols with full-width ones; strict: ASCII letters, digits and ._- plus a hash)")
+	redact := fs.Bool("redact", false, "Mask matched text in snippets")
+	redactChar := fs.String("redact-char", "*", "Mask character used by -redact")
+	format := fs.String("format", FormatText, "Output format (text|table|json|jsonl|csv|tsv)")
+	fileOwner := fs.Bool("file-owner", false, "Include the owner, group and permissions of each file in per-file results (owner and group only where the platform provides them)")
+	absPaths := fs.Bool("abs-paths", false, "Report canonical absolute paths (symlinks resolved) in json/jsonl output")
+	pathRoot := fs.String("root", "", "Report json/jsonl paths relative to this directory after canonicalizing them (paths outside it stay absolute); implies -abs-paths")
+	colorMode := fs.String("color", ColorAuto, "Highlight matches in text snippets with ANSI colors (auto|always|never); auto colors only a terminal stdout without -o")
+	maxSnippets := fs.Int("max-snippets", MaxSnippets, "Maximum number of snippets kept per query; 0 = unlimited")
+	maxSnippetBytes := fs.Int("max-snippet-bytes", 0, "Truncate snippets and -lines-out lines longer than this many bytes, marking the cut with …; 0 = unlimited")
+	noPager := fs.Bool("no-pager", false, "Do not pipe long terminal output through $PAGER")
+	escapeNonPrintable := fs.Bool("escape-nonprintable", false, "Render control and zero-width characters in snippets as \\uXXXX")
+	escapeNonASCII := fs.Bool("escape-non-ascii", false, "With -escape-nonprintable, also escape all non-ASCII characters")
+	hexSnippets := fs.Bool("hex-snippets", false, "Show a hex dump of the original bytes under each snippet")
+	contextAlign := fs.String("context-align", ContextAlignChar, "Context window alignment (char|word)")
+	compareEnc := fs.String("compare-enc", "", "Also show each snippet as it would appear after conversion to this encoding (sjis|eucjp|iso2022jp)")
+	layoutFile := fs.String("layout", "", "Layout file overriding report headers, labels and separators (key = value)")
+	separator := fs.String("separator", DefaultLayout.Separator, "Section separator line for text output")
+	header := fs.String("header", DefaultLayout.Header, "Section header for text output ({query} is replaced)")
+	snippetPrefixFlag := fs.String("snippet-prefix", DefaultLayout.SnippetPrefix, "Prefix of each snippet in text output ({line}, {offset} and {n} are replaced)")
+	verifySHA256 := fs.String("verify-sha256", "", "Verify the input against a SHA-256 hash (or sha256sum-style checksum file) before scanning")
+	cacheDir := fs.String("cache", "", "Directory for caching results keyed by file hash, queries and options")
+	workerAddr := fs.String("worker", "", "Run as a distributed scan worker listening on this address (an empty host listens on 127.0.0.1 only); requires -worker-root and "+WorkerTokenEnv)
+	workerRoot := fs.String("worker-root", "", "Directory a -worker serves; request paths must be relative to it")
+	coordinator := fs.Bool("coordinator", false, "Distribute the input files across -workers and merge their results")
+	workerJobs := fs.Int("worker-jobs", runtime.NumCPU(), "Maximum number of jobs a worker scans concurrently")
+	workerQueue := fs.Int("worker-queue", DefaultJobQueueSize, "Maximum number of jobs waiting in a worker's queue")
+	jobMaxMatches := fs.Int("job-max-matches", DefaultJobMaxMatches, "Maximum number of matches a worker keeps per job for GET /jobs/{id}/matches (0 for no limit); the counts are not affected")
+	jobRetention := fs.Duration("job-retention", DefaultJobRetention, "How long a worker keeps finished job results")
+	workers := fs.String("workers", "", "Comma-separated worker addresses used by -coordinator")
+	workerTimeout := fs.Duration("worker-timeout", DefaultWorkerTimeout, "Deadline for one -coordinator scan request, also the -worker response write timeout")
+	expandVariants := fs.Bool("expand-variants", false, "Also match variant (itaiji) and IVS forms of each query character")
+	variantsFile := fs.String("variants", "", "Variant table file (one group of equivalent characters per line); implies -expand-variants")
+	romaji := fs.Bool("romaji", false, "Match romaji queries (satou) against their kana and kanji renderings")
+	anchor := fs.String("anchor", "", "Require matches at line start, line end or the whole line (start|end|full); not available for character class or range queries")
+	contextUnit := fs.String("context-unit", ContextUnitChar, "Context unit (char|sentence)")
+	sentenceMax := fs.Int("sentence-max", DefaultSentenceMax, "Maximum snippet length in characters with -context-unit sentence")
+	convertReport := fs.String("convert-report", "", "Convert an older JSON/JSONL report to the current schema and exit")
+	follow := fs.Bool("follow", false, "Keep watching the input for appended lines and print hits as they arrive")
+	followRate := fs.Float64("follow-rate", 0, "Maximum hits printed per second in -follow mode (0 = unlimited)")
+	followBuffer := fs.Int("follow-buffer", DefaultFollowBuffer, "Number of hits buffered in -follow mode before overflow handling applies")
+	followOverflow := fs.String("follow-overflow", OverflowDrop, "What to do when the -follow buffer is full (drop|block)")
+	snapshot := fs.Bool("snapshot", false, "Copy each input file to a temporary location and scan the copy, for a consistent view of files being written")
+	anonymize := fs.Bool("anonymize", false, "Hash file paths and mask snippets except the matched characters and their JIS classification, for reports shared outside the organization")
+	retryOpenCount := fs.Int("retry-open", 0, "Retry opening an input file up to N times when another process holds it open (a sharing violation on Windows)")
+	zipPassword := fs.String("zip-password", "", "Password for encrypted ZIP input (prompted on stdin if omitted)")
+	signKey := fs.String("sign", "", "Sign the -o report: PEM private key for a detached signature, any other file as an HMAC secret")
+	verifyReport := fs.String("verify-report", "", "Verify a report against its .sig file using the -sign key and exit")
+	filter := fs.String("filter", "", "Comma-separated preprocessing filters applied to each line before matching (ansi|base64|html|quoted-printable|unicode-escape|url[:charset])")
+	groupBy := fs.String("group-by-capture", "", "Rank results by the values captured by this named group of re: queries")
+	inventory := fs.String("inventory", "", "List each character not representable in this encoding once, with its first location in every input file, and exit")
+	audit := fs.String("audit", "", "Instead of searching, report every character outside JIS X 0208 classified by JIS X 0213 plane and kanji level, with counts, code points and context, and exit (jis2004)")
+	glyphChanges := fs.Bool("glyph-changes", false, "Instead of searching, list every line containing one of the 168 characters whose example glyph changed between JIS90 and JIS2004, and exit")
+	ivsReport := fs.Bool("ivs-report", false, "Instead of searching, list every ideographic variation sequence with its base character and selector, and exit")
+	nonBMP := fs.Bool("non-bmp", false, "Instead of searching, list every character above U+FFFF (a surrogate pair in UTF-16, lost in UCS-2 storage) with its line and context, and exit")
+	columnStats := fs.String("column-stats", "", "For tabular inputs, report per column how many rows contain characters not representable in this encoding, and exit")
+	columnsSpec := fs.String("columns", ColumnsCSV, "How -column-stats splits rows into columns (csv|tsv|fixed:W1,W2,... with widths in characters)")
+	columnHeader := fs.Bool("column-header", false, "With -column-stats, treat the first row of each file as column names")
+	printSchema := fs.Bool("print-schema", false, "Print the JSON Schema of the json/jsonl output and exit")
+	cooccur := fs.Bool("cooccurrence", false, "Report how many lines matched each pair of queries")
+	inputEnc := fs.String("enc", "", "Input file encoding (auto|sjis|sjis2004|eucjp|iso2022jp|utf-16le|utf-16be); default UTF-8, or UTF-16 when the file starts with a UTF-16 BOM")
+	combineLines := fs.Bool("combine-lines", false, "When several queries hit the same line, show one snippet with all matches highlighted")
+	include := fs.String("include", "", "When the input is a directory, search only files matching these comma-separated glob patterns")
+	exclude := fs.String("exclude", "", "When the input is a directory, skip files and directories matching these comma-separated glob patterns")
+	jobs := fs.Int("jobs", runtime.NumCPU(), "Number of files searched concurrently when several files or a directory are given")
+	failOn := fs.String("fail-on", SeverityError, "Exit with code 3 when a rule of this severity or higher is exceeded (error|warning|info)")
+	suppressMarker := fs.String("suppress-marker", DefaultSuppressMarker, "Skip hits on lines containing this marker and on the line after it (marker=q1,q2 limits it to those queries; empty disables)")
+	stateFile := fs.String("state", "", "State file remembering how far each input file was scanned; later runs scan and report only appended lines, including those moved to a rotated file such as app.log.1")
+	engine := fs.String("engine", EngineAuto, "Matching engine for plain string queries (auto|naive|bytes|aho-corasick); results are identical")
+	unicodeVersion := fs.String("unicode-version", DefaultUnicodeVersion, "Unicode version used by version-dependent character classes such as @class:unassigned")
+	normalize := fs.String("normalize", "", "Unicode-normalize queries and lines before matching (nfkc|nfc); snippets keep the original text")
+	countOnly := fs.Bool("c", false, "Print only the number of matching lines per query, skipping snippet extraction")
+	noLineMode := fs.Bool("no-line-mode", false, "Match the input as one continuous text with line breaks removed, so terms wrapped across lines are found; counts every hit and reports character offsets")
+	maxTextBytes := fs.Int64("max-text-bytes", DefaultMaxTextBytes, "With -no-line-mode, fail on inputs larger than this many bytes after decoding, since the whole input is held in memory; 0 = unlimited")
+	extended := fs.Bool("E", false, "Treat every query as a regular expression; \\p{JIS3}, \\p{JIS4} and \\p{CP932EXT} match JIS X 0213 level 3/4 kanji and CP932 extensions")
+	ignoreCase := fs.Bool("i", false, "Ignore case distinctions (Unicode-aware) in queries and input")
+	foldKana := fs.Bool("fold-kana", false, "Treat hiragana and katakana as equal (かたろぐ matches カタログ); snippets keep the original text")
+	ignoreIVS := fs.Bool("ignore-ivs", false, "Ignore variation selectors (IVS U+E0100..U+E01EF and SVS) when matching, so 辻 also hits 辻 followed by a selector")
+	allowGrowth := fs.Bool("allow-growth", false, "Scan files that grow during the scan to their end instead of stopping at the size seen when the scan started")
+	foldWidth := fs.Bool("fold-width", false, "Treat half-width and full-width forms as equal (ｶﾀﾛｸﾞ matches カタログ, Ａ matches A); snippets keep the original text")
+	sample := fs.String("sample", "", "Scan only a sample of lines: percentage (1%) or every Nth line (100)")
+
+	if err := fs.Parse(args); err != nil {
+		logger.Error("Flag parse error", "code", CodeUsage, "error", err)
+		return ExitError
+	}
+
+	// 設定ファイルの値は明示されていないフラグにのみ反映する
+	configPath := *configFile
+	if configPath == "" && ctx.FileStat != nil {
+		if path := ConfigPathFor(ctx.ExecPath); path != "" {
+			if _, err := ctx.FileStat(path); err == nil {
+				configPath = path
+			}
+		}
+	}
+	var fileConfig FileConfig
+	if configPath != "" {
+		cf, err := ctx.FileReader(configPath)
+		if err != nil {
+			logger.Error("Failed to open config file", "code", CodeOpenFailed, "path", configPath, "error", err)
+			return ExitError
+		}
+		loaded, err := LoadConfigFile(cf)
+		cf.Close()
+		if err == nil {
+			err = loaded.Apply(fs)
+		}
+		if err != nil {
+			logger.Error("Invalid config file", "code", CodeInvalidConfig, "path", configPath, "error", err)
+			return ExitError
+		}
+		fileConfig = *loaded
+	}
+
+	// 機械可読出力を選んだ場合はエラーも呼び出し元が解析できるJSONで出力する
+	if *format == FormatJSON || *format == FormatJSONL {
+		logger = newJSONLogger(ctx.Stderr)
+	}
+
+	// 書き込み中のログ等、他のプロセスが開いているために開けなかった入力は間隔を空けて開き直す
+	if *retryOpenCount < 0 {
+		logger.Error("-retry-open must not be negative", "code", CodeInvalidOption, "retry-open", *retryOpenCount)
+		return ExitError
+	}
+	if *retryOpenCount > 0 {
+		ctx.FileReader = retryingReader(ctx.FileReader, *retryOpenCount, DefaultRetryOpenInterval)
+		if ctx.DirFS != nil {
+			ctx.DirFS = retryingDirFS(ctx.DirFS, *retryOpenCount, DefaultRetryOpenInterval)
+		}
+	}
+
+	if *printSchema {
+		ctx.Stdout.Write(ReportSchema)
+		return 0
+	}
+
+	// 旧版レポートの変換は検索を伴わないため、ここで処理して終了する
+	if *convertReport != "" {
+		rf, err := ctx.FileReader(*convertReport)
+		if err != nil {
+			logger.Error("Failed to open report", "code", CodeOpenFailed, "path", *convertReport, "error", err)
+			return ExitError
+		}
+		defer rf.Close()
+		if err := ConvertReport(rf, ctx.Stdout); err != nil {
+			logger.Error("Failed to convert report", "code", CodeError, "path", *convertReport, "error", err)
+			return ExitError
+		}
+		return 0
+	}
+
+	// 問題文字・監査・字形変更・異体字シーケンス・BMP の外の文字の一覧と列ごとの集計は検索語を使わず、指定されたすべての入力を
+	// 検索と同じ手順(ディレクトリの再帰、ZIP/gzip の展開、-enc による変換)で開いて走査する
+	if *inventory != "" || *audit != "" || *glyphChanges || *ivsReport || *nonBMP || *columnStats != "" {
+		var inventoryEnc encoding.Encoding
+		if *inventory != "" {
+			enc, err := LookupEncoding(*inventory)
+			if err != nil {
+				logger.Error("Invalid inventory encoding", "code", CodeInvalidOption, "error", err)
+				return ExitError
+			}
+			inventoryEnc = enc
+		}
+		var columnEnc encoding.Encoding
+		var layout ColumnLayout
+		if *columnStats != "" {
+			enc, err := LookupEncoding(*columnStats)
+			if err != nil {
+				logger.Error("Invalid column-stats encoding", "code", CodeInvalidOption, "error", err)
+				return ExitError
+			}
+			if layout, err = ParseColumnLayout(*columnsSpec); err != nil {
+				logger.Error("Invalid column layout", "code", CodeInvalidConfig, "error", err)
+				return ExitError
+			}
+			columnEnc = enc
+		}
+		if *audit != "" && *audit != AuditJIS2004 {
+			logger.Error("Invalid audit", "code", CodeInvalidOption, "audit", *audit, "supported", AuditJIS2004)
+			return ExitError
+		}
+		if *inputEnc != "" && *inputEnc != EncodingAuto && *inputEnc != encodingUTF8 {
+			if _, err := LookupEncoding(*inputEnc); err != nil {
+				logger.Error("Invalid input encoding", "code", CodeInvalidOption, "error", err)
+				return ExitError
+			}
+		}
+		if fs.NArg() == 0 {
+			logger.Error("input file path is required", "code", CodeUsage)
+			return ExitError
+		}
+		includePatterns, err := ParsePatterns(*include)
+		if err != nil {
+			logger.Error("Invalid include option", "code", CodeInvalidOption, "error", err)
+			return ExitError
+		}
+		excludePatterns, err := ParsePatterns(*exclude)
+		if err != nil {
+			logger.Error("Invalid exclude option", "code", CodeInvalidOption, "error", err)
+			return ExitError
+		}
+		sopts := scanInputOptions{
+			Encoding: *inputEnc,
+			Walk:     WalkOptions{Include: includePatterns, Exclude: excludePatterns},
+			Password: zipPasswordPrompt(ctx, *zipPassword),
+		}
+
+		var write func() error
+		found := true
+		switch {
+		case inventoryEnc != nil:
+			inv := NewCharInventory(inventoryEnc)
+			err = scanInputs(ctx, fs.Args(), sopts, inv.Scan)
+			write = func() error { return WriteInventory(ctx.Stdout, inv.Entries(), *format) }
+		case *audit != "":
+			a := NewJIS2004Audit(*contextSize)
+			err = scanInputs(ctx, fs.Args(), sopts, a.Scan)
+			write = func() error { return WriteAudit(ctx.Stdout, a.Entries(), *format) }
+		case *glyphChanges:
+			var lines []GlyphChangeLine
+			err = scanInputs(ctx, fs.Args(), sopts, func(r io.Reader, path string) error {
+				l, err := ScanGlyphChanges(r, path)
+				lines = append(lines, l...)
+				return err
+			})
+			found = len(lines) > 0
+			write = func() error { return WriteGlyphChanges(ctx.Stdout, lines, *format) }
+		case *ivsReport:
+			rep := NewIVSReport()
+			err = scanInputs(ctx, fs.Args(), sopts, rep.Scan)
+			write = func() error { return WriteIVSReport(ctx.Stdout, rep.Entries(), *format) }
+		case *nonBMP:
+			var chars []NonBMPChar
+			err = scanInputs(ctx, fs.Args(), sopts, func(r io.Reader, path string) error {
+				c, err := ScanNonBMP(r, path, *contextSize)
+				chars = append(chars, c...)
+				return err
+			})
+			found = len(chars) > 0
+			write = func() error { return WriteNonBMP(ctx.Stdout, chars, *format) }
+		default:
+			cs := NewColumnStats(columnEnc, layout, *columnHeader)
+			err = scanInputs(ctx, fs.Args(), sopts, func(r io.Reader, _ string) error { return cs.Scan(r) })
+			write = func() error { return WriteColumnStats(ctx.Stdout, cs.Stats(), *format) }
+		}
+		if err != nil {
+			logger.Error("Scan failed", "code", CodeSearchFailed, "error", err)
+			return ExitError
+		}
+		if err := write(); err != nil {
+			logger.Error("Failed to write results", "code", CodeWriteFailed, "error", err)
+			return ExitWriteError
+		}
+		// 該当行・該当文字の一覧は grep と同様に、見つからなければ ExitNoMatch を返す
+		if !found {
+			return ExitNoMatch
+		}
+		return ExitMatch
+	}
+
+	// 署名の検証も検索を伴わないため、ここで処理して終了する
+	if *verifyReport != "" {
+		if *signKey == "" {
+			logger.Error("-verify-report requires -sign with the public key or HMAC secret", "code", CodeUsage)
+			return ExitError
+		}
+		key, err := readFile(ctx, *signKey)
+		if err != nil {
+			logger.Error("Failed to read key", "code", CodeReadFailed, "path", *signKey, "error", err)
+			return ExitError
+		}
+		report, err := readFile(ctx, *verifyReport)
+		if err != nil {
+			logger.Error("Failed to read report", "code", CodeReadFailed, "path", *verifyReport, "error", err)
+			return ExitError
+		}
+		sig, err := readFile(ctx, *verifyReport+SignatureExt)
+		if err != nil {
+			logger.Error("Failed to read signature", "code", CodeReadFailed, "path", *verifyReport+SignatureExt, "error", err)
+			return ExitError
+		}
+		if err := VerifyReport(report, string(sig), key); err != nil {
+			logger.Error("Report verification failed", "code", CodeVerifyFailed, "path", *verifyReport, "error", err)
+			return ExitError
+		}
+		fmt.Fprintf(ctx.Stdout, "%s: OK\n", *verifyReport)
+		return 0
+	}
+
+	// ワーカーとして起動した場合は走査要求を待ち受け続ける。
+	// POST /scan は同期的に、POST /jobs はジョブキューを経由して非同期に走査する。
+	// 走査できるのは -worker-root の下のファイルのみで、要求には共有トークンが必要。
+	if *workerTimeout <= 0 {
+		logger.Error("-worker-timeout must be positive", "code", CodeInvalidOption, "timeout", *workerTimeout)
+		return ExitError
+	}
+	if *workerAddr != "" {
+		if *workerRoot == "" {
+			logger.Error("-worker requires -worker-root", "code", CodeUsage)
+			return ExitError
+		}
+		token := ctx.getenv(WorkerTokenEnv)
+		if token == "" {
+			logger.Error("-worker requires a shared token in "+WorkerTokenEnv, "code", CodeUsage)
+			return ExitError
+		}
+		open, err := RootOpener(*workerRoot, ctx.FileReader)
+		if err != nil {
+			logger.Error("Invalid worker root", "code", CodeInvalidOption, "path", *workerRoot, "error", err)
+			return ExitError
+		}
+
+		if *jobMaxMatches < 0 {
+			logger.Error("Invalid job max matches", "code", CodeInvalidOption, "matches", *jobMaxMatches)
+			return ExitError
+		}
+		queue := NewJobQueue(open, *workerJobs, *workerQueue, *jobMaxMatches, *jobRetention)
+		mux := http.NewServeMux()
+		mux.Handle("/scan", WorkerHandler(open))
+		mux.Handle("/jobs", queue)
+		mux.Handle("/jobs/", queue)
+
+		addr := workerListenAddr(*workerAddr)
+		logger.Info("Worker listening", "addr", addr, "root", *workerRoot, "jobs", *workerJobs, "queue", *workerQueue)
+		if err := newWorkerServer(addr, RequireToken(token, mux), *workerTimeout).ListenAndServe(); err != nil {
+			logger.Error("Worker stopped", "code", CodeRemoteFailed, "error", err)
+		}
+		return ExitError
+	}
+
+	// 負の値が指定された場合のガード
+	if *contextSize < 0 {
+		logger.Error("Context size cannot be negative", "code", CodeInvalidOption)
+		return ExitError
+	}
+
+	sampleEvery := 0
+	if *sample != "" {
+		n, err := ParseSampleSpec(*sample)
+		if err != nil {
+			logger.Error("Invalid sample option", "code", CodeInvalidOption, "error", err)
+			return ExitError
+		}
+		sampleEvery = n
+	}
+
+	filters, err := ParseFilters(*filter)
+	if err != nil {
+		logger.Error("Invalid filter option", "code", CodeInvalidOption, "error", err)
+		return ExitError
+	}
+
+	if !ValidSeverity(*failOn) {
+		logger.Error("Invalid fail-on severity", "code", CodeInvalidOption, "severity", *failOn)
+		return ExitError
+	}
+
+	includePatterns, err := ParsePatterns(*include)
+	if err != nil {
+		logger.Error("Invalid include option", "code", CodeInvalidOption, "error", err)
+		return ExitError
+	}
+	excludePatterns, err := ParsePatterns(*exclude)
+	if err != nil {
+		logger.Error("Invalid exclude option", "code", CodeInvalidOption, "error", err)
+		return ExitError
+	}
+	if *jobs < 1 {
+		logger.Error("Invalid jobs", "code", CodeInvalidOption, "jobs", *jobs)
+		return ExitError
+	}
+
+	if *passthrough != "" && *passthrough != "matched" && *passthrough != "unmatched" {
+		logger.Error("Invalid passthrough mode", "code", CodeInvalidOption, "mode", *passthrough)
+		return ExitError
+	}
+
+	var redactMask rune
+	if *redact {
+		runes := []rune(*redactChar)
+		if len(runes) != 1 {
+			logger.Error("Redact character must be a single character", "code", CodeInvalidOption, "char", *redactChar)
+			return ExitError
+		}
+		redactMask = runes[0]
+	}
+
+	escape := EscapeNone
+	if *escapeNonPrintable {
+		escape = EscapeNonPrintable
+		if *escapeNonASCII {
+			escape = EscapeNonASCII
+		}
+	}
+
+	if *contextAlign != ContextAlignChar && *contextAlign != ContextAlignWord {
+		logger.Error("Invalid context alignment", "code", CodeInvalidOption, "align", *contextAlign)
+		return ExitError
+	}
+	if *contextUnit != ContextUnitChar && *contextUnit != ContextUnitSentence {
+		logger.Error("Invalid context unit", "code", CodeInvalidOption, "unit", *contextUnit)
+		return ExitError
+	}
+
+	if !ValidEngine(*engine) {
+		logger.Error("Invalid engine", "code", CodeInvalidOption, "engine", *engine)
+		return ExitError
+	}
+
+	uniVersion, err := LookupUnicodeVersion(*unicodeVersion)
+	if err != nil {
+		logger.Error("Invalid unicode version", "code", CodeInvalidOption, "error", err)
+		return ExitError
+	}
+
+	if *normalize != "" {
+		if _, err := LookupNormalization(*normalize); err != nil {
+			logger.Error("Invalid normalize option", "code", CodeInvalidOption, "error", err)
+			return ExitError
+		}
+	}
+
+	if *inputEnc != "" && *inputEnc != EncodingAuto {
+		if _, err := LookupEncoding(*inputEnc); err != nil {
+			logger.Error("Invalid input encoding", "code", CodeInvalidOption, "error", err)
+			return ExitError
+		}
+	}
+
+	var compareEncoding encoding.Encoding
+	if *compareEnc != "" {
+		enc, err := LookupEncoding(*compareEnc)
+		if err != nil {
+			logger.Error("Invalid compare encoding", "code", CodeInvalidOption, "error", err)
+			return ExitError
+		}
+		compareEncoding = enc
+	}
+
+	outputOpts := OutputOptions{Format: *format, Layout: DefaultLayout, GroupBy: *groupBy, CoOccurrence: *cooccur, CountOnly: *countOnly}
+	// 署名はファイルに保存した機械可読レポートに対してのみ行う
+	if *signKey != "" && (*outputFile == "" || !outputOpts.MachineReadable()) {
+		logger.Error("-sign requires -o and -format json or jsonl", "code", CodeUsage)
+		return ExitError
+	}
+	// 行単位でない照合では行番号より文字位置を示す
+	if *noLineMode {
+		outputOpts.Layout.SnippetPrefix = DefaultWholeTextPrefix
+	}
+	if *layoutFile != "" {
+		lf, err := ctx.FileReader(*layoutFile)
+		if err != nil {
+			logger.Error("Failed to open layout file", "code", CodeOpenFailed, "path", *layoutFile, "error", err)
+			return ExitError
+		}
+		outputOpts.Layout, err = LoadLayout(lf, outputOpts.Layout)
+		lf.Close()
+		if err != nil {
+			logger.Error("Invalid layout file", "code", CodeInvalidConfig, "path", *layoutFile, "error", err)
+			return ExitError
+		}
+	}
+	// 明示されたフラグはレイアウトファイルより優先する
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "separator":
+			outputOpts.Layout.Separator = *separator
+		case "header":
+			outputOpts.Layout.Header = *header
+		case "snippet-prefix":
+			outputOpts.Layout.SnippetPrefix = *snippetPrefixFlag
+		}
+	})
+	// -o のファイルにはエスケープシーケンスを書き込まないよう、auto では標準出力のみの場合に限る
+	color, err := ColorEnabled(*colorMode, ctx.StdoutIsTTY, *outputFile != "", ctx.getenv("NO_COLOR") != "")
+	if err != nil {
+		logger.Error("Invalid color mode", "code", CodeInvalidOption, "error", err)
+		return ExitError
+	}
+	outputOpts.Layout.Color = color && *format == FormatText
+
+	if *maxSnippets < 0 {
+		logger.Error("-max-snippets must not be negative", "code", CodeInvalidOption, "value", *maxSnippets)
+		return ExitError
+	}
+	if *maxSnippetBytes < 0 {
+		logger.Error("-max-snippet-bytes must not be negative", "code", CodeInvalidOption, "value", *maxSnippetBytes)
+		return ExitError
+	}
+	if *maxTextBytes < 0 {
+		logger.Error("-max-text-bytes must not be negative", "code", CodeInvalidOption, "value", *maxTextBytes)
+		return ExitError
+	}
+
+	switch *anchor {
+	case "", AnchorStart, AnchorEnd, AnchorFull:
+	default:
+		logger.Error("Invalid anchor", "code", CodeInvalidOption, "anchor", *anchor)
+		return ExitError
+	}
+
+	// 行をつなげて照合する場合、行単位の処理は行えない
+	if *noLineMode {
+		for _, f := range []struct {
+			name string
+			set  bool
+		}{
+			{"follow", *follow}, {"state", *stateFile != ""}, {"passthrough", *passthrough != ""}, {"lines-out", *linesOut != ""},
+			{"sample", *sample != ""}, {"fold-duplicates", *foldDuplicates}, {"combine-lines", *combineLines}, {"cooccurrence", *cooccur},
+		} {
+			if f.set {
+				logger.Error("-no-line-mode cannot be combined with -"+f.name, "code", CodeUsage, "flag", f.name)
+				return ExitError
+			}
+		}
+	}
+
+	remainingArgs := fs.Args()
+	// 入力の指定がなく標準入力がパイプであれば、標準入力を検索する(標準入力からクエリを読む場合を除く)
+	if len(remainingArgs) == 0 && ctx.StdinIsPipe && !*coordinator && !*queriesStdin {
+		remainingArgs = []string{StdinPath}
+	}
+	// クエリは実行ファイル名・設定ファイル・-f・-q の順に合わせる
+	var fileQueries []string
+	if !*replaceQueries {
+		fileQueries = fileConfig.Queries
+	}
+	if *queryFile != "" {
+		qf, err := ctx.FileReader(*queryFile)
+		if err != nil {
+			logger.Error("Failed to open query file", "code", CodeOpenFailed, "path", *queryFile, "error", err)
+			return ExitError
+		}
+		listed, err := LoadQueryFile(qf)
+		qf.Close()
+		fileQueries = append(fileQueries, listed...)
+		if err != nil {
+			logger.Error("Invalid query file", "code", CodeInvalidQuery, "path", *queryFile, "error", err)
+			return ExitError
+		}
+	}
+	if *queriesStdin {
+		listed, err := readStdinQueries(ctx, remainingArgs, *queriesEnc)
+		if err != nil {
+			logger.Error("Failed to read queries from stdin", "code", CodeReadFailed, "error", err)
+			return ExitError
+		}
+		fileQueries = append(fileQueries, listed...)
+	} else if *queriesEnc != "" {
+		logger.Error("-queries-enc requires -queries-stdin", "code", CodeUsage)
+		return ExitError
+	}
+	fileQueries = append(fileQueries, flagQueries...)
+	if *replaceQueries && *queryFile == "" && !*queriesStdin && len(flagQueries) == 0 {
+		logger.Error("-replace-queries requires -f, -q or -queries-stdin", "code", CodeUsage)
+		return ExitError
+	}
+	config, err := ParseArgsWithQueries(remainingArgs, ctx.ExecPath, fileQueries, *replaceQueries)
+	if err != nil {
+		logger.Error("Configuration error", "code", CodeInvalidConfig, "error", err)
+		return ExitError
+	}
+
+	var rules []QueryRule
+	if *rulesFile != "" {
+		rf, err := ctx.FileReader(*rulesFile)
+		if err != nil {
+			logger.Error("Failed to open rules file", "code", CodeOpenFailed, "path", *rulesFile, "error", err)
+			return ExitError
+		}
+		rules, err = LoadRules(rf)
+		rf.Close()
+		if err != nil {
+			logger.Error("Invalid rules file", "code", CodeInvalidConfig, "path", *rulesFile, "error", err)
+			return ExitError
+		}
+		config.Queries = MergeRuleQueries(config.Queries, rules)
+	}
+
+	if err := ValidateQueries(config.Queries, *extended, *anchor); err != nil {
+		logger.Error("Invalid query", "code", CodeInvalidQuery, "error", err)
+		return ExitError
+	}
+
+	var variants VariantTable
+	if *expandVariants || *variantsFile != "" {
+		variants = DefaultVariants()
+		if *variantsFile != "" {
+			vf, err := ctx.FileReader(*variantsFile)
+			if err != nil {
+				logger.Error("Failed to open variants file", "code", CodeOpenFailed, "path", *variantsFile, "error", err)
+				return ExitError
+			}
+			err = LoadVariants(vf, variants)
+			vf.Close()
+			if err != nil {
+				logger.Error("Invalid variants file", "code", CodeInvalidConfig, "path", *variantsFile, "error", err)
+				return ExitError
+			}
+		}
+	}
+
+	// フラグで指定された値をConfigに適用
+	config.ContextSize = *contextSize
+	config.Options = SearchOptions{
+		ContextSize:     config.ContextSize,
+		FoldDuplicates:  *foldDuplicates,
+		SampleEvery:     sampleEvery,
+		RedactMask:      redactMask,
+		Escape:          escape,
+		KeepRaw:         *hexSnippets,
+		ContextAlign:    *contextAlign,
+		ContextUnit:     *contextUnit,
+		SentenceMax:     *sentenceMax,
+		Variants:        variants,
+		Romaji:          *romaji,
+		Anchor:          *anchor,
+		CompareEncoding: compareEncoding,
+		Filters:         filters,
+		CoOccurrence:    *cooccur,
+		InputEncoding:   *inputEnc,
+		CombineLines:    *combineLines,
+		SuppressMarker:  *suppressMarker,
+		IgnoreCase:      *ignoreCase,
+		FoldWidth:       *foldWidth,
+		FoldKana:        *foldKana,
+		IgnoreIVS:       *ignoreIVS,
+		Normalize:       strings.ToLower(*normalize),
+		Engine:          *engine,
+		UnicodeVersion:  uniVersion,
+		Extended:        *extended,
+		WholeText:       *noLineMode,
+		MaxTextBytes:    *maxTextBytes,
+		CountOnly:       *countOnly,
+		MaxSnippetBytes: *maxSnippetBytes,
+		MaxSnippets:     *maxSnippets,
+		AllowGrowth:     *allowGrowth,
+		Trace:           root,
+	}
+	// -max-snippets 0 は無制限(SearchOptions では負の値)
+	if *maxSnippets == 0 {
+		config.Options.MaxSnippets = -1
+	}
+
+	config.Walk = WalkOptions{Include: includePatterns, Exclude: excludePatterns, Jobs: *jobs}
+	if ctx.FileStat != nil && ctx.DirFS != nil && config.InputFilePath != StdinPath {
+		if info, err := ctx.FileStat(config.InputFilePath); err == nil && info.IsDir() {
+			config.InputIsDir = true
+		}
+	}
+	// 複数ファイルの結果では各スニペットに出典ファイルが記録される
+	if !config.MultiInput() {
+		outputOpts.InputPath = config.InputFilePath
+	}
+	if *absPaths || *pathRoot != "" {
+		outputOpts.Paths, err = NewPathResolver(*pathRoot)
+		if err != nil {
+			logger.Error("Invalid root directory", "code", CodeInvalidOption, "path", *pathRoot, "error", err)
+			return ExitError
+		}
+	}
+
+	// パスワードは暗号化エントリに出会った時に一度だけ問い合わせる
+	config.ZipPassword = zipPasswordPrompt(ctx, *zipPassword)
+
+	// 分散走査ではワーカーが行を読むため、元の行を書き出す出力は行えない
+	if *coordinator && (*passthrough != "" || *linesOut != "") {
+		logger.Error("-coordinator cannot be used with -passthrough or -lines-out, which write the original lines", "code", CodeUsage)
+		return ExitError
+	}
+
+	// 匿名化はレポートにのみ適用するため、元の行をそのまま書き出す出力とは併用できない
+	if *anonymize && (*follow || *passthrough != "" || *linesOut != "") {
+		logger.Error("-anonymize cannot be used with -follow, -passthrough or -lines-out, which write the original lines", "code", CodeUsage)
+		return ExitError
+	}
+
+	// 追従モードは集計レポートを出さず、ヒットを逐次出力し続ける
+	if *follow {
+		if *snapshot {
+			logger.Error("-snapshot cannot be used with -follow, which reads the file as it grows", "code", CodeUsage)
+			return ExitError
+		}
+		if config.MultiInput() || isZipPath(config.InputFilePath) || isGzipPath(config.InputFilePath) {
+			logger.Error("Follow mode requires a single uncompressed file, not a directory or archive", "code", CodeUsage, "path", config.InputFilePath)
+			return ExitError
+		}
+		if *followOverflow != OverflowDrop && *followOverflow != OverflowBlock {
+			logger.Error("Invalid follow overflow mode", "code", CodeInvalidOption, "mode", *followOverflow)
+			return ExitError
+		}
+		f, err := openInput(ctx, config.InputFilePath)
+		if err != nil {
+			logger.Error("Failed to open input file", "code", CodeOpenFailed, "path", config.InputFilePath, "error", err)
+			return ExitError
+		}
+		defer f.Close()
+
+		sigCtx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+		fopts := FollowOptions{Interval: DefaultFollowInterval, Rate: *followRate, Buffer: *followBuffer, Overflow: *followOverflow}
+		err = Follow(sigCtx, f, config.Queries, config.Options, fopts, func(ev FollowEvent) error {
+			if ev.Dropped > 0 {
+				logger.Warn("Follow buffer overflowed", "dropped", ev.Dropped)
+			}
+			return WriteFollowEvent(ctx.Stdout, ev)
+		})
+		if err != nil {
+			logger.Error("Follow failed", "code", CodeSearchFailed, "path", config.InputFilePath, "error", err)
+			return ExitError
+		}
+		return 0
+	}
+
+	// パススルー時は標準出力を行の書き出しに使うため、レポートは -o のみに出力する
+	reportStdout := ctx.Stdout
+	var paged *bytes.Buffer
+	if *passthrough != "" {
+		config.Options.PassThrough = ctx.Stdout
+		config.Options.PassMatched = *passthrough == "matched"
+		reportStdout = io.Discard
+	} else if ctx.Pager != nil && !*noPager {
+		// ページャに渡すため標準出力向けのレポートを一旦溜める
+		paged = new(bytes.Buffer)
+		reportStdout = paged
+	}
+
+	if *linesOut != "" {
+		names, err := QueryFileNames(config.Queries, *queryFileNames)
+		if err != nil {
+			logger.Error("Invalid query file name mode", "code", CodeInvalidOption, "error", err)
+			return ExitError
+		}
+		config.Options.LineSinks = make(map[string]io.Writer, len(config.Queries))
+		for _, q := range config.Queries {
+			path := LinesOutPath(*linesOut, names[q])
+			lf, err := ctx.FileCreator(path)
+			if err != nil {
+				logger.Error("Failed to create lines output file", "code", CodeWriteFailed, "path", path, "error", err)
+				return ExitError
+			}
+			defer lf.Close()
+			config.Options.LineSinks[q] = lf
+		}
+	}
+
+	var outWriter io.Writer
+	var signed *bytes.Buffer // 署名対象としてファイルに書き出した内容を保持する
+
+	var reportFile *atomicFile
+	if *outputFile != "" {
+		f, err := createAtomic(ctx, *outputFile)
+		if err != nil {
+			logger.Error("Failed to create output file", "code", CodeWriteFailed, "path", *outputFile, "error", err)
+			return ExitError
+		}
+		defer f.Abort()
+		reportFile = f
+		if *signKey != "" {
+			signed = new(bytes.Buffer)
+			outWriter = io.MultiWriter(reportStdout, f, signed)
+		} else {
+			outWriter = io.MultiWriter(reportStdout, f)
+		}
+	} else {
+		outWriter = reportStdout
+	}
+
+	// -output の出力先は走査前に作成し、書き込めない場合は走査を始めない
+	extraFiles := make([]*atomicFile, len(extraOutputs))
+	for i, spec := range extraOutputs {
+		f, err := createAtomic(ctx, spec.Path)
+		if err != nil {
+			logger.Error("Failed to create output file", "code", CodeWriteFailed, "path", spec.Path, "error", err)
+			return ExitError
+		}
+		defer f.Abort()
+		extraFiles[i] = f
+	}
+
+	// 書き込み中のファイルも一貫した内容で検索できるよう、入力は一時的なコピーから読む
+	if *snapshot {
+		snaps := newInputSnapshots(ctx.FileReader, config.InputPaths)
+		defer snaps.Remove()
+		ctx.FileReader = snaps.Open
+		if ctx.DirFS != nil {
+			ctx.DirFS = snapshotDirFS(ctx.DirFS)
+		}
+	}
+
+	// 照合が指定されていれば走査前に入力全体のハッシュ値を確認する
+	if *verifySHA256 != "" {
+		if config.MultiInput() || config.InputFilePath == StdinPath {
+			logger.Error("-verify-sha256 requires a single file, not a directory or stdin", "code", CodeUsage, "path", config.InputFilePath)
+			return ExitError
+		}
+		expected, err := ExpectedSHA256(*verifySHA256, ctx.FileReader)
+		if err != nil {
+			logger.Error("Invalid checksum", "code", CodeInvalidOption, "error", err)
+			return ExitError
+		}
+		vf, err := ctx.FileReader(config.InputFilePath)
+		if err != nil {
+			logger.Error("Failed to open input file", "code", CodeOpenFailed, "path", config.InputFilePath, "error", err)
+			return ExitError
+		}
+		outputOpts.File, err = VerifySHA256(vf, config.InputFilePath, expected)
+		vf.Close()
+		if err != nil {
+			logger.Error("Input verification failed", "code", CodeVerifyFailed, "path", config.InputFilePath, "error", err)
+			return ExitError
+		}
+	}
+
+	// 増分走査は前回の続きから読むため、ファイル単位で途中から読み直せる(圧縮されていない)入力に限る
+	var state *ScanState
+	if *stateFile != "" {
+		if config.MultiInput() || config.InputFilePath == StdinPath || isZipPath(config.InputFilePath) || isGzipPath(config.InputFilePath) || *coordinator {
+			logger.Error("-state requires a single plain file, not a directory, stdin, compressed file or -coordinator", "code", CodeUsage, "path", config.InputFilePath)
+			return ExitError
+		}
+		state, err = LoadScanState(ctx.FileReader, *stateFile)
+		if err != nil {
+			logger.Error("Failed to load state file", "code", CodeReadFailed, "path", *stateFile, "error", err)
+			return ExitError
+		}
+	}
+
+	// キャッシュはファイルのハッシュ値をキーとするため、走査前にハッシュを計算する。
+	// パススルーや行の書き出しは走査時の副作用なのでキャッシュしない。
+	var cache *ResultCache
+	var cacheKey string
+	var results map[string]*SearchResult
+	if *coordinator {
+		if *workers == "" {
+			logger.Error("Coordinator mode requires -workers", "code", CodeUsage)
+			return ExitError
+		}
+		token := ctx.getenv(WorkerTokenEnv)
+		if token == "" {
+			logger.Error("Coordinator mode requires the workers' shared token in "+WorkerTokenEnv, "code", CodeUsage)
+			return ExitError
+		}
+		paths := remainingArgs
+		client := &http.Client{Timeout: *workerTimeout}
+		results, err = Coordinate(client, token, strings.Split(*workers, ","), paths, config.Queries, NewRemoteOptions(config.Options))
+		if err != nil {
+			logger.Error("Distributed scan failed", "code", CodeRemoteFailed, "error", err)
+			return ExitError
+		}
+	} else if *cacheDir != "" && state == nil && !config.MultiInput() && config.InputFilePath != StdinPath && config.Options.PassThrough == nil && config.Options.LineSinks == nil {
+		if outputOpts.File == nil {
+			hf, err := ctx.FileReader(config.InputFilePath)
+			if err != nil {
+				logger.Error("Failed to open input file", "code", CodeOpenFailed, "path", config.InputFilePath, "error", err)
+				return ExitError
+			}
+			outputOpts.File, err = HashInput(hf, config.InputFilePath)
+			hf.Close()
+			if err != nil {
+				logger.Error("Failed to hash input file", "code", CodeReadFailed, "path", config.InputFilePath, "error", err)
+				return ExitError
+			}
+		}
+		cache = &ResultCache{Dir: *cacheDir, Open: ctx.FileReader, Create: ctx.FileCreator, Rename: ctx.Rename, Remove: ctx.Remove}
+		cacheKey = CacheKey(outputOpts.File.SHA256, config.Queries, config.Options)
+		if cached, ok := cache.Load(cacheKey); ok {
+			logger.Info("Using cached results", "key", cacheKey)
+			results = cached
+		}
+	}
+
+	if results == nil {
+		if state != nil {
+			results, err = scanIncremental(ctx, config, state)
+		} else {
+			results, err = scanInput(ctx, config, &outputOpts)
+		}
+		if err != nil {
+			logger.Error("Search failed", "code", CodeSearchFailed, "path", config.InputFilePath, "error", err)
+			return ExitError
+		}
+		if cache != nil {
+			if err := cache.Store(cacheKey, results); err != nil {
+				logger.Warn("Failed to store cache", "error", err)
+			}
+		}
+	}
+
+	if outputOpts.File != nil && outputOpts.File.Modified == nil && ctx.FileStat != nil {
+		if info, err := ctx.FileStat(config.InputFilePath); err == nil {
+			modified := info.ModTime()
+			outputOpts.File.Modified = &modified
+		}
+	}
+
+	if *fileOwner && ctx.FileStat != nil {
+		AnnotateOwnership(results, outputOpts.File, ctx.FileStat)
+	}
+
+	breached := ApplyRules(results, rules, *failOn)
+
+	// 匿名化は集計・判定の後、出力の直前に行う(キャッシュには元の結果を保存する)
+	if *anonymize {
+		results = AnonymizeResults(results, outputOpts.Paths)
+		outputOpts = AnonymizeOutput(outputOpts)
+	}
+
+	writeSpan := root.Start("write", stringAttr("objis.format", outputOpts.Format))
+	defer writeSpan.End()
+	if err := WriteFormatted(outWriter, results, config.Queries, outputOpts); err != nil {
+		writeSpan.SetError(err)
+		logger.Error("Failed to write results", "code", CodeWriteFailed, "error", err)
+		salvageCounts(logger, results, config.Queries)
+		return ExitWriteError
+	}
+	if reportFile != nil {
+		if err := reportFile.Commit(); err != nil {
+			writeSpan.SetError(err)
+			logger.Error("Failed to write output file", "code", CodeWriteFailed, "path", *outputFile, "error", err)
+			salvageCounts(logger, results, config.Queries)
+			return ExitWriteError
+		}
+	}
+	for i, spec := range extraOutputs {
+		opts := outputOpts
+		opts.Format = spec.Format
+		opts.Layout.Color = false
+		err := WriteFormatted(extraFiles[i], results, config.Queries, opts)
+		if err == nil {
+			err = extraFiles[i].Commit()
+		}
+		if err != nil {
+			writeSpan.SetError(err)
+			logger.Error("Failed to write output file", "code", CodeWriteFailed, "path", spec.Path, "error", err)
+			salvageCounts(logger, results, config.Queries)
+			return ExitWriteError
+		}
+	}
+	writeSpan.End()
+
+	// レポートを出力できた場合のみ走査位置を進める(失敗時は次回同じ範囲を再走査する)
+	if state != nil {
+		if err := state.Save(ctx, *stateFile); err != nil {
+			logger.Error("Failed to save state file", "code", CodeWriteFailed, "path", *stateFile, "error", err)
+			return ExitError
+		}
+	}
+
+	if signed != nil {
+		key, err := readFile(ctx, *signKey)
+		if err != nil {
+			logger.Error("Failed to read key", "code", CodeReadFailed, "path", *signKey, "error", err)
+			return ExitError
+		}
+		sig, err := SignReport(signed.Bytes(), key)
+		if err != nil {
+			logger.Error("Failed to sign report", "code", CodeError, "error", err)
+			return ExitError
+		}
+		sf, err := ctx.FileCreator(*outputFile + SignatureExt)
+		if err != nil {
+			logger.Error("Failed to create signature file", "code", CodeWriteFailed, "path", *outputFile+SignatureExt, "error", err)
+			return ExitError
+		}
+		_, err = io.WriteString(sf, sig)
+		if cerr := sf.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			logger.Error("Failed to write signature file", "code", CodeWriteFailed, "path", *outputFile+SignatureExt, "error", err)
+			return ExitError
+		}
+	}
+
+	if paged != nil {
+		if err := ctx.Pager(paged.Bytes()); err != nil {
+			logger.Error("Failed to write results", "code", CodeWriteFailed, "error", err)
+			salvageCounts(logger, results, config.Queries)
+			return ExitWriteError
+		}
+	}
+
+	// 閾値超過はエラー(2)と区別できる終了コードで通知する
+	if breached {
+		return ExitRuleViolation
+	}
+	if !anyMatched(results) {
+		return ExitNoMatch
+	}
+	return ExitMatch
+}
+
+// anyMatched はいずれかの検索語がヒットしたかを返します
+func anyMatched(results map[string]*SearchResult) bool {
+	for _, res := range results {
+		if res.Count > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// salvageCounts はレポートを書き込めなかった場合(ディスクの空き不足や権限の不足)に、
+// 長時間の走査の結果を失わないよう、検索語ごとの該当数をログとして標準エラーに記録します
+func salvageCounts(logger *slog.Logger, results map[string]*SearchResult, queries []string) {
+	for _, q := range queries {
+		if res, ok := results[q]; ok {
+			logger.Warn("Salvaged count", "query", q, "count", res.Count)
+		}
+	}
+}
+
+// legacyExitCode は終了コードを -legacy-exit の体系(成功0・エラー1・閾値超過3)に読み替えます
+func legacyExitCode(code int) int {
+	switch code {
+	case ExitNoMatch:
+		return 0
+	case ExitError:
+		return 1
+	}
+	return code
+}
+
+// scanInput は入力ファイルを開いて検索します。
+// 機械可読出力でファイル情報が未取得の場合は、走査と同時にハッシュを計算して outputOpts に設定します。
+// ディレクトリや複数のファイルの場合は -jobs 個ずつ並行して検索します(ファイル情報は出力しない)。
+func scanInput(ctx AppContext, config *Config, outputOpts *OutputOptions) (map[string]*SearchResult, error) {
+	if config.MultiInput() {
+		return SearchInputs(ctx, config.InputPaths, config.Queries, config.Options, config.Walk, config.ZipPassword)
+	}
+
+	f, err := openInput(ctx, config.InputFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open input file: %w", err)
+	}
+	defer f.Close()
+
+	// ZIPアーカイブのファイルは位置を指定して読むため包まず、ファイル情報は検索の後に先頭から読み直して計算する
+	var input io.Reader = f
+	seeker, rewind := f.(io.Seeker)
+	rewind = rewind && isZipPath(config.InputFilePath)
+	var guard *sizeGuard
+	var meta *metaReader
+	if !rewind {
+		guard = newSizeGuard(f, config.Options.AllowGrowth)
+		if guard != nil {
+			input = guard
+		}
+		if outputOpts.MachineReadable() && outputOpts.File == nil {
+			meta = newMetaReader(input)
+			input = meta
+		}
+	}
+
+	// 検索実行時にコンテキストサイズを渡す
+	results, err := searchFile(input, config.InputFilePath, config.Queries, config.Options, config.ZipPassword)
+	if err != nil {
+		return nil, err
+	}
+	guard.Annotate(results, config.InputFilePath)
+
+	if rewind && outputOpts.MachineReadable() && outputOpts.File == nil {
+		if _, err := seeker.Seek(0, io.SeekStart); err != nil {
+			return nil, fmt.Errorf("failed to read input file: %w", err)
+		}
+		meta = newMetaReader(f)
+		if _, err := io.Copy(io.Discard, meta); err != nil {
+			return nil, fmt.Errorf("failed to read input file: %w", err)
+		}
+	}
+	if meta != nil {
+		outputOpts.File = meta.Meta(config.InputFilePath, nil)
+	}
+	return results, nil
+}
+
+// decodeInput は入力を inputEncoding から UTF-8 に変換する Reader と、実際に使った文字コードを返します。
+// EncodingAuto なら先頭を見て判定し、空でも UTF-16 のBOMがあれば UTF-16 として読みます(Windowsのツールの出力に多い)。
+func decodeInput(r io.Reader, inputEncoding string) (io.Reader, string, error) {
+	if inputEncoding == EncodingAuto || inputEncoding == "" {
+		br := bufio.NewReaderSize(r, DetectSampleSize)
+		inputEncoding = sniffEncoding(br, inputEncoding)
+		r = br
+	}
+	if inputEncoding != "" && inputEncoding != encodingUTF8 {
+		enc, err := LookupEncoding(inputEncoding)
+		if err != nil {
+			return nil, "", err
+		}
+		r = transform.NewReader(r, enc.NewDecoder())
+	}
+	return r, inputEncoding, nil
+}
+
+// sniffEncoding は inputEncoding が EncodingAuto か空の場合に、入力の先頭を見て実際に使う文字コードを返します
+func sniffEncoding(br *bufio.Reader, inputEncoding string) string {
+	switch inputEncoding {
+	case EncodingAuto:
+		sample, _ := br.Peek(DetectSampleSize)
+		return DetectEncoding(sample)
+	case "":
+		bom, _ := br.Peek(2)
+		return DetectUTF16BOM(bom)
+	}
+	return inputEncoding
+}
+
+// zipPasswordPrompt は暗号化エントリに出会った時に一度だけパスワードを返す関数を返します。
+// password が空なら標準入力から問い合わせます。
+func zipPasswordPrompt(ctx AppContext, password string) func() (string, error) {
+	return sync.OnceValues(func() (string, error) {
+		if password != "" {
+			return password, nil
+		}
+		if ctx.Stdin == nil {
+			return "", errors.New("entry is encrypted; specify -zip-password")
+		}
+		fmt.Fprint(ctx.Stderr, "ZIP password: ")
+		// 端末から入力する場合はエコーせずに読む
+		if f, ok := ctx.Stdin.(interface{ Fd() uintptr }); ok && term.IsTerminal(int(f.Fd())) {
+			pw, err := term.ReadPassword(int(f.Fd()))
+			fmt.Fprintln(ctx.Stderr)
+			if err != nil {
+				return "", fmt.Errorf("failed to read zip password: %w", err)
+			}
+			return string(pw), nil
+		}
+		line, err := bufio.NewReader(ctx.Stdin).ReadString('\n')
+		if err != nil && line == "" {
+			return "", fmt.Errorf("failed to read zip password: %w", err)
+		}
+		return strings.TrimRight(line, "\r\n"), nil
+	})
+}
+
+// openInput は入力ファイルを開きます。パスが StdinPath の場合は標準入力を返します。
+func openInput(ctx AppContext, path string) (io.ReadCloser, error) {
+	if path != StdinPath {
+		return ctx.FileReader(path)
+	}
+	if ctx.Stdin == nil {
+		return nil, errors.New("stdin is not available")
+	}
+	return io.NopCloser(ctx.Stdin), nil
+}
+
+// readFile はファイル全体を読み込みます
+func readFile(ctx AppContext, path string) ([]byte, error) {
+	f, err := ctx.FileReader(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return io.ReadAll(f)
+}
+
+// stdinIsPipe は標準入力が端末以外(パイプやリダイレクト)につながっているかを返します
+func stdinIsPipe() bool {
+	info, err := os.Stdin.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice == 0
+}
+
+func main() {
+	exe, err := os.Executable()
+	if err != nil {
+		exe = os.Args[0]
+	}
+
+	ctx := AppContext{
+		Args:     os.Args,
+		ExecPath: exe,
+		Stdout:   os.Stdout,
+		Stderr:   os.Stderr,
+		Stdin:    os.Stdin,
+		FileReader: func(path string) (io.ReadCloser, error) {
+			return openShared(path)
+		},
+		FileCreator: func(path string) (io.WriteCloser, error) {
+			return os.Create(path)
+		},
+		Rename:      os.Rename,
+		Remove:      os.Remove,
+		Pager:       NewTerminalPager(os.Stdout),
+		FileStat:    os.Stat,
+		DirFS:       sharedDirFS,
+		StdinIsPipe: stdinIsPipe(),
+		StdoutIsTTY: isTerminal(os.Stdout),
+		Getenv:      os.Getenv,
+	}
+
+	os.Exit(Run(ctx))
+}
@@ -1,124 +1,633 @@
-package main
-
-import (
-	"bytes"
-	"io"
-	"strings"
-	"testing"
-)
-
-// TestParseArgs は実行ファイル名パースの正常系・異常系を網羅します
-func TestParseArgs(t *testing.T) {
-	tests := []struct {
-		name        string
-		args        []string
-		execPath    string
-		wantQueries []string
-		wantErr     bool
-	}{
-		{
-			name:        "Normal_TwoQueries",
-			args:        []string{"file.txt"},
-			execPath:    "/bin/grep_error_warn",
-			wantQueries: []string{"error", "warn"},
-			wantErr:     false,
-		},
-		{
-			name:     "Error_NoUnderscore",
-			args:     []string{"file.txt"},
-			execPath: "grep",
-			wantErr:  true,
-		},
-		{
-			name:     "Error_NoInputFile",
-			args:     []string{},
-			execPath: "grep_error",
-			wantErr:  true,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			got, err := ParseArgs(tt.args, tt.execPath)
-			if (err != nil) != tt.wantErr {
-				t.Errorf("ParseArgs() error = %v, wantErr %v", err, tt.wantErr)
-				return
-			}
-			if !tt.wantErr {
-				if len(got.Queries) != len(tt.wantQueries) {
-					t.Errorf("Query count mismatch. got %v, want %v", got.Queries, tt.wantQueries)
-				}
-				// デフォルト値が入っていることの確認
-				if got.ContextSize != DefaultContextSize {
-					t.Errorf("ContextSize default mismatch. got %d, want %d", got.ContextSize, DefaultContextSize)
-				}
-			}
-		})
-	}
-}
-
-// TestSearchStream_ContextSize は指定された文字数で切り出されるか確認します
-func TestSearchStream_ContextSize(t *testing.T) {
-	// "TARGET" の前後に数字を配置
-	content := "12345678901234567890TARGET12345678901234567890"
-	//          ^^^^^^^^^^^^^^^^^^^^      ^^^^^^^^^^^^^^^^^^^^
-	//          20 chars                  20 chars
-
-	r := strings.NewReader(content)
-
-	// ケース1: デフォルトの20文字
-	results, err := SearchStream(r, []string{"TARGET"}, 20)
-	if err != nil {
-		t.Fatalf("Unexpected error: %v", err)
-	}
-
-	want20 := "12345678901234567890TARGET12345678901234567890"
-	if results["TARGET"].Snippets[0] != want20 {
-		t.Errorf("Context(20) mismatch.\n got:  %q\n want: %q", results["TARGET"].Snippets[0], want20)
-	}
-
-	// ケース2: 5文字指定（Readerをリセット）
-	r.Reset(content)
-	results, err = SearchStream(r, []string{"TARGET"}, 5)
-	if err != nil {
-		t.Fatalf("Unexpected error: %v", err)
-	}
-
-	want5 := "67890TARGET12345"
-	if results["TARGET"].Snippets[0] != want5 {
-		t.Errorf("Context(5) mismatch.\n got:  %q\n want: %q", results["TARGET"].Snippets[0], want5)
-	}
-}
-
-// TestRun_Integration_FlagCheck は -n フラグの動作を確認します
-func TestRun_Integration_FlagCheck(t *testing.T) {
-	mockStdout := new(bytes.Buffer)
-	mockReader := func(_ string) (io.ReadCloser, error) {
-		return io.NopCloser(strings.NewReader("PRE_TEXT_TARGET_POST_TEXT")), nil
-	}
-
-	// -n 4 を指定して実行
-	ctx := AppContext{
-		Args:        []string{"app", "-n", "4", "dummy.log"}, // Args[0]無視, -n 4 指定
-		ExecPath:    "app_TARGET",
-		Stdout:      mockStdout,
-		Stderr:      io.Discard,
-		FileReader:  mockReader,
-		FileCreator: func(_ string) (io.WriteCloser, error) { return nil, nil },
-	}
-
-	if code := Run(ctx); code != 0 {
-		t.Errorf("Run() exit code = %d", code)
-	}
-
-	output := mockStdout.String()
-
-	// 修正済み: 入力 "PRE_TEXT_TARGET_POST_TEXT" に対する前後4文字の正しい期待値
-	// 前4文字: "EXT_" (E, X, T, _)
-	// 後4文字: "_POS" (_, P, O, S)
-	want := "EXT_TARGET_POS"
-
-	if !strings.Contains(output, want) {
-		t.Errorf("Output should contain snippet with 4 chars context.\n Output: %s\n Want partial: %s", output, want)
-	}
-}
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/encoding/unicode"
+)
+
+// TestParseArgs は実行ファイル名パースの正常系・異常系を網羅します
+func TestParseArgs(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		execPath    string
+		wantQueries []string
+		wantErr     bool
+	}{
+		{
+			name:        "Normal_TwoQueries",
+			args:        []string{"file.txt"},
+			execPath:    "/bin/grep_error_warn",
+			wantQueries: []string{"error", "warn"},
+			wantErr:     false,
+		},
+		{
+			name:        "Normal_LogLevelPreset",
+			args:        []string{"file.txt"},
+			execPath:    "/bin/grep_@loglevel_ERROR_gaiji",
+			wantQueries: []string{"FATAL", "ERROR", "WARN", "INFO", "gaiji"},
+			wantErr:     false,
+		},
+		{
+			name:     "Error_NoUnderscore",
+			args:     []string{"file.txt"},
+			execPath: "grep",
+			wantErr:  true,
+		},
+		{
+			name:     "Error_NoInputFile",
+			args:     []string{},
+			execPath: "grep_error",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseArgs(tt.args, tt.execPath)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseArgs() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr {
+				if len(got.Queries) != len(tt.wantQueries) {
+					t.Errorf("Query count mismatch. got %v, want %v", got.Queries, tt.wantQueries)
+				}
+				// デフォルト値が入っていることの確認
+				if got.ContextSize != DefaultContextSize {
+					t.Errorf("ContextSize default mismatch. got %d, want %d", got.ContextSize, DefaultContextSize)
+				}
+			}
+		})
+	}
+}
+
+// TestSearchStream_ContextSize は指定された文字数で切り出されるか確認します
+func TestSearchStream_ContextSize(t *testing.T) {
+	// "TARGET" の前後に数字を配置
+	content := "12345678901234567890TARGET12345678901234567890"
+	//          ^^^^^^^^^^^^^^^^^^^^      ^^^^^^^^^^^^^^^^^^^^
+	//          20 chars                  20 chars
+
+	r := strings.NewReader(content)
+
+	// ケース1: デフォルトの20文字
+	results, err := SearchStream(r, []string{"TARGET"}, 20)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	want20 := "12345678901234567890TARGET12345678901234567890"
+	if results["TARGET"].Snippets[0] != want20 {
+		t.Errorf("Context(20) mismatch.\n got:  %q\n want: %q", results["TARGET"].Snippets[0], want20)
+	}
+
+	// ケース2: 5文字指定（Readerをリセット）
+	r.Reset(content)
+	results, err = SearchStream(r, []string{"TARGET"}, 5)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	want5 := "67890TARGET12345"
+	if results["TARGET"].Snippets[0] != want5 {
+		t.Errorf("Context(5) mismatch.\n got:  %q\n want: %q", results["TARGET"].Snippets[0], want5)
+	}
+}
+
+// TestRun_Integration_FlagCheck は -n フラグの動作を確認します
+func TestRun_Integration_FlagCheck(t *testing.T) {
+	mockStdout := new(bytes.Buffer)
+	mockReader := func(_ string) (io.ReadCloser, error) {
+		return io.NopCloser(strings.NewReader("PRE_TEXT_TARGET_POST_TEXT")), nil
+	}
+
+	// -n 4 を指定して実行
+	ctx := AppContext{
+		Args:        []string{"app", "-n", "4", "dummy.log"}, // Args[0]無視, -n 4 指定
+		ExecPath:    "app_TARGET",
+		Stdout:      mockStdout,
+		Stderr:      io.Discard,
+		FileReader:  mockReader,
+		FileCreator: func(_ string) (io.WriteCloser, error) { return nil, nil },
+	}
+
+	if code := Run(ctx); code != 0 {
+		t.Errorf("Run() exit code = %d", code)
+	}
+
+	output := mockStdout.String()
+
+	// 修正済み: 入力 "PRE_TEXT_TARGET_POST_TEXT" に対する前後4文字の正しい期待値
+	// 前4文字: "EXT_" (E, X, T, _)
+	// 後4文字: "_POS" (_, P, O, S)
+	want := "EXT_TARGET_POS"
+
+	if !strings.Contains(output, want) {
+		t.Errorf("Output should contain snippet with 4 chars context.\n Output: %s\n Want partial: %s", output, want)
+	}
+}
+
+// TestRun_Stdin は "-" の指定、またはパイプからの入力で標準入力が検索されるか確認します
+func TestRun_Stdin(t *testing.T) {
+	run := func(args []string, pipe bool) (int, string) {
+		stdout := new(bytes.Buffer)
+		code := Run(AppContext{
+			Args:        append([]string{"app"}, args...),
+			ExecPath:    "app_ERROR",
+			Stdout:      stdout,
+			Stderr:      io.Discard,
+			Stdin:       strings.NewReader("INFO a\nERROR b\n"),
+			StdinIsPipe: pipe,
+			FileReader: func(path string) (io.ReadCloser, error) {
+				t.Errorf("FileReader(%q) should not be called", path)
+				return nil, io.EOF
+			},
+		})
+		return code, stdout.String()
+	}
+
+	for _, args := range [][]string{{"-"}, {}} {
+		code, out := run(args, true)
+		if code != 0 || !strings.Contains(out, "[ERROR]\n該当数: 1\n") {
+			t.Errorf("Run(%q) = %d, output:\n%s", args, code, out)
+		}
+	}
+	// 端末からの入力であれば、従来どおり入力ファイルの指定を求める
+	if code, _ := run(nil, false); code != ExitError {
+		t.Errorf("Run() without input = %d, want %d", code, ExitError)
+	}
+}
+
+// TestSearchStream_FoldDuplicates は同一行が1つのスニペットに折り畳まれるか確認します
+func TestSearchStream_FoldDuplicates(t *testing.T) {
+	content := "ERROR disk full\nINFO ok\nERROR disk full\nERROR other\nERROR disk full\n"
+
+	results, err := SearchStreamWithOptions(strings.NewReader(content), []string{"ERROR"}, SearchOptions{
+		ContextSize:    20,
+		FoldDuplicates: true,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	res := results["ERROR"]
+	if res.Count != 4 {
+		t.Errorf("Count mismatch. got %d, want 4", res.Count)
+	}
+	if len(res.Snippets) != 2 {
+		t.Fatalf("Snippet count mismatch. got %d, want 2", len(res.Snippets))
+	}
+
+	out := new(bytes.Buffer)
+	WriteResults(out, results, []string{"ERROR"})
+	want := "line 1: ERROR disk full ×3 (lines 1, 3, 5)"
+	if !strings.Contains(out.String(), want) {
+		t.Errorf("Output should contain fold annotation.\n Output: %s\n Want partial: %s", out.String(), want)
+	}
+}
+
+// TestSearchStream_Sample はサンプリング時に外挿値が計算されるか確認します
+func TestSearchStream_Sample(t *testing.T) {
+	every, err := ParseSampleSpec("10%")
+	if err != nil || every != 10 {
+		t.Fatalf("ParseSampleSpec(10%%) = %d, %v; want 10", every, err)
+	}
+
+	var sb strings.Builder
+	for i := 0; i < 1000; i++ {
+		if i%2 == 0 {
+			sb.WriteString("hit\n")
+		} else {
+			sb.WriteString("miss\n")
+		}
+	}
+
+	// 奇数間隔で抽出し、偶数行・奇数行の両方が標本に入るようにする
+	results, err := SearchStreamWithOptions(strings.NewReader(sb.String()), []string{"hit"}, SearchOptions{SampleEvery: 5})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	est := results["hit"].Estimate
+	if est == nil {
+		t.Fatal("Estimate should be set when sampling")
+	}
+	if est.SampledLines != 200 || est.TotalLines != 1000 {
+		t.Errorf("Sample size mismatch. got %d/%d, want 200/1000", est.SampledLines, est.TotalLines)
+	}
+	if est.Count != 500 {
+		t.Errorf("Estimated count mismatch. got %d, want 500", est.Count)
+	}
+
+	// 密度は推定該当数に基づく: 500件 / 1000行 = 5000件/1万行
+	if _, per10k := results["hit"].Density(); per10k != 5000 {
+		t.Errorf("Density per 10k lines mismatch. got %.2f, want 5000", per10k)
+	}
+}
+
+// TestRun_Passthrough はヒット行のみが標準出力に流れ、レポートが -o に出力されるか確認します
+func TestRun_Passthrough(t *testing.T) {
+	mockStdout := new(bytes.Buffer)
+	report := new(bytes.Buffer)
+	ctx := AppContext{
+		Args:     []string{"app", "-passthrough", "matched", "-o", "report.txt", "in.txt"},
+		ExecPath: "app_髙",
+		Stdout:   mockStdout,
+		Stderr:   io.Discard,
+		FileReader: func(_ string) (io.ReadCloser, error) {
+			return io.NopCloser(strings.NewReader("高橋\n髙橋\n佐藤\n")), nil
+		},
+		FileCreator: func(_ string) (io.WriteCloser, error) {
+			return nopWriteCloser{report}, nil
+		},
+	}
+
+	if code := Run(ctx); code != 0 {
+		t.Fatalf("Run() exit code = %d", code)
+	}
+	if got := mockStdout.String(); got != "髙橋\n" {
+		t.Errorf("Passthrough output mismatch. got %q, want %q", got, "髙橋\n")
+	}
+	if !strings.Contains(report.String(), "該当数: 1") {
+		t.Errorf("Report should be written to -o.\n Report: %s", report.String())
+	}
+}
+
+// nopWriteCloser はテスト用に Close を持たない Writer を WriteCloser にします
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error { return nil }
+
+// TestRun_LinesOut はクエリごとのファイルにヒット行全体が書き出されるか確認します
+func TestRun_LinesOut(t *testing.T) {
+	created := make(map[string]*bytes.Buffer)
+	ctx := AppContext{
+		Args:     []string{"app", "-lines-out", "hits.txt", "in.txt"},
+		ExecPath: "app_ERROR_WARN",
+		Stdout:   io.Discard,
+		Stderr:   io.Discard,
+		FileReader: func(_ string) (io.ReadCloser, error) {
+			return io.NopCloser(strings.NewReader("ERROR a\nWARN b\nERROR WARN c\n")), nil
+		},
+		FileCreator: func(path string) (io.WriteCloser, error) {
+			created[path] = new(bytes.Buffer)
+			return nopWriteCloser{created[path]}, nil
+		},
+	}
+
+	if code := Run(ctx); code != 0 {
+		t.Fatalf("Run() exit code = %d", code)
+	}
+
+	want := map[string]string{
+		"hits_ERROR.txt": "ERROR a\nERROR WARN c\n",
+		"hits_WARN.txt":  "WARN b\nERROR WARN c\n",
+	}
+	for path, content := range want {
+		buf, ok := created[path]
+		if !ok {
+			t.Errorf("File %s was not created", path)
+			continue
+		}
+		if buf.String() != content {
+			t.Errorf("%s mismatch. got %q, want %q", path, buf.String(), content)
+		}
+	}
+}
+
+// TestSearchStream_Redact はスニペット中のヒット文字列が伏せ字になるか確認します
+func TestSearchStream_Redact(t *testing.T) {
+	content := "氏名:山田太郎 様 / 山田"
+	results, err := SearchStreamWithOptions(strings.NewReader(content), []string{"山田"}, SearchOptions{
+		ContextSize: 3,
+		RedactMask:  '■',
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	want := "氏名:■■太郎 "
+	if got := results["山田"].Snippets[0]; got != want {
+		t.Errorf("Redacted snippet mismatch.\n got:  %q\n want: %q", got, want)
+	}
+}
+
+// TestRun_Pager はページャが設定されている場合にレポートがページャへ渡されるか確認します
+func TestRun_Pager(t *testing.T) {
+	for _, tt := range []struct {
+		args      []string
+		wantPaged bool
+	}{
+		{[]string{"app", "in.txt"}, true},
+		{[]string{"app", "-no-pager", "in.txt"}, false},
+	} {
+		mockStdout := new(bytes.Buffer)
+		var paged []byte
+		ctx := AppContext{
+			Args:     tt.args,
+			ExecPath: "app_WARN",
+			Stdout:   mockStdout,
+			Stderr:   io.Discard,
+			FileReader: func(_ string) (io.ReadCloser, error) {
+				return io.NopCloser(strings.NewReader("WARN a\n")), nil
+			},
+			Pager: func(content []byte) error {
+				paged = content
+				return nil
+			},
+		}
+
+		if code := Run(ctx); code != 0 {
+			t.Fatalf("Run(%v) exit code = %d", tt.args, code)
+		}
+		if gotPaged := bytes.Contains(paged, []byte("[WARN]")); gotPaged != tt.wantPaged {
+			t.Errorf("Run(%v) paged = %v, want %v", tt.args, gotPaged, tt.wantPaged)
+		}
+		if gotDirect := strings.Contains(mockStdout.String(), "[WARN]"); gotDirect == tt.wantPaged {
+			t.Errorf("Run(%v) direct output = %v, want %v", tt.args, gotDirect, !tt.wantPaged)
+		}
+	}
+}
+
+// TestSearchStream_HexSnippets はスニペット範囲の元のバイト列が16進で出力されるか確認します
+func TestSearchStream_HexSnippets(t *testing.T) {
+	results, err := SearchStreamWithOptions(strings.NewReader("xx髙橋yy"), []string{"髙"}, SearchOptions{
+		ContextSize: 1,
+		KeepRaw:     true,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	info := results["髙"].Infos[0]
+	if want := "x髙橋"; string(info.Raw) != want {
+		t.Errorf("Raw bytes mismatch. got %q, want %q", info.Raw, want)
+	}
+
+	out := new(bytes.Buffer)
+	WriteResults(out, results, []string{"髙"})
+	if want := "    00000000  78 e9 ab 99 e6 a9 8b"; !strings.Contains(out.String(), want) {
+		t.Errorf("Output should contain hex dump.\n Output: %s\n Want partial: %s", out.String(), want)
+	}
+}
+
+// TestSearchStream_EncodedRawBytes は UTF-8 以外の入力で、16進表示・行頭のバイト位置・-lines-out・-passthrough に
+// 変換前の入力のバイト列が使われるか確認します
+func TestSearchStream_EncodedRawBytes(t *testing.T) {
+	sjis := func(s string) string {
+		b, err := japanese.ShiftJIS.NewEncoder().String(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return b
+	}
+	lines, pass := new(bytes.Buffer), new(bytes.Buffer)
+	results, err := SearchStreamWithOptions(strings.NewReader(sjis("あ\r\nxx高橋yy\n")), []string{"高"}, SearchOptions{
+		ContextSize:   1,
+		KeepRaw:       true,
+		InputEncoding: "sjis",
+		LineSinks:     map[string]io.Writer{"高": lines},
+		PassThrough:   pass,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	info := results["高"].Infos[0]
+	if want := "\x78\x8d\x82\x8b\xb4"; string(info.Raw) != want {
+		t.Errorf("Raw bytes = % x, want % x", info.Raw, want)
+	}
+	if info.Offset != 4 || results["高"].First.Offset != 4 {
+		t.Errorf("Offset = %d, want the file offset 4", info.Offset)
+	}
+	if want := sjis("xx高橋yy\n"); lines.String() != want {
+		t.Errorf("Lines out = % x, want % x", lines.Bytes(), want)
+	}
+	if want := sjis("あ\n"); pass.String() != want {
+		t.Errorf("Passthrough = % x, want % x", pass.Bytes(), want)
+	}
+	out := new(bytes.Buffer)
+	WriteResults(out, results, []string{"高"})
+	if want := "    00000000  78 8d 82 8b b4"; !strings.Contains(out.String(), want) {
+		t.Errorf("Output should contain hex dump of the Shift_JIS bytes.\n Output: %s\n Want partial: %s", out.String(), want)
+	}
+
+	// 切り詰めは文字の途中で切らず、目印も入力の文字コードで書く
+	lines.Reset()
+	_, err = SearchStreamWithOptions(strings.NewReader(sjis("高橋高橋\n")), []string{"高"}, SearchOptions{
+		InputEncoding:   "sjis",
+		LineSinks:       map[string]io.Writer{"高": lines},
+		MaxSnippetBytes: 5,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if want := sjis("高橋…\n"); lines.String() != want {
+		t.Errorf("Clamped lines out = % x, want % x", lines.Bytes(), want)
+	}
+
+	// UTF-16 では BOM の後ろから数える
+	utf16, err := unicode.UTF16(unicode.LittleEndian, unicode.UseBOM).NewEncoder().String("a\nb高c\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	results, err = SearchStreamWithOptions(strings.NewReader(utf16), []string{"高"}, SearchOptions{ContextSize: 1, KeepRaw: true})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	info = results["高"].Infos[0]
+	if want := "b\x00\xd8\x9ac\x00"; string(info.Raw) != want || info.Offset != 6 {
+		t.Errorf("UTF-16 raw = % x at offset %d, want % x at 6", info.Raw, info.Offset, want)
+	}
+}
+
+// TestSearchStream_CompareEncoding は変換先で表現できない文字が置換表示されるか確認します
+func TestSearchStream_CompareEncoding(t *testing.T) {
+	enc, err := LookupEncoding("sjis")
+	if err != nil {
+		t.Fatalf("LookupEncoding() error = %v", err)
+	}
+
+	results, err := SearchStreamWithOptions(strings.NewReader("宛名: 髙橋様"), []string{"橋"}, SearchOptions{
+		ContextSize:     3,
+		CompareEncoding: enc,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// 髙(はしごだか)はCP932のIBM拡張文字として表現できる
+	info := results["橋"].Infos[0]
+	if want := ": 髙橋様"; info.Converted != want {
+		t.Errorf("Converted mismatch. got %q, want %q", info.Converted, want)
+	}
+
+	results, err = SearchStreamWithOptions(strings.NewReader("𠮟責"), []string{"責"}, SearchOptions{
+		ContextSize:     3,
+		CompareEncoding: enc,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if want := "〓責"; results["責"].Infos[0].Converted != want {
+		t.Errorf("Converted mismatch. got %q, want %q", results["責"].Infos[0].Converted, want)
+	}
+}
+
+// TestRun_JSONFileMeta は機械可読出力に入力ファイルのサイズとSHA-256が含まれるか確認します
+func TestRun_JSONFileMeta(t *testing.T) {
+	mockStdout := new(bytes.Buffer)
+	ctx := AppContext{
+		Args:     []string{"app", "-format", "json", "in.txt"},
+		ExecPath: "app_WARN",
+		Stdout:   mockStdout,
+		Stderr:   io.Discard,
+		FileReader: func(_ string) (io.ReadCloser, error) {
+			return io.NopCloser(strings.NewReader("abc")), nil
+		},
+	}
+
+	if code := Run(ctx); code != ExitNoMatch {
+		t.Fatalf("Run() exit code = %d, want %d", code, ExitNoMatch)
+	}
+
+	var report JSONReport
+	if err := json.Unmarshal(mockStdout.Bytes(), &report); err != nil {
+		t.Fatalf("Output is not valid JSON: %v", err)
+	}
+	want := FileMeta{Path: "in.txt", Size: 3, SHA256: "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"}
+	if report.File == nil || report.File.Path != want.Path || report.File.Size != want.Size || report.File.SHA256 != want.SHA256 {
+		t.Errorf("File meta mismatch.\n got:  %+v\n want: %+v", report.File, want)
+	}
+}
+
+// TestSearchStream_FirstLast は最初と最後にヒットした行の行番号と行頭オフセットが記録されるか確認します
+func TestSearchStream_FirstLast(t *testing.T) {
+	content := "INFO start\nERROR a\nINFO\nERROR b\nINFO end\n"
+	results, err := SearchStream(strings.NewReader(content), []string{"ERROR", "none"}, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res := results["ERROR"]
+	if *res.First != (Occurrence{Line: 2, Offset: 11}) || *res.Last != (Occurrence{Line: 4, Offset: 24}) {
+		t.Errorf("First/Last = %+v / %+v, want line 2 @11 and line 4 @24", *res.First, *res.Last)
+	}
+	if results["none"].First != nil {
+		t.Error("Queries without hits should have no first occurrence")
+	}
+
+	out := new(bytes.Buffer)
+	WriteResults(out, results, []string{"ERROR"})
+	if !strings.Contains(out.String(), "出現範囲: 2行目 (offset 11) 〜 4行目 (offset 24)\n") {
+		t.Errorf("Output missing occurrence range:\n%s", out.String())
+	}
+}
+
+// TestRun_CountOnly は -c でスニペットを作らず、クエリごとの該当数のみを出力するか確認します
+func TestRun_CountOnly(t *testing.T) {
+	input := "WARN a\nERROR b\nWARN c\n"
+	results, err := SearchStreamWithOptions(strings.NewReader(input), []string{"WARN"}, SearchOptions{ContextSize: 5, CountOnly: true})
+	if err != nil {
+		t.Fatalf("SearchStreamWithOptions() error = %v", err)
+	}
+	if res := results["WARN"]; res.Count != 2 || len(res.Snippets) != 0 || res.Last.Line != 3 {
+		t.Errorf("Result = %d hits, %d snippets, last %+v", res.Count, len(res.Snippets), res.Last)
+	}
+
+	stdout := new(bytes.Buffer)
+	code := Run(AppContext{
+		Args:       []string{"app", "-c", "input.log"},
+		ExecPath:   "app_WARN_ERROR_INFO",
+		Stdout:     stdout,
+		Stderr:     io.Discard,
+		FileReader: func(path string) (io.ReadCloser, error) { return io.NopCloser(strings.NewReader(input)), nil },
+	})
+	if code != 0 {
+		t.Fatalf("Run() exit code = %d", code)
+	}
+	if got, want := stdout.String(), "WARN: 2\nERROR: 1\nINFO: 0\n"; got != want {
+		t.Errorf("Output = %q, want %q", got, want)
+	}
+}
+
+// TestRun_ExitCodes はヒットの有無とエラーで grep と同じ終了コードを返し、-legacy-exit で従来の終了コードになるか確認します
+func TestRun_ExitCodes(t *testing.T) {
+	run := func(execPath string, args ...string) int {
+		return Run(AppContext{
+			Args:     append([]string{"app"}, args...),
+			ExecPath: execPath,
+			Stdout:   io.Discard,
+			Stderr:   io.Discard,
+			FileReader: func(path string) (io.ReadCloser, error) {
+				if path != "input.log" {
+					return nil, errors.New("no such file")
+				}
+				return io.NopCloser(strings.NewReader("WARN a\n")), nil
+			},
+		})
+	}
+
+	tests := []struct {
+		name     string
+		execPath string
+		args     []string
+		want     int
+	}{
+		{"Match", "app_WARN_ERROR", []string{"input.log"}, ExitMatch},
+		{"NoMatch", "app_ERROR", []string{"input.log"}, ExitNoMatch},
+		{"Error", "app_WARN", []string{"missing.log"}, ExitError},
+		{"LegacyNoMatch", "app_ERROR", []string{"-legacy-exit", "input.log"}, 0},
+		{"LegacyError", "app_WARN", []string{"-legacy-exit", "missing.log"}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if code := run(tt.execPath, tt.args...); code != tt.want {
+				t.Errorf("Run() exit code = %d, want %d", code, tt.want)
+			}
+		})
+	}
+}
+
+// TestRun_MaxSnippets は -max-snippets で検索語ごとのスニペットの件数を変えられ、0で全件になるか確認します
+func TestRun_MaxSnippets(t *testing.T) {
+	input := strings.Repeat("WARN x\n", 15)
+	run := func(args ...string) int {
+		stdout := new(bytes.Buffer)
+		code := Run(AppContext{
+			Args:       append(append([]string{"app"}, args...), "input.log"),
+			ExecPath:   "app_WARN",
+			Stdout:     stdout,
+			Stderr:     io.Discard,
+			FileReader: func(string) (io.ReadCloser, error) { return io.NopCloser(strings.NewReader(input)), nil },
+		})
+		if code != ExitMatch {
+			t.Fatalf("Run(%q) exit code = %d", args, code)
+		}
+		return strings.Count(stdout.String(), "line ")
+	}
+
+	tests := []struct {
+		args []string
+		want int
+	}{
+		{nil, MaxSnippets},
+		{[]string{"-max-snippets", "3"}, 3},
+		{[]string{"-max-snippets", "0"}, 15},
+	}
+	for _, tt := range tests {
+		if got := run(tt.args...); got != tt.want {
+			t.Errorf("Run(%q) snippets = %d, want %d", tt.args, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,265 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// piiPatterns は @pii プリセットで使用する個人情報パターンです。
+// これらのクエリのヒット箇所は -redact の指定がなくても常に伏せ字にします。
+var piiPatterns = map[string]*regexp.Regexp{
+	"@pii:phone":    regexp.MustCompile(`\b0\d{1,4}-\d{1,4}-\d{4}\b`),
+	"@pii:mynumber": regexp.MustCompile(`\b\d{4}[ -]?\d{4}[ -]?\d{4}\b`),
+	"@pii:postal":   regexp.MustCompile(`〒?\b\d{3}-\d{4}\b`),
+}
+
+// DefaultRedactMask は伏せ字指定がない場合に使用するマスク文字です
+const DefaultRedactMask = '*'
+
+// matcher は1つのクエリの照合方法を表します。
+// 位置はすべて行文字列中のバイトオフセット [start, end) で表します。
+type matcher interface {
+	// find は最初のヒット位置を返します(ヒットしなければnil)
+	find(line string) []int
+	// findAll はすべてのヒット位置を重ならないように返します
+	findAll(line string) [][]int
+	// alwaysRedact はヒット箇所を常に伏せ字にすべきかを返します
+	alwaysRedact() bool
+}
+
+// 行内でのヒット位置の固定指定
+const (
+	AnchorStart = "start" // 行頭に一致
+	AnchorEnd   = "end"   // 行末に一致
+	AnchorFull  = "full"  // 行全体に一致
+)
+
+// newMatcher はクエリ文字列に対応するmatcherを生成します
+func newMatcher(query string, opts SearchOptions) matcher {
+	// 未知の正規化形式は事前に Run・ワーカーの RemoteOptions.validate で弾く
+	form, err := LookupNormalization(opts.Normalize)
+	normalize := opts.Normalize != "" && err == nil
+	if normalize {
+		query = form.String(query)
+	}
+	// 文字の種類・範囲のクエリは元の文字で判定するため、表記を揃えない
+	foldable := foldableQuery(query)
+	foldWidthQuery, foldKanaQuery := opts.FoldWidth && foldable, opts.FoldKana && foldable
+	ignoreIVSQuery := opts.IgnoreIVS && foldable
+	if ignoreIVSQuery {
+		query, _ = stripVariationSelectors(query)
+	}
+	if foldWidthQuery {
+		query, _ = foldWidthLine(query)
+	}
+	if foldKanaQuery {
+		query = foldKana(query)
+	}
+
+	m := baseMatcher(query, opts)
+	if opts.IgnoreCase {
+		m = ignoreCase(m)
+	}
+	if opts.Anchor != "" {
+		m = anchorMatcher(m, opts.Anchor)
+	}
+	if foldKanaQuery {
+		m = kanaMatcher{inner: m}
+	}
+	if foldWidthQuery {
+		m = widthMatcher{inner: m}
+	}
+	if ignoreIVSQuery {
+		m = ivsMatcher{inner: m}
+	}
+	if normalize {
+		return normMatcher{inner: m, form: form}
+	}
+	return m
+}
+
+// foldableQuery はクエリの表記を揃えて照合できるか(文字の種類・範囲・個人情報のクエリでないか)を返します
+func foldableQuery(query string) bool {
+	if strings.HasPrefix(query, CharClassPrefix) || piiPatterns[query] != nil {
+		return false
+	}
+	_, _, isRange, _ := parseRuneRange(query)
+	return !isRange
+}
+
+// anchorMatcher はヒット位置を行頭・行末・行全体に固定したmatcherを返します
+func anchorMatcher(m matcher, anchor string) matcher {
+	var src string
+	redact := m.alwaysRedact()
+	switch m := m.(type) {
+	case literalMatcher:
+		src = regexp.QuoteMeta(string(m))
+	case *regexpMatcher:
+		src = m.re.String()
+	case *foldMatcher:
+		src = m.re.String()
+	default:
+		// 文字の種類・範囲のクエリとの組み合わせは事前に ValidateQueries で弾く
+		return m
+	}
+
+	switch anchor {
+	case AnchorStart:
+		src = "^(?:" + src + ")"
+	case AnchorEnd:
+		src = "(?:" + src + ")$"
+	case AnchorFull:
+		src = "^(?:" + src + ")$"
+	}
+	return newRegexpMatcher(regexp.MustCompile(src), redact)
+}
+
+// baseMatcher はクエリの種類とオプションに応じた照合方法を選びます
+func baseMatcher(query string, opts SearchOptions) matcher {
+	if re, ok := piiPatterns[query]; ok {
+		return newRegexpMatcher(re, true)
+	}
+	// 未知の種類は事前に ValidateQueries で弾く
+	if pred, ok, err := lookupCharClass(query, opts.UnicodeVersion); ok && err == nil {
+		return classMatcher(pred)
+	}
+	if lo, hi, ok, err := parseRuneRange(query); ok && err == nil {
+		return classMatcher(func(r rune) bool { return r >= lo && r <= hi })
+	}
+	if expr, ok := regexpQuery(query, opts.Extended); ok {
+		// 不正な式は事前に ValidateQueries で弾く
+		if re, err := compileQueryRegexp(expr); err == nil {
+			return newRegexpMatcher(re, false)
+		}
+	}
+	if opts.Romaji {
+		if re := romajiRegexp(query); re != nil {
+			return newRegexpMatcher(re, false)
+		}
+	}
+	if opts.Variants != nil {
+		return newRegexpMatcher(variantRegexp(query, opts.Variants), false)
+	}
+	return literalMatcher(query)
+}
+
+// ignoreCase は大文字・小文字を区別しないmatcherを返します(文字の種類のクエリはそのまま)
+func ignoreCase(m matcher) matcher {
+	switch m := m.(type) {
+	case literalMatcher:
+		return newFoldMatcher(string(m))
+	case *regexpMatcher:
+		re := regexp.MustCompile("(?i)" + m.re.String())
+		return &regexpMatcher{re: re, redact: m.redact, named: m.named}
+	}
+	return m
+}
+
+// foldMatcher は大文字・小文字を区別せずに文字列で照合します。
+// 畳み込んだ行に畳み込んだクエリが含まれる場合のみ、正規表現でヒット位置を求めます。
+type foldMatcher struct {
+	folded string         // 事前照合用に畳み込んだクエリ
+	re     *regexp.Regexp // 元の行でのヒット位置を求める (?i) 付きの正規表現
+}
+
+func newFoldMatcher(query string) *foldMatcher {
+	return &foldMatcher{
+		folded: foldCase(query),
+		re:     regexp.MustCompile("(?i)" + regexp.QuoteMeta(query)),
+	}
+}
+
+func (m *foldMatcher) find(line string) []int {
+	if !strings.Contains(foldCase(line), m.folded) {
+		return nil
+	}
+	return m.re.FindStringIndex(line)
+}
+
+func (m *foldMatcher) findAll(line string) [][]int {
+	if !strings.Contains(foldCase(line), m.folded) {
+		return nil
+	}
+	return m.re.FindAllStringIndex(line, -1)
+}
+
+func (*foldMatcher) alwaysRedact() bool { return false }
+
+// foldCase は各文字を大文字・小文字の同値類(unicode.SimpleFold)の最小の文字に置き換えます。
+// regexp の (?i) と同じ同値関係のため、事前照合で取りこぼすことはありません。
+func foldCase(s string) string {
+	return strings.Map(foldRune, s)
+}
+
+// foldRune は文字の同値類の代表(最小の文字)を返します
+func foldRune(r rune) rune {
+	if r < utf8.RuneSelf {
+		if 'a' <= r && r <= 'z' {
+			return r - 'a' + 'A'
+		}
+		return r
+	}
+	folded := r
+	for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+		folded = min(folded, f)
+	}
+	return folded
+}
+
+// literalMatcher は文字列の完全一致で照合します
+type literalMatcher string
+
+func (m literalMatcher) find(line string) []int {
+	// 高速なバイト検索で照合する
+	i := strings.Index(line, string(m))
+	if i < 0 {
+		return nil
+	}
+	return []int{i, i + len(m)}
+}
+
+func (m literalMatcher) findAll(line string) [][]int {
+	var locs [][]int
+	for offset := 0; offset <= len(line); {
+		i := strings.Index(line[offset:], string(m))
+		if i < 0 {
+			break
+		}
+		start := offset + i
+		locs = append(locs, []int{start, start + len(m)})
+		offset = start + len(m)
+	}
+	return locs
+}
+
+func (literalMatcher) alwaysRedact() bool { return false }
+
+// regexpMatcher は正規表現で照合します
+type regexpMatcher struct {
+	re     *regexp.Regexp
+	redact bool
+	named  bool // 名前付きグループを含み、捕捉値を集計する
+}
+
+func newRegexpMatcher(re *regexp.Regexp, redact bool) *regexpMatcher {
+	return &regexpMatcher{re: re, redact: redact, named: hasNamedGroups(re)}
+}
+
+func (m *regexpMatcher) find(line string) []int {
+	return m.re.FindStringIndex(line)
+}
+
+func (m *regexpMatcher) findAll(line string) [][]int {
+	return m.re.FindAllStringIndex(line, -1)
+}
+
+func (m *regexpMatcher) alwaysRedact() bool { return m.redact }
+
+// runeRange はバイト単位のヒット位置をルーン単位に変換します
+func runeRange(line string, loc []int) (start, end int) {
+	start = utf8.RuneCountInString(line[:loc[0]])
+	end = start + utf8.RuneCountInString(line[loc[0]:loc[1]])
+	return start, end
+}
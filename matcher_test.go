@@ -0,0 +1,132 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// TestSearchStream_PIIPreset は @pii プリセットが既定で伏せ字になるか確認します
+func TestSearchStream_PIIPreset(t *testing.T) {
+	content := "TEL:03-1234-5678 山田\n〒100-0001 東京都\n番号 1234 5678 9012 です\n"
+	queries := expandPresets([]string{"@pii"})
+
+	results, err := SearchStreamWithOptions(strings.NewReader(content), queries, SearchOptions{ContextSize: 3})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	tests := map[string]string{
+		"@pii:phone":    "EL:************ 山田",
+		"@pii:postal":   "********* 東京",
+		"@pii:mynumber": "番号 ************** です",
+	}
+	for q, want := range tests {
+		res := results[q]
+		if res.Count != 1 {
+			t.Errorf("%s count mismatch. got %d, want 1", q, res.Count)
+			continue
+		}
+		if res.Snippets[0] != want {
+			t.Errorf("%s snippet mismatch.\n got:  %q\n want: %q", q, res.Snippets[0], want)
+		}
+	}
+}
+
+// TestSearchStream_Anchor は行頭・行末・行全体への固定を確認します
+func TestSearchStream_Anchor(t *testing.T) {
+	content := "ERROR at start\nend with ERROR\nERROR\nin ERROR middle\n"
+
+	tests := []struct {
+		anchor string
+		want   int
+	}{
+		{"", 4},
+		{AnchorStart, 2},
+		{AnchorEnd, 2},
+		{AnchorFull, 1},
+	}
+	for _, tt := range tests {
+		results, err := SearchStreamWithOptions(strings.NewReader(content), []string{"ERROR"}, SearchOptions{Anchor: tt.anchor})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if got := results["ERROR"].Count; got != tt.want {
+			t.Errorf("anchor=%q count = %d, want %d", tt.anchor, got, tt.want)
+		}
+	}
+}
+
+// TestValidateQueries_Anchor は1文字ずつ照合するクエリと -anchor の組み合わせを弾くか確認します
+func TestValidateQueries_Anchor(t *testing.T) {
+	for _, q := range []string{"@class:non-jis90", "U+3400..U+4DBF"} {
+		if err := ValidateQueries([]string{q}, false, AnchorStart); err == nil {
+			t.Errorf("ValidateQueries(%q) with anchor should fail", q)
+		}
+		if err := ValidateQueries([]string{q}, false, ""); err != nil {
+			t.Errorf("ValidateQueries(%q) without anchor error = %v", q, err)
+		}
+	}
+	for _, q := range []string{"ERROR", "re:^E", "@pii:phone"} {
+		if err := ValidateQueries([]string{q}, false, AnchorFull); err != nil {
+			t.Errorf("ValidateQueries(%q) with anchor error = %v", q, err)
+		}
+	}
+
+	// 表記を揃える照合でも位置を固定する
+	content := "ｴﾗｰ at start\nend with えらー\n"
+	opts := SearchOptions{Anchor: AnchorStart, FoldKana: true, FoldWidth: true}
+	results, err := SearchStreamWithOptions(strings.NewReader(content), []string{"エラー"}, opts)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := results["エラー"].Count; got != 1 {
+		t.Errorf("anchored folded count = %d, want 1", got)
+	}
+}
+
+// TestSearchStream_IgnoreCase は大文字・小文字を区別しない照合とスニペットの切り出しを確認します
+func TestSearchStream_IgnoreCase(t *testing.T) {
+	content := "warn: disk\nWaRn twice WARN\nΣΊΣΥΦΟΣ σίσυφος\nＥＲＲＯＲ ｅｒｒｏｒ\nno hit\n"
+	queries := []string{"WARN", "σίσυφοσ", "ｅｒｒｏｒ", "re:(?P<lv>warn):"}
+
+	results, err := SearchStreamWithOptions(strings.NewReader(content), queries, SearchOptions{ContextSize: 2, IgnoreCase: true})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	tests := map[string]struct {
+		count   int
+		snippet string
+	}{
+		"WARN":             {2, "warn: "},
+		"σίσυφοσ":          {1, "ΣΊΣΥΦΟΣ σ"},
+		"ｅｒｒｏｒ":            {1, "ＥＲＲＯＲ ｅ"},
+		"re:(?P<lv>warn):": {1, "warn: d"},
+	}
+	for q, want := range tests {
+		res := results[q]
+		if res.Count != want.count || len(res.Snippets) == 0 || res.Snippets[0] != want.snippet {
+			t.Errorf("%s: count = %d, snippets = %q, want %d, %q", q, res.Count, res.Snippets, want.count, want.snippet)
+		}
+	}
+
+	results, err = SearchStreamWithOptions(strings.NewReader(content), []string{"WARN"}, SearchOptions{Anchor: AnchorStart, IgnoreCase: true})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := results["WARN"].Count; got != 2 {
+		t.Errorf("anchored count = %d, want 2", got)
+	}
+}
+
+// TestFoldCase は畳み込みが regexp の (?i) と同じ同値関係になるか確認します
+func TestFoldCase(t *testing.T) {
+	for _, pair := range [][2]string{{"Straße", "STRASSE"}, {"K", "K"}, {"ſ", "S"}, {"Ǆ", "ǆ"}} {
+		same := foldCase(pair[0]) == foldCase(pair[1])
+		re := regexp.MustCompile("(?i)^" + regexp.QuoteMeta(pair[0]) + "$")
+		if same != re.MatchString(pair[1]) {
+			t.Errorf("foldCase(%q) == foldCase(%q) is %t, regexp disagrees", pair[0], pair[1], same)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// isNonBMP は基本多言語面(BMP)の外の文字(UTF-16 でサロゲートペアになる U+10000 以上の文字)であるかを返します。
+// JIS X 0213 の第3・第4水準漢字の一部(𠮟 等)はここに割り当てられており、UCS-2 で保存する後段のシステムでは壊れます。
+func isNonBMP(r rune) bool {
+	return r > 0xFFFF
+}
+
+// NonBMPChar は BMP の外の文字の1つの出現箇所です
+type NonBMPChar struct {
+	Path    string `json:"path"`
+	Line    int    `json:"line"`
+	Col     int    `json:"col"` // 文字単位(1始まり)
+	Char    string `json:"char"`
+	Code    string `json:"code"`
+	Class   string `json:"class"`   // JIS X 0213 での分類(level3|level4|plane1|outside)
+	Snippet string `json:"snippet"` // 前後の文脈
+}
+
+// ScanNonBMP は入力を走査し、BMP の外の文字の出現箇所をすべて返します(-non-bmp)
+func ScanNonBMP(r io.Reader, path string, contextSize int) ([]NonBMPChar, error) {
+	var chars []NonBMPChar
+	scanner := newLineReader(r)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := scanner.Text()
+		if lineNum == 1 {
+			line = strings.TrimPrefix(line, utf8BOM)
+		}
+		if strings.IndexFunc(line, isNonBMP) < 0 {
+			continue
+		}
+		runes := []rune(line)
+		for i, c := range runes {
+			if !isNonBMP(c) {
+				continue
+			}
+			from, to := snippetRange(len(runes), i, i+1, contextSize)
+			chars = append(chars, NonBMPChar{
+				Path:    path,
+				Line:    lineNum,
+				Col:     i + 1,
+				Char:    string(c),
+				Code:    fmt.Sprintf("U+%04X", c),
+				Class:   classifyJIS2004(c),
+				Snippet: string(runes[from:to]),
+			})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading stream: %w", err)
+	}
+	return chars, nil
+}
+
+// WriteNonBMP は出現箇所の一覧を出力します。
+// テキスト形式では1箇所1行で "a.txt:3:5: U+20B9F 𠮟 (第1面 第3水準漢字): 前後の文脈" のように出力します。
+func WriteNonBMP(w io.Writer, chars []NonBMPChar, format string) error {
+	if format == FormatJSON || format == FormatJSONL {
+		enc := json.NewEncoder(w)
+		if format == FormatJSON {
+			enc.SetIndent("", "  ")
+			if chars == nil {
+				chars = []NonBMPChar{}
+			}
+			return enc.Encode(struct {
+				Schema string       `json:"schema"`
+				Chars  []NonBMPChar `json:"chars"`
+			}{SchemaVersion, chars})
+		}
+		for _, c := range chars {
+			if err := enc.Encode(c); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	for _, c := range chars {
+		if _, err := fmt.Fprintf(w, "%s:%d:%d: %s %s (%s): %s\n", c.Path, c.Line, c.Col, c.Code, c.Char, auditLabels[c.Class], EscapeSnippet(c.Snippet, EscapeNonPrintable)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
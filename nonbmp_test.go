@@ -0,0 +1,26 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestScanNonBMP(t *testing.T) {
+	chars, err := ScanNonBMP(strings.NewReader("\ufeff𠮟責\n叱る\n𩸽と😀\n"), "a.txt", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []NonBMPChar{
+		{Path: "a.txt", Line: 1, Col: 1, Char: "𠮟", Code: "U+20B9F", Class: AuditLevel3, Snippet: "𠮟責"},
+		{Path: "a.txt", Line: 3, Col: 1, Char: "𩸽", Code: "U+29E3D", Class: AuditLevel4, Snippet: "𩸽と"},
+		{Path: "a.txt", Line: 3, Col: 3, Char: "😀", Code: "U+1F600", Class: AuditOutside, Snippet: "と😀"},
+	}
+	if len(chars) != len(want) {
+		t.Fatalf("ScanNonBMP() = %+v, want %+v", chars, want)
+	}
+	for i := range want {
+		if chars[i] != want[i] {
+			t.Errorf("chars[%d] = %+v, want %+v", i, chars[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// normForms は -normalize で指定できるUnicode正規化形式です
+var normForms = map[string]norm.Form{
+	"nfc":  norm.NFC,
+	"nfkc": norm.NFKC,
+}
+
+// LookupNormalization は正規化形式の名前を検証して返します
+func LookupNormalization(name string) (norm.Form, error) {
+	form, ok := normForms[strings.ToLower(name)]
+	if !ok {
+		return 0, fmt.Errorf("unknown normalization form: %s (expected nfc or nfkc)", name)
+	}
+	return form, nil
+}
+
+// normSegment は正規化した行の1区間と、それに対応する元の行のバイト範囲です
+type normSegment struct {
+	norm          int // 正規化後の行での開始位置
+	orig, origEnd int // 元の行での範囲 [orig, origEnd)
+}
+
+// normalizeLine は行を正規化し、正規化後の位置を元の行の位置へ戻すための区間の一覧を返します
+func normalizeLine(form norm.Form, line string) (string, []normSegment) {
+	var it norm.Iter
+	it.InitString(form, line)
+
+	var sb strings.Builder
+	var segs []normSegment
+	for !it.Done() {
+		start := it.Pos()
+		seg := it.Next()
+		segs = append(segs, normSegment{norm: sb.Len(), orig: start, origEnd: it.Pos()})
+		sb.Write(seg)
+	}
+	return sb.String(), segs
+}
+
+// origRange は正規化後の行でのヒット位置を元の行の位置に戻します。
+// 1文字が複数の文字に展開される場合(㍿ → 株式会社 等)は、展開元の文字全体をヒット位置とします。
+func origRange(segs []normSegment, loc []int) []int {
+	at := func(pos int) normSegment {
+		i := sort.Search(len(segs), func(i int) bool { return segs[i].norm > pos }) - 1
+		return segs[max(i, 0)]
+	}
+	start := at(loc[0]).orig
+	if loc[1] == loc[0] {
+		return []int{start, start}
+	}
+	return []int{start, at(loc[1] - 1).origEnd}
+}
+
+// normMatcher は行とクエリを正規化してから照合し、ヒット位置は元の行の位置で返します。
+// スニペットは元の行から切り出されます。
+type normMatcher struct {
+	inner matcher // 正規化したクエリで照合するmatcher
+	form  norm.Form
+}
+
+func (m normMatcher) find(line string) []int {
+	if m.form.IsNormalString(line) {
+		return m.inner.find(line)
+	}
+	text, segs := normalizeLine(m.form, line)
+	loc := m.inner.find(text)
+	if loc == nil {
+		return nil
+	}
+	return origRange(segs, loc)
+}
+
+func (m normMatcher) findAll(line string) [][]int {
+	if m.form.IsNormalString(line) {
+		return m.inner.findAll(line)
+	}
+	text, segs := normalizeLine(m.form, line)
+	locs := m.inner.findAll(text)
+	for i, loc := range locs {
+		locs[i] = origRange(segs, loc)
+	}
+	return locs
+}
+
+func (m normMatcher) alwaysRedact() bool { return m.inner.alwaysRedact() }
+
+// captureSource は名前付きグループの捕捉値を集計する正規表現と、照合対象の行を返します。
+// 正規化して照合する場合は正規化した行から捕捉します。
+func captureSource(m matcher, line string) (*regexpMatcher, string, bool) {
+	if nm, ok := m.(normMatcher); ok {
+		if !nm.form.IsNormalString(line) {
+			line = nm.form.String(line)
+		}
+		m = nm.inner
+	}
+	if im, ok := m.(ivsMatcher); ok {
+		if needsIVSStrip(line) {
+			line, _ = stripVariationSelectors(line)
+		}
+		m = im.inner
+	}
+	if wm, ok := m.(widthMatcher); ok {
+		if needsWidthFold(line) {
+			line, _ = foldWidthLine(line)
+		}
+		m = wm.inner
+	}
+	if km, ok := m.(kanaMatcher); ok {
+		line = foldKana(line)
+		m = km.inner
+	}
+	rm, ok := m.(*regexpMatcher)
+	if !ok || !rm.named {
+		return nil, "", false
+	}
+	return rm, line, true
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestSearchStream_Normalize は全角・半角の混在をNFKC正規化で同一視し、スニペットは元の表記のまま切り出すか確認します
+func TestSearchStream_Normalize(t *testing.T) {
+	content := "ｴﾗｰ発生 code=１２３\nエラー code=123\n㈱テスト 株式会社\nno hit\n"
+	queries := []string{"エラー", "ＣＯＤＥ=123", "(株)", "re:code=(?P<code>\\d+)"}
+
+	results, err := SearchStreamWithOptions(strings.NewReader(content), queries, SearchOptions{ContextSize: 1, Normalize: "nfkc", IgnoreCase: true})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	tests := map[string]struct {
+		count   int
+		snippet string
+		match   string
+	}{
+		"エラー":                    {2, "ｴﾗｰ発", "ｴﾗｰ"},
+		"ＣＯＤＥ=123":               {2, " code=１２３", "code=１２３"},
+		"(株)":                    {1, "㈱テ", "㈱"},
+		"re:code=(?P<code>\\d+)": {2, " code=１２３", "code=１２３"},
+	}
+	for q, want := range tests {
+		res := results[q]
+		if res.Count != want.count || len(res.Snippets) == 0 || res.Snippets[0] != want.snippet {
+			t.Errorf("%s: count = %d, snippets = %q, want %d, %q", q, res.Count, res.Snippets, want.count, want.snippet)
+			continue
+		}
+		info := res.Infos[0]
+		if got := res.Snippets[0][info.MatchStart:info.MatchEnd]; got != want.match {
+			t.Errorf("%s: match = %q, want %q", q, got, want.match)
+		}
+	}
+	if got := results["re:code=(?P<code>\\d+)"].Captures["code"]["123"]; got != 2 {
+		t.Errorf("captured code=123 count = %d, want 2", got)
+	}
+
+	if _, err := LookupNormalization("nfd"); err == nil {
+		t.Error("LookupNormalization(nfd) should fail")
+	}
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// OutputSpec は -output で指定する追加の出力先です(fmt=json,path=a.json)
+type OutputSpec struct {
+	Format string
+	Path   string
+}
+
+// outputFormats は -output で指定できる形式です
+var outputFormats = map[string]bool{
+	FormatText: true, FormatTable: true, FormatJSON: true, FormatJSONL: true, FormatCSV: true, FormatTSV: true,
+}
+
+// ParseOutputSpec は "fmt=json,path=a.json" の形式の出力先の指定を解釈します。
+// path はパスに "," を含められるよう最後に指定した場合のみ残りをすべてパスとみなします。
+func ParseOutputSpec(spec string) (OutputSpec, error) {
+	var out OutputSpec
+	rest := spec
+	for rest != "" {
+		key, value, ok := strings.Cut(rest, "=")
+		if !ok {
+			return OutputSpec{}, fmt.Errorf("invalid output: %q (fmt=FORMAT,path=PATH)", spec)
+		}
+		if key == "path" {
+			out.Path, rest = value, ""
+			break
+		}
+		value, rest, _ = strings.Cut(value, ",")
+		switch key {
+		case "fmt", "format":
+			out.Format = value
+		default:
+			return OutputSpec{}, fmt.Errorf("invalid output key: %q in %q", key, spec)
+		}
+	}
+	if out.Path == "" {
+		return OutputSpec{}, fmt.Errorf("output path is required: %q", spec)
+	}
+	if out.Format == "" {
+		out.Format = FormatText
+	}
+	if !outputFormats[out.Format] {
+		return OutputSpec{}, fmt.Errorf("invalid output format: %s (text|table|json|jsonl|csv|tsv)", out.Format)
+	}
+	return out, nil
+}
+
+// outputList は繰り返し指定できる追加の出力先のフラグ(-output)の値です
+type outputList []OutputSpec
+
+func (o *outputList) String() string {
+	specs := make([]string, len(*o))
+	for i, s := range *o {
+		specs[i] = "fmt=" + s.Format + ",path=" + s.Path
+	}
+	return strings.Join(specs, " ")
+}
+
+func (o *outputList) Set(value string) error {
+	spec, err := ParseOutputSpec(value)
+	if err != nil {
+		return err
+	}
+	*o = append(*o, spec)
+	return nil
+}
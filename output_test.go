@@ -0,0 +1,75 @@
+package main
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseOutputSpec(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    OutputSpec
+		wantErr bool
+	}{
+		{"fmt=json,path=a.json", OutputSpec{Format: FormatJSON, Path: "a.json"}, false},
+		{"path=out/a,b.csv", OutputSpec{Format: FormatText, Path: "out/a,b.csv"}, false},
+		{"format=tsv,path=a.tsv", OutputSpec{Format: FormatTSV, Path: "a.tsv"}, false},
+		{"fmt=html,path=a.html", OutputSpec{}, true},
+		{"fmt=json", OutputSpec{}, true},
+		{"a.json", OutputSpec{}, true},
+		{"fmt=json,mode=x,path=a.json", OutputSpec{}, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseOutputSpec(tt.spec)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseOutputSpec(%q) error = %v, wantErr %v", tt.spec, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseOutputSpec(%q) = %+v, want %+v", tt.spec, got, tt.want)
+		}
+	}
+}
+
+// TestRun_MultipleOutputs は1回の走査で -output の各形式のレポートを書き出すか確認します
+func TestRun_MultipleOutputs(t *testing.T) {
+	dir := t.TempDir()
+	jsonPath := filepath.Join(dir, "a.json")
+	csvPath := filepath.Join(dir, "a.csv")
+	opens := 0
+	stdout := new(strings.Builder)
+	code := Run(AppContext{
+		Args:     []string{"app", "-output", "fmt=json,path=" + jsonPath, "-output", "fmt=csv,path=" + csvPath, "input.log"},
+		ExecPath: "app_WARN",
+		Stdout:   stdout,
+		Stderr:   io.Discard,
+		FileReader: func(string) (io.ReadCloser, error) {
+			opens++
+			return io.NopCloser(strings.NewReader("WARN a\n")), nil
+		},
+		FileCreator: func(path string) (io.WriteCloser, error) { return os.Create(path) },
+		Rename:      os.Rename,
+		Remove:      os.Remove,
+	})
+	if code != ExitMatch {
+		t.Fatalf("Run() exit code = %d", code)
+	}
+	if opens != 1 {
+		t.Errorf("Input opened %d times, want 1", opens)
+	}
+	if !strings.Contains(stdout.String(), "[WARN]") {
+		t.Errorf("Stdout = %q, want the text report", stdout)
+	}
+	for path, want := range map[string]string{jsonPath: `"query": "WARN"`, csvPath: "input.log,WARN,1,1,"} {
+		got, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(got), want) {
+			t.Errorf("%s = %q, want it to contain %q", filepath.Base(path), got, want)
+		}
+	}
+}
@@ -0,0 +1,10 @@
+//go:build !unix
+
+package main
+
+import "os"
+
+// fileOwnership は所有者を取得できない環境では権限のみを返します
+func fileOwnership(info os.FileInfo) *FileOwnership {
+	return &FileOwnership{Mode: info.Mode().String()}
+}
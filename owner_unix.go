@@ -0,0 +1,26 @@
+//go:build unix
+
+package main
+
+import (
+	"os"
+	"os/user"
+	"strconv"
+	"syscall"
+)
+
+// fileOwnership はファイルの所有者・グループ・権限を返します。名前を引けない所有者・グループはIDの数値で示します。
+func fileOwnership(info os.FileInfo) *FileOwnership {
+	o := &FileOwnership{Mode: info.Mode().String()}
+	if st, ok := info.Sys().(*syscall.Stat_t); ok {
+		uid, gid := strconv.FormatUint(uint64(st.Uid), 10), strconv.FormatUint(uint64(st.Gid), 10)
+		o.Owner, o.Group = uid, gid
+		if u, err := user.LookupId(uid); err == nil {
+			o.Owner = u.Username
+		}
+		if g, err := user.LookupGroupId(gid); err == nil {
+			o.Group = g.Name
+		}
+	}
+	return o
+}
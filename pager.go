@@ -0,0 +1,81 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// DefaultScreenLines は端末の行数が取得できない場合に仮定する1画面の行数です
+const DefaultScreenLines = 24
+
+// NewTerminalPager は出力先が端末で、かつ1画面に収まらない場合に $PAGER を経由して
+// 表示する関数を返します。それ以外の場合は out にそのまま書き出します。
+func NewTerminalPager(out *os.File) func([]byte) error {
+	return func(content []byte) error {
+		if !isTerminal(out) || bytes.Count(content, []byte("\n")) < screenLines() {
+			_, err := out.Write(content)
+			return err
+		}
+
+		args := pagerCommand()
+		if len(args) == 0 {
+			_, err := out.Write(content)
+			return err
+		}
+
+		cmd := exec.Command(args[0], args[1:]...)
+		cmd.Stdin = bytes.NewReader(content)
+		cmd.Stdout = out
+		cmd.Stderr = os.Stderr
+		// git と同様に、less には色を保持し1画面なら即終了するオプションを渡す
+		if os.Getenv("LESS") == "" {
+			cmd.Env = append(os.Environ(), "LESS=FRX")
+		}
+		if err := cmd.Run(); err != nil {
+			// ページャが起動できない場合は直接出力する
+			if _, ok := err.(*exec.ExitError); !ok {
+				_, err = out.Write(content)
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// pagerCommand は $PAGER(未設定ならOS既定)のコマンドラインを返します。
+// "cat" が指定された場合はページャを使用しません。
+func pagerCommand() []string {
+	pager, ok := os.LookupEnv("PAGER")
+	if !ok {
+		pager = "less"
+		if runtime.GOOS == "windows" {
+			pager = "more"
+		}
+	}
+	args := strings.Fields(pager)
+	if len(args) == 0 || args[0] == "cat" {
+		return nil
+	}
+	return args
+}
+
+// isTerminal は f が端末(キャラクタデバイス)であるかを返します
+func isTerminal(f *os.File) bool {
+	fi, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return fi.Mode()&os.ModeCharDevice != 0
+}
+
+// screenLines は環境変数 LINES から1画面の行数を返します
+func screenLines() int {
+	if n, err := strconv.Atoi(os.Getenv("LINES")); err == nil && n > 0 {
+		return n
+	}
+	return DefaultScreenLines
+}
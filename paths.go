@@ -0,0 +1,71 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// PathResolver は機械可読出力に書くファイルのパスを、実行時の作業ディレクトリによらない形に揃えます。
+// パスは絶対パスにしてシンボリックリンクを解決し、Root が指定されていればその下のパスを Root からの相対パスにします。
+// 作業ディレクトリの異なる実行同士でもレポートのパスを比較できるようにするためのものです。
+type PathResolver struct {
+	Root     string // 正規化済みの基準ディレクトリ(空なら絶対パスのまま)
+	resolved map[string]string
+}
+
+// NewPathResolver は root (空なら絶対パスで出力する)を基準にパスを揃える PathResolver を生成します
+func NewPathResolver(root string) (*PathResolver, error) {
+	pr := &PathResolver{resolved: make(map[string]string)}
+	if root != "" {
+		canonical, err := canonicalPath(root)
+		if err != nil {
+			return nil, err
+		}
+		pr.Root = canonical
+	}
+	return pr, nil
+}
+
+// Resolve はパスを揃えた形で返します。標準入力や空のパスはそのまま返します。
+// ZIPのエントリ(a.zip:dir/b.txt)はアーカイブのパスのみを揃えます。
+func (pr *PathResolver) Resolve(path string) string {
+	if pr == nil || path == "" || path == StdinPath {
+		return path
+	}
+	if p, ok := pr.resolved[path]; ok {
+		return p
+	}
+
+	base, entry := splitZipEntry(path)
+	p, err := canonicalPath(base)
+	if err != nil {
+		p = base
+	} else if pr.Root != "" {
+		if rel, err := filepath.Rel(pr.Root, p); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			p = filepath.ToSlash(rel)
+		}
+	}
+	pr.resolved[path] = p + entry
+	return p + entry
+}
+
+// canonicalPath は絶対パスにしてシンボリックリンクを解決したパスを返します。
+// 存在しない部分はそのまま残し、存在する親ディレクトリまでを解決します。
+func canonicalPath(path string) (string, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	rest := ""
+	for dir := abs; ; {
+		if real, err := filepath.EvalSymlinks(dir); err == nil {
+			return filepath.Join(real, rest), nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return abs, nil
+		}
+		rest = filepath.Join(filepath.Base(dir), rest)
+		dir = parent
+	}
+}
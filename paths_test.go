@@ -0,0 +1,76 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestPathResolver は絶対パス・基準ディレクトリからの相対パスに揃え、シンボリックリンクを解決するか確認します
+func TestPathResolver(t *testing.T) {
+	dir, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "logs"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	link := filepath.Join(dir, "link")
+	if err := os.Symlink(filepath.Join(dir, "logs"), link); err != nil {
+		t.Skip("symlinks are not available:", err)
+	}
+
+	abs, _ := NewPathResolver("")
+	if got, want := abs.Resolve(filepath.Join(link, "a.log")), filepath.Join(dir, "logs", "a.log"); got != want {
+		t.Errorf("Resolve(symlink) = %s, want %s", got, want)
+	}
+	if got := abs.Resolve(StdinPath); got != StdinPath {
+		t.Errorf("Resolve(stdin) = %s", got)
+	}
+
+	rooted, err := NewPathResolver(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := map[string]string{
+		filepath.Join(link, "a.log"):             "logs/a.log",
+		filepath.Join(dir, "b.zip") + ":x/y.txt": "b.zip:x/y.txt",
+		filepath.Dir(dir):                        filepath.Dir(dir),
+	}
+	for in, want := range tests {
+		if got := rooted.Resolve(in); got != want {
+			t.Errorf("Resolve(%s) = %s, want %s", in, got, want)
+		}
+	}
+}
+
+// TestRun_AbsPaths は -root を指定した場合に、作業ディレクトリによらず同じパスを報告するか確認します
+func TestRun_AbsPaths(t *testing.T) {
+	dir, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	input := filepath.Join(dir, "logs", "..", "logs", "a.log")
+	stdout := new(bytes.Buffer)
+	code := Run(AppContext{
+		Args:       []string{"app", "-format", "json", "-root", dir, input},
+		ExecPath:   "app_WARN",
+		Stdout:     stdout,
+		Stderr:     io.Discard,
+		FileReader: func(string) (io.ReadCloser, error) { return io.NopCloser(strings.NewReader("WARN a\n")), nil },
+	})
+	if code != ExitMatch {
+		t.Fatalf("Run() exit code = %d", code)
+	}
+	var report JSONReport
+	if err := json.Unmarshal(stdout.Bytes(), &report); err != nil {
+		t.Fatal(err)
+	}
+	if report.Input != "logs/a.log" {
+		t.Errorf("Input = %q, want logs/a.log", report.Input)
+	}
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"golang.org/x/text/transform"
+)
+
+// queryList は繰り返し指定できるクエリのフラグ(-q)の値です
+type queryList []string
+
+func (q *queryList) String() string { return strings.Join(*q, ",") }
+
+func (q *queryList) Set(value string) error {
+	if value == "" {
+		return errors.New("empty query")
+	}
+	*q = append(*q, value)
+	return nil
+}
+
+// LoadQueryFile はクエリファイル(-f)から1行に1つずつクエリを読み込みます。
+// 空行と "#" で始まる行は読み飛ばします。行末の CR は取り除きますが、それ以外の空白はクエリの一部とみなします。
+func LoadQueryFile(r io.Reader) ([]string, error) {
+	var queries []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSuffix(scanner.Text(), "\r")
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		queries = append(queries, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading query file: %w", err)
+	}
+	return queries, nil
+}
+
+// readStdinQueries は -queries-stdin のクエリを標準入力から読み込みます(形式は -f と同じ)。
+// encName が指定されていれば、その文字コードから変換して読み込みます。
+// 標準入力はクエリに使うため、検索する入力に標準入力("-")は指定できません。
+func readStdinQueries(ctx AppContext, inputs []string, encName string) ([]string, error) {
+	if ctx.Stdin == nil {
+		return nil, errors.New("stdin is not available")
+	}
+	for _, in := range inputs {
+		if in == StdinPath {
+			return nil, errors.New("stdin cannot be both the query list and the input")
+		}
+	}
+	var r io.Reader = ctx.Stdin
+	if encName != "" {
+		enc, err := LookupEncoding(encName)
+		if err != nil {
+			return nil, err
+		}
+		r = transform.NewReader(r, enc.NewDecoder())
+	}
+	return LoadQueryFile(r)
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestLoadQueryFile はコメント行・空行を読み飛ばし、行末のCRを取り除くか確認します
+func TestLoadQueryFile(t *testing.T) {
+	got, err := LoadQueryFile(strings.NewReader("# 氏名\n髙橋\r\n\n 齋藤 \nU+3400..U+4DBF\n"))
+	if err != nil {
+		t.Fatalf("LoadQueryFile() error = %v", err)
+	}
+	if want := []string{"髙橋", " 齋藤 ", "U+3400..U+4DBF"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("LoadQueryFile() = %q, want %q", got, want)
+	}
+}
+
+// TestRun_QueryFile は -f・-q のクエリが実行ファイル名のクエリに加わるか、-replace-queries で置き換わるか確認します
+func TestRun_QueryFile(t *testing.T) {
+	files := map[string]string{
+		"q.txt":     "ERROR\nWARN\n",
+		"input.log": "WARN a\nERROR b\nINFO c\n",
+	}
+	run := func(execPath string, args ...string) (string, int) {
+		stdout := new(bytes.Buffer)
+		code := Run(AppContext{
+			Args:     append([]string{"app"}, args...),
+			ExecPath: execPath,
+			Stdout:   stdout,
+			Stderr:   io.Discard,
+			FileReader: func(path string) (io.ReadCloser, error) {
+				return io.NopCloser(strings.NewReader(files[path])), nil
+			},
+		})
+		return stdout.String(), code
+	}
+
+	out, code := run("app_INFO", "-f", "q.txt", "input.log")
+	if code != 0 || !strings.Contains(out, "[INFO]\n") || !strings.Contains(out, "[ERROR]\n") {
+		t.Errorf("Queries should be merged (code %d).\n Output: %s", code, out)
+	}
+
+	out, code = run("app_INFO", "-f", "q.txt", "-replace-queries", "input.log")
+	if code != 0 || strings.Contains(out, "[INFO]") || !strings.Contains(out, "[WARN]\n") {
+		t.Errorf("Queries should be replaced (code %d).\n Output: %s", code, out)
+	}
+
+	if _, code := run("objis", "-f", "q.txt", "input.log"); code != 0 {
+		t.Errorf("-f should not require queries in the executable name, exit code = %d", code)
+	}
+	out, code = run("objis", "-q", "INFO", "-q", "WARN", "input.log")
+	if code != 0 || !strings.Contains(out, "[INFO]\n該当数: 1") || !strings.Contains(out, "[WARN]\n") {
+		t.Errorf("Repeated -q should add each query (code %d).\n Output: %s", code, out)
+	}
+	out, code = run("app_INFO", "-q", "ERROR", "-replace-queries", "input.log")
+	if code != 0 || strings.Contains(out, "[INFO]") || !strings.Contains(out, "[ERROR]\n") {
+		t.Errorf("-replace-queries should keep only -q queries (code %d).\n Output: %s", code, out)
+	}
+
+	if _, code := run("objis", "input.log"); code == 0 {
+		t.Error("Run() without any queries should fail")
+	}
+}
+
+// TestRun_QueriesStdin は -queries-stdin で標準入力のクエリを(-queries-enc の文字コードから変換して)読むか確認します
+func TestRun_QueriesStdin(t *testing.T) {
+	sjis, _ := LookupEncoding("sjis")
+	encoded, _ := sjis.NewEncoder().String("髙橋\n")
+
+	run := func(stdin string, args ...string) (string, int) {
+		stdout := new(bytes.Buffer)
+		code := Run(AppContext{
+			Args:        append([]string{"app"}, args...),
+			ExecPath:    "objis",
+			Stdout:      stdout,
+			Stderr:      io.Discard,
+			Stdin:       strings.NewReader(stdin),
+			StdinIsPipe: true,
+			FileReader: func(string) (io.ReadCloser, error) {
+				return io.NopCloser(strings.NewReader("髙橋 一郎\n高橋 二郎\n")), nil
+			},
+		})
+		return stdout.String(), code
+	}
+
+	out, code := run(encoded, "-queries-stdin", "-queries-enc", "sjis", "input.log")
+	if code != ExitMatch || !strings.Contains(out, "[髙橋]\n該当数: 1") {
+		t.Errorf("Query should be read from stdin (code %d).\n Output: %s", code, out)
+	}
+	if _, code := run("髙橋\n", "-queries-stdin", "-"); code != ExitError {
+		t.Errorf("stdin as both queries and input: exit code = %d, want %d", code, ExitError)
+	}
+	if _, code := run("髙橋\n", "-queries-enc", "sjis", "input.log"); code != ExitError {
+		t.Errorf("-queries-enc without -queries-stdin: exit code = %d, want %d", code, ExitError)
+	}
+}
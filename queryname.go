@@ -0,0 +1,122 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// -query-filenames の指定値
+const (
+	QueryNamesReadable = "readable" // ファイル名に使えない記号を全角に置き換え、それ以外はクエリのまま使う
+	QueryNamesStrict   = "strict"   // ASCIIの英数字と "._-" のみを使い、変えた場合はハッシュ値を付ける
+)
+
+// MaxQueryFileNameBytes はクエリから作るファイル名の部分の上限(バイト)です。超える場合は切り詰めてハッシュ値を付けます。
+const MaxQueryFileNameBytes = 100
+
+// fullwidthReplacements はWindowsのファイル名に使えない記号の置き換え先です
+var fullwidthReplacements = strings.NewReplacer(
+	"/", "／", `\`, "＼", ":", "：", "*", "＊", "?", "？", `"`, "＂", "<", "＜", ">", "＞", "|", "｜",
+)
+
+// windowsReservedNames はWindowsで拡張子を付けても使えないファイル名です
+var windowsReservedNames = map[string]bool{
+	"CON": true, "PRN": true, "AUX": true, "NUL": true,
+	"COM1": true, "COM2": true, "COM3": true, "COM4": true, "COM5": true, "COM6": true, "COM7": true, "COM8": true, "COM9": true,
+	"LPT1": true, "LPT2": true, "LPT3": true, "LPT4": true, "LPT5": true, "LPT6": true, "LPT7": true, "LPT8": true, "LPT9": true,
+}
+
+// QueryFileNames はクエリごとの出力ファイル名に使う文字列を返します。
+// 別のクエリが同じ名前になる場合は、クエリのハッシュ値を付けて区別します(大文字小文字のみの違いも同じ名前とみなす)。
+func QueryFileNames(queries []string, mode string) (map[string]string, error) {
+	var derive func(string) string
+	switch mode {
+	case QueryNamesReadable, "":
+		derive = readableFileName
+	case QueryNamesStrict:
+		derive = strictFileName
+	default:
+		return nil, fmt.Errorf("invalid query file name mode: %s (readable|strict)", mode)
+	}
+
+	names := make(map[string]string, len(queries))
+	seen := make(map[string]int, len(queries))
+	for _, q := range queries {
+		names[q] = derive(q)
+		seen[strings.ToLower(names[q])]++
+	}
+	for _, q := range queries {
+		if seen[strings.ToLower(names[q])] > 1 && !strings.HasSuffix(names[q], "-"+queryHash(q)) {
+			names[q] += "-" + queryHash(q)
+		}
+	}
+	return names, nil
+}
+
+// readableFileName は記号を全角に、制御文字を "_" に置き換えたファイル名を返します
+func readableFileName(query string) string {
+	name := strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return '_'
+		}
+		return r
+	}, fullwidthReplacements.Replace(query))
+	// Windowsでは末尾の空白とピリオドは取り除かれる
+	if trimmed := strings.TrimRight(name, " ."); trimmed != name {
+		name = trimmed + "_"
+	}
+	if reservedFileName(name) {
+		name += "_" + queryHash(query)
+	}
+	if len(name) > MaxQueryFileNameBytes {
+		name = clampFileName(name) + "-" + queryHash(query)
+	}
+	return name
+}
+
+// strictFileName はASCIIの英数字と "._-" 以外を "_" に置き換え、変えた場合はクエリのハッシュ値を付けたファイル名を返します
+func strictFileName(query string) string {
+	name := strings.Map(func(r rune) rune {
+		if r < utf8.RuneSelf && (r == '.' || r == '_' || r == '-' || unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			return r
+		}
+		return '_'
+	}, query)
+	if name == query && len(name) <= MaxQueryFileNameBytes && !strings.HasSuffix(name, ".") && !reservedFileName(name) {
+		return name
+	}
+	name = strings.Trim(name, "._")
+	if len(name) > MaxQueryFileNameBytes {
+		name = name[:MaxQueryFileNameBytes]
+	}
+	// 英数字を含まないクエリ(日本語のみ等)は名前が残らないため、ハッシュ値のみにする
+	if name == "" {
+		return queryHash(query)
+	}
+	return name + "-" + queryHash(query)
+}
+
+// reservedFileName はWindowsの予約名(CON や NUL.txt 等)であるかを返します
+func reservedFileName(name string) bool {
+	base, _, _ := strings.Cut(name, ".")
+	return windowsReservedNames[strings.ToUpper(base)]
+}
+
+// clampFileName は文字の途中で切らないよう、ファイル名を MaxQueryFileNameBytes バイト以内に切り詰めます
+func clampFileName(name string) string {
+	i := MaxQueryFileNameBytes
+	for i > 0 && !utf8.RuneStart(name[i]) {
+		i--
+	}
+	return name[:i]
+}
+
+// queryHash はクエリを区別するための短いハッシュ値(SHA-256の先頭8桁)を返します
+func queryHash(query string) string {
+	sum := sha256.Sum256([]byte(query))
+	return hex.EncodeToString(sum[:4])
+}
@@ -0,0 +1,50 @@
+package main
+
+import "testing"
+
+// TestQueryFileNames はファイル名に使えない文字を含むクエリから、モードごとに安全で重複しない名前を作るか確認します
+func TestQueryFileNames(t *testing.T) {
+	queries := []string{"WARN", "外字", `re:\d+/x`, "a:b", "a：b", "CON", "log.", "warn"}
+	tests := []struct {
+		mode string
+		want map[string]string
+	}{
+		{QueryNamesReadable, map[string]string{
+			"WARN":     "WARN-" + queryHash("WARN"),
+			"外字":       "外字",
+			`re:\d+/x`: `re：＼d+／x`,
+			"a:b":      "a：b-" + queryHash("a:b"),
+			"a：b":      "a：b-" + queryHash("a：b"),
+			"CON":      "CON_" + queryHash("CON"),
+			"log.":     "log_",
+			"warn":     "warn-" + queryHash("warn"),
+		}},
+		{QueryNamesStrict, map[string]string{
+			"WARN":     "WARN-" + queryHash("WARN"),
+			"外字":       queryHash("外字"),
+			`re:\d+/x`: "re__d__x-" + queryHash(`re:\d+/x`),
+			"a:b":      "a_b-" + queryHash("a:b"),
+			"a：b":      "a_b-" + queryHash("a：b"),
+			"CON":      "CON-" + queryHash("CON"),
+			"log.":     "log-" + queryHash("log."),
+			"warn":     "warn-" + queryHash("warn"),
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			names, err := QueryFileNames(queries, tt.mode)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, q := range queries {
+				if names[q] != tt.want[q] {
+					t.Errorf("name of %q = %q, want %q", q, names[q], tt.want[q])
+				}
+			}
+		})
+	}
+
+	if _, err := QueryFileNames(queries, "raw"); err == nil {
+		t.Error("QueryFileNames() with an unknown mode should fail")
+	}
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"strings"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/unicode"
+)
+
+// isPlainUTF8 は文字コードの名前が変換の不要な UTF-8 (BOM付きを含む)を指すかを返します
+func isPlainUTF8(name string) bool {
+	switch strings.ToLower(name) {
+	case "", encodingUTF8, "utf-8-bom":
+		return true
+	}
+	return false
+}
+
+// isBigEndian は UTF-16 の文字コードの名前がビッグエンディアンを指すかを返します
+func isBigEndian(name string) bool {
+	return strings.HasSuffix(strings.ToLower(name), "be")
+}
+
+// encodedNewline は文字コード encoding での改行(LF)のバイト列を返します
+func encodedNewline(encoding string) []byte {
+	switch {
+	case !IsUTF16(encoding):
+		return []byte{'\n'}
+	case isBigEndian(encoding):
+		return []byte{0, '\n'}
+	default:
+		return []byte{'\n', 0}
+	}
+}
+
+// rawLine は変換前の行と、それを UTF-8 に変換した行です。
+// スニペットの範囲や -lines-out の行を、入力の文字コードのバイト列のまま取り出すのに使います。
+type rawLine struct {
+	raw      []byte // 変換前の行(改行を除く。先頭行ではBOMを含む)
+	text     string // 変換後の行(先頭のBOMを除く)
+	encoding string // 入力の文字コード
+}
+
+// span はルーン位置 [from, to) の文字に対応する変換前のバイト列を返します。
+// line は照合に使った行で、フィルタで text から変わっている場合は該当部分を入力の文字コードに符号化し直します。
+func (l rawLine) span(line string, from, to int) []byte {
+	if line == l.text {
+		if offsets, lead, ok := l.offsets(); ok {
+			return []byte(string(l.raw[lead+offsets[from] : lead+offsets[to]]))
+		}
+	}
+	runes := []rune(line)
+	return l.encode(string(runes[from:to]))
+}
+
+// clamp は変換前の行を、文字の途中で切らずに limit バイト以内に切り詰めます(limit が0以下なら切り詰めない)
+func (l rawLine) clamp(limit int) []byte {
+	if limit <= 0 || len(l.raw) <= limit {
+		return l.raw
+	}
+	if isPlainUTF8(l.encoding) {
+		return clampLine(l.raw, limit)
+	}
+	marker := l.encode(TruncationMarker)
+	offsets, lead, ok := l.offsets()
+	if !ok {
+		return append(l.encode(string(clampLine([]byte(l.text), limit))), marker...)
+	}
+	n := len(offsets) - 1
+	for n > 0 && lead+offsets[n] > limit {
+		n--
+	}
+	return append(l.raw[:lead+offsets[n]:lead+offsets[n]], marker...)
+}
+
+// offsets は text の各文字の先頭に対応する、変換前の行の先頭(BOMの後ろ)からのバイト位置と、BOMのバイト数を返します。
+// 1文字ずつ符号化し直した長さの合計が変換前の行と合わない(不正なバイト列や、状態を持つ ISO-2022-JP の漢字)場合は ok が偽です。
+func (l rawLine) offsets() (offsets []int, lead int, ok bool) {
+	offsets = make([]int, 0, len(l.text)+1)
+	pos := 0
+	if isPlainUTF8(l.encoding) {
+		for i := range l.text {
+			offsets = append(offsets, i)
+		}
+		pos = len(l.text)
+	} else {
+		enc := widthEncoding(l.encoding)
+		if enc == nil {
+			return nil, 0, false
+		}
+		encoder := enc.NewEncoder()
+		for _, r := range l.text {
+			offsets = append(offsets, pos)
+			b, err := encoder.String(string(r))
+			if err != nil {
+				return nil, 0, false
+			}
+			pos += len(b)
+		}
+	}
+	lead = len(l.raw) - pos
+	return append(offsets, pos), lead, lead >= 0
+}
+
+// encode は s を入力の文字コードに符号化します。表せない文字は文字コードの代替文字にします。
+func (l rawLine) encode(s string) []byte {
+	if isPlainUTF8(l.encoding) {
+		return []byte(s)
+	}
+	enc := widthEncoding(l.encoding)
+	if enc == nil {
+		return []byte(s)
+	}
+	b, err := encoding.ReplaceUnsupported(enc.NewEncoder()).String(s)
+	if err != nil {
+		return []byte(s)
+	}
+	return []byte(b)
+}
+
+// widthEncoding は文字ごとのバイト数を求めるための文字コードを返します(UTF-16 は BOM を付けない)
+func widthEncoding(name string) encoding.Encoding {
+	if IsUTF16(name) {
+		if isBigEndian(name) {
+			return unicode.UTF16(unicode.BigEndian, unicode.IgnoreBOM)
+		}
+		return unicode.UTF16(unicode.LittleEndian, unicode.IgnoreBOM)
+	}
+	enc, err := LookupEncoding(name)
+	if err != nil {
+		return nil
+	}
+	return enc
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+)
+
+// romajiTable はローマ字(ヘボン式・訓令式)からひらがなへの変換表です
+var romajiTable = map[string]string{
+	"a": "あ", "i": "い", "u": "う", "e": "え", "o": "お",
+	"ka": "か", "ki": "き", "ku": "く", "ke": "け", "ko": "こ",
+	"sa": "さ", "si": "し", "shi": "し", "su": "す", "se": "せ", "so": "そ",
+	"ta": "た", "ti": "ち", "chi": "ち", "tu": "つ", "tsu": "つ", "te": "て", "to": "と",
+	"na": "な", "ni": "に", "nu": "ぬ", "ne": "ね", "no": "の",
+	"ha": "は", "hi": "ひ", "hu": "ふ", "fu": "ふ", "he": "へ", "ho": "ほ",
+	"ma": "ま", "mi": "み", "mu": "む", "me": "め", "mo": "も",
+	"ya": "や", "yu": "ゆ", "yo": "よ",
+	"ra": "ら", "ri": "り", "ru": "る", "re": "れ", "ro": "ろ",
+	"wa": "わ", "wo": "を", "nn": "ん", "n'": "ん",
+	"ga": "が", "gi": "ぎ", "gu": "ぐ", "ge": "げ", "go": "ご",
+	"za": "ざ", "zi": "じ", "ji": "じ", "zu": "ず", "ze": "ぜ", "zo": "ぞ",
+	"da": "だ", "di": "ぢ", "du": "づ", "de": "で", "do": "ど",
+	"ba": "ば", "bi": "び", "bu": "ぶ", "be": "べ", "bo": "ぼ",
+	"pa": "ぱ", "pi": "ぴ", "pu": "ぷ", "pe": "ぺ", "po": "ぽ",
+	"kya": "きゃ", "kyu": "きゅ", "kyo": "きょ",
+	"sya": "しゃ", "syu": "しゅ", "syo": "しょ", "sha": "しゃ", "shu": "しゅ", "sho": "しょ",
+	"tya": "ちゃ", "tyu": "ちゅ", "tyo": "ちょ", "cha": "ちゃ", "chu": "ちゅ", "cho": "ちょ",
+	"nya": "にゃ", "nyu": "にゅ", "nyo": "にょ",
+	"hya": "ひゃ", "hyu": "ひゅ", "hyo": "ひょ",
+	"mya": "みゃ", "myu": "みゅ", "myo": "みょ",
+	"rya": "りゃ", "ryu": "りゅ", "ryo": "りょ",
+	"gya": "ぎゃ", "gyu": "ぎゅ", "gyo": "ぎょ",
+	"zya": "じゃ", "zyu": "じゅ", "zyo": "じょ", "ja": "じゃ", "ju": "じゅ", "jo": "じょ",
+	"bya": "びゃ", "byu": "びゅ", "byo": "びょ",
+	"pya": "ぴゃ", "pyu": "ぴゅ", "pyo": "ぴょ",
+	"-": "ー",
+}
+
+// nameReadings は読み(ひらがな)から代表的な漢字表記を引く組み込みの表です。
+// 人名検索でよく使われる姓を収録しています。
+var nameReadings = map[string][]string{
+	"さとう":  {"佐藤", "佐東"},
+	"すずき":  {"鈴木"},
+	"たかはし": {"高橋", "髙橋"},
+	"たなか":  {"田中"},
+	"いとう":  {"伊藤", "伊東"},
+	"わたなべ": {"渡辺", "渡邊", "渡邉", "渡部"},
+	"やまもと": {"山本"},
+	"なかむら": {"中村"},
+	"こばやし": {"小林"},
+	"かとう":  {"加藤"},
+	"よしだ":  {"吉田", "𠮷田"},
+	"やまだ":  {"山田"},
+	"ささき":  {"佐々木"},
+	"やまぐち": {"山口"},
+	"まつもと": {"松本"},
+	"いのうえ": {"井上"},
+	"きむら":  {"木村"},
+	"はやし":  {"林"},
+	"さいとう": {"斎藤", "斉藤", "齋藤", "齊藤"},
+	"しみず":  {"清水"},
+	"やまざき": {"山崎", "山﨑"},
+	"もり":   {"森"},
+	"いけだ":  {"池田"},
+	"はしもと": {"橋本"},
+	"あべ":   {"阿部", "安部"},
+	"いしかわ": {"石川"},
+	"やました": {"山下"},
+	"なかじま": {"中島", "中嶋"},
+	"いしい":  {"石井"},
+	"おがわ":  {"小川"},
+	"まえだ":  {"前田"},
+	"おかだ":  {"岡田"},
+	"はせがわ": {"長谷川"},
+	"ふじた":  {"藤田"},
+	"ごとう":  {"後藤"},
+	"こんどう": {"近藤"},
+	"むらかみ": {"村上"},
+	"えんどう": {"遠藤"},
+	"あおき":  {"青木"},
+	"さかもと": {"坂本"},
+}
+
+// isRomaji はクエリがローマ字のみ(英字・アポストロフィ・長音符号)で構成されているかを返します
+func isRomaji(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range strings.ToLower(s) {
+		if (r < 'a' || r > 'z') && r != '\'' && r != '-' && r != 'ō' && r != 'ū' {
+			return false
+		}
+	}
+	return true
+}
+
+// RomajiToHiragana はローマ字をひらがなに変換します。変換できない綴りを含む場合は false を返します。
+func RomajiToHiragana(s string) (string, bool) {
+	s = strings.ToLower(s)
+	// 長音記号付きの母音と "oh" 表記を "ou" に揃える
+	s = strings.NewReplacer("ō", "ou", "ū", "uu").Replace(s)
+	if strings.HasSuffix(s, "oh") {
+		s = strings.TrimSuffix(s, "h") + "u"
+	}
+
+	var sb strings.Builder
+	for i := 0; i < len(s); {
+		// 子音の重なりは促音(っ)、ただし "nn" は撥音
+		if i+1 < len(s) && s[i] == s[i+1] && s[i] != 'n' && !isVowel(s[i]) {
+			sb.WriteString("っ")
+			i++
+			continue
+		}
+		// 母音・y 以外が続く n は撥音
+		if s[i] == 'n' && (i+1 == len(s) || (!isVowel(s[i+1]) && s[i+1] != 'y' && s[i+1] != 'n' && s[i+1] != '\'')) {
+			sb.WriteString("ん")
+			i++
+			continue
+		}
+
+		matched := false
+		for l := 3; l >= 1; l-- {
+			if i+l > len(s) {
+				continue
+			}
+			if kana, ok := romajiTable[s[i:i+l]]; ok {
+				sb.WriteString(kana)
+				i += l
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return "", false
+		}
+	}
+	return sb.String(), true
+}
+
+func isVowel(c byte) bool {
+	return strings.IndexByte("aiueo", c) >= 0
+}
+
+// hiraganaToKatakana はひらがなをカタカナに変換します
+func hiraganaToKatakana(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'ぁ' && r <= 'ゖ' {
+			return r + 0x60
+		}
+		return r
+	}, s)
+}
+
+// romajiRegexp はローマ字のクエリから、ひらがな・カタカナ(長音符表記を含む)・組み込み表の漢字表記の
+// いずれかに一致する正規表現を生成します。ローマ字として解釈できない場合は nil を返します。
+func romajiRegexp(query string) *regexp.Regexp {
+	if !isRomaji(query) {
+		return nil
+	}
+	hira, ok := RomajiToHiragana(query)
+	if !ok {
+		return nil
+	}
+
+	forms := []string{hira, hiraganaToKatakana(hira)}
+	// カタカナでは "ou" "uu" などの長音を "ー" で書くことが多い
+	long := strings.NewReplacer("ou", "o-", "oo", "o-", "uu", "u-", "aa", "a-", "ii", "i-", "ee", "e-").Replace(strings.ToLower(query))
+	if longHira, ok := RomajiToHiragana(long); ok && longHira != hira {
+		forms = append(forms, hiraganaToKatakana(longHira))
+	}
+	forms = append(forms, nameReadings[hira]...)
+
+	quoted := make([]string, len(forms))
+	for i, f := range forms {
+		quoted[i] = regexp.QuoteMeta(f)
+	}
+	return regexp.MustCompile(strings.Join(quoted, "|"))
+}
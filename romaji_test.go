@@ -0,0 +1,39 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestRomajiToHiragana はヘボン式・訓令式のローマ字がひらがなに変換されるか確認します
+func TestRomajiToHiragana(t *testing.T) {
+	tests := map[string]string{
+		"satou":     "さとう",
+		"Satoh":     "さとう",
+		"takahashi": "たかはし",
+		"kanda":     "かんだ",
+		"hattori":   "はっとり",
+		"shin'ya":   "しんや",
+		"tyuuou":    "ちゅうおう",
+	}
+	for in, want := range tests {
+		if got, ok := RomajiToHiragana(in); !ok || got != want {
+			t.Errorf("RomajiToHiragana(%q) = %q, %v; want %q", in, got, ok, want)
+		}
+	}
+	if _, ok := RomajiToHiragana("xq"); ok {
+		t.Error("RomajiToHiragana(xq) should fail")
+	}
+}
+
+// TestSearchStream_Romaji はローマ字のクエリがかな・カタカナ・漢字表記に一致するか確認します
+func TestSearchStream_Romaji(t *testing.T) {
+	content := "佐藤一郎\nさとう\nサトウ\nサトー\nSATOU\n鈴木\n"
+	results, err := SearchStreamWithOptions(strings.NewReader(content), []string{"satou"}, SearchOptions{Romaji: true})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := results["satou"].Count; got != 4 {
+		t.Errorf("Count = %d, want 4", got)
+	}
+}
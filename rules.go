@@ -0,0 +1,191 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// 上限を超えた場合の重大度。既定では SeverityError の超過のみ終了コードで失敗を通知する(-fail-on で変更できる)。
+const (
+	SeverityError   = "error"   // 修正が必要な違反
+	SeverityWarning = "warning" // 確認が必要な違反
+	SeverityInfo    = "info"    // 参考情報
+)
+
+// severityRanks は重大度の順位です(大きいほど重い)
+var severityRanks = map[string]int{
+	SeverityInfo:    1,
+	SeverityWarning: 2,
+	SeverityError:   3,
+}
+
+// ValidSeverity は重大度の名前が正しいかを返します
+func ValidSeverity(s string) bool {
+	return severityRanks[s] > 0
+}
+
+// severityLabels はテキスト出力で上限超過を表す語です
+var severityLabels = map[string]string{
+	SeverityError:   "超過",
+	SeverityWarning: "警告",
+	SeverityInfo:    "情報",
+}
+
+// ポリシーの指定方法(ルールファイルの行頭の語)と重大度の対応
+var policySeverities = map[string]string{
+	"forbid": SeverityError,
+	"warn":   SeverityWarning,
+	"info":   SeverityInfo,
+}
+
+// QueryRule はルールファイルの1行(query "外字" max=0 など)を表します
+type QueryRule struct {
+	Query    string
+	Max      *int   // 許容する最大該当数(nilなら上限なし)
+	Severity string // 上限を超えた場合の重大度(空なら SeverityError)
+}
+
+// LoadRules はルールファイルを読み込みます。
+// 空行と '#' で始まる行は無視します。
+// `forbid non-jis90, pua` や `warn ivs` の行は、文字の種類ごとに該当数0を上限とするルールになります。
+func LoadRules(r io.Reader) ([]QueryRule, error) {
+	var rules []QueryRule
+
+	scanner := bufio.NewScanner(r)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if directive, rest, _ := strings.Cut(line, " "); policySeverities[directive] != "" {
+			policy, err := parsePolicyLine(rest, policySeverities[directive])
+			if err != nil {
+				return nil, fmt.Errorf("rules line %d: %w", lineNum, err)
+			}
+			rules = append(rules, policy...)
+			continue
+		}
+
+		rule, err := parseRuleLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("rules line %d: %w", lineNum, err)
+		}
+		rules = append(rules, rule)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading rules: %w", err)
+	}
+	return rules, nil
+}
+
+// parseRuleLine は `query "検索語" key=value ...` 形式の1行を解析します。
+// severity のみ指定して max を省略した場合は、1件でもヒットすれば超過とみなします。
+func parseRuleLine(line string) (QueryRule, error) {
+	rest, ok := strings.CutPrefix(line, "query ")
+	if !ok {
+		return QueryRule{}, fmt.Errorf("unknown directive: %s", line)
+	}
+	rest = strings.TrimSpace(rest)
+
+	quoted, err := strconv.QuotedPrefix(rest)
+	if err != nil {
+		return QueryRule{}, fmt.Errorf("query must be a quoted string: %s", rest)
+	}
+	query, err := strconv.Unquote(quoted)
+	if err != nil || query == "" {
+		return QueryRule{}, fmt.Errorf("invalid query: %s", quoted)
+	}
+
+	rule := QueryRule{Query: query}
+	for _, attr := range strings.Fields(rest[len(quoted):]) {
+		key, value, ok := strings.Cut(attr, "=")
+		if !ok {
+			return QueryRule{}, fmt.Errorf("attribute must be key=value: %s", attr)
+		}
+
+		switch key {
+		case "max":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 0 {
+				return QueryRule{}, fmt.Errorf("invalid max: %s", value)
+			}
+			rule.Max = &n
+		case "severity":
+			if !ValidSeverity(value) {
+				return QueryRule{}, fmt.Errorf("invalid severity: %s (expected error, warning or info)", value)
+			}
+			rule.Severity = value
+		default:
+			return QueryRule{}, fmt.Errorf("unknown attribute: %s", key)
+		}
+	}
+	if rule.Severity != "" && rule.Max == nil {
+		zero := 0
+		rule.Max = &zero
+	}
+	return rule, nil
+}
+
+// parsePolicyLine はカンマ区切りの文字の種類を、重大度 severity で該当数0を上限とするルールに変換します
+func parsePolicyLine(rest, severity string) ([]QueryRule, error) {
+	var rules []QueryRule
+	for _, name := range strings.Split(rest, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		query := CharClassPrefix + name
+		if _, _, err := lookupCharClass(query, ""); err != nil {
+			return nil, err
+		}
+		zero := 0
+		rules = append(rules, QueryRule{Query: query, Max: &zero, Severity: severity})
+	}
+	if len(rules) == 0 {
+		return nil, fmt.Errorf("policy needs at least one character class")
+	}
+	return rules, nil
+}
+
+// MergeRuleQueries はルールで宣言されたクエリのうち未指定のものを末尾に追加します
+func MergeRuleQueries(queries []string, rules []QueryRule) []string {
+	merged := append([]string(nil), queries...)
+	seen := make(map[string]bool, len(queries))
+	for _, q := range queries {
+		seen[q] = true
+	}
+	for _, rule := range rules {
+		if !seen[rule.Query] {
+			seen[rule.Query] = true
+			merged = append(merged, rule.Query)
+		}
+	}
+	return merged
+}
+
+// ApplyRules は結果に閾値と重大度を設定し、重大度が failOn 以上で超過したクエリが1つでもあればtrueを返します
+func ApplyRules(results map[string]*SearchResult, rules []QueryRule, failOn string) bool {
+	breached := false
+	for _, rule := range rules {
+		res, ok := results[rule.Query]
+		if !ok || rule.Max == nil {
+			continue
+		}
+		res.Max = rule.Max
+		res.Severity = rule.Severity
+		if res.Severity == "" {
+			res.Severity = SeverityError
+		}
+		if res.Breached() && severityRanks[res.Severity] >= severityRanks[failOn] {
+			breached = true
+		}
+	}
+	return breached
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+// TestLoadRules はルールファイルの正常系・異常系を確認します
+func TestLoadRules(t *testing.T) {
+	src := "# policy\nquery \"外字\" max=0\n\nquery \"WARN\" max=100\nquery \"INFO\"\n"
+	rules, err := LoadRules(strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(rules) != 3 {
+		t.Fatalf("Rule count mismatch. got %d, want 3", len(rules))
+	}
+	if rules[0].Query != "外字" || rules[0].Max == nil || *rules[0].Max != 0 {
+		t.Errorf("Rule[0] mismatch. got %+v", rules[0])
+	}
+	if rules[2].Max != nil {
+		t.Errorf("Rule[2] should have no max. got %d", *rules[2].Max)
+	}
+
+	for _, bad := range []string{"query WARN", "query \"WARN\" max=-1", "limit \"WARN\"", "query \"WARN\" min=1"} {
+		if _, err := LoadRules(strings.NewReader(bad)); err == nil {
+			t.Errorf("LoadRules(%q) should fail", bad)
+		}
+	}
+}
+
+// TestRun_RulesBreach は閾値超過時に終了コード3となるか確認します
+func TestRun_RulesBreach(t *testing.T) {
+	files := map[string]string{
+		"rules.txt": "query \"WARN\" max=1\nquery \"ERROR\" max=0\n",
+		"app.log":   "WARN a\nWARN b\nINFO c\n",
+	}
+	mockStdout := new(bytes.Buffer)
+	ctx := AppContext{
+		Args:     []string{"app", "-rules", "rules.txt", "app.log"},
+		ExecPath: "app_WARN",
+		Stdout:   mockStdout,
+		Stderr:   io.Discard,
+		FileReader: func(path string) (io.ReadCloser, error) {
+			return io.NopCloser(strings.NewReader(files[path])), nil
+		},
+	}
+
+	if code := Run(ctx); code != 3 {
+		t.Errorf("Run() exit code = %d, want 3", code)
+	}
+
+	output := mockStdout.String()
+	for _, want := range []string{"[WARN]\n該当数: 2\n上限: 1 (超過)", "[ERROR]\n該当数: 0\n上限: 0 (OK)"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Output should contain %q.\n Output: %s", want, output)
+		}
+	}
+}
+
+// TestRun_Policies は禁止ポリシーの違反で終了コード3、警告のみなら0となるか確認します
+func TestRun_Policies(t *testing.T) {
+	run := func(rules, input string) (int, string) {
+		files := map[string]string{"rules.txt": rules, "data.txt": input}
+		stdout := new(bytes.Buffer)
+		code := Run(AppContext{
+			Args:     []string{"app", "-rules", "rules.txt", "data.txt"},
+			ExecPath: "app_WARN",
+			Stdout:   stdout,
+			Stderr:   io.Discard,
+			FileReader: func(path string) (io.ReadCloser, error) {
+				return io.NopCloser(strings.NewReader(files[path])), nil
+			},
+		})
+		return code, stdout.String()
+	}
+
+	rules := "forbid non-jis90, PUA, halfwidth-kana\nwarn ivs\n"
+	code, out := run(rules, "葛\U000E0100飾\n")
+	if code != 0 || !strings.Contains(out, "[@class:ivs]\n該当数: 1\n上限: 0 (警告)") {
+		t.Errorf("Warning only: code = %d, output:\n%s", code, out)
+	}
+
+	code, out = run(rules, "ｶﾅ\n")
+	if code != 3 || !strings.Contains(out, "[@class:halfwidth-kana]\n該当数: 1\n上限: 0 (超過)") {
+		t.Errorf("Forbidden: code = %d, output:\n%s", code, out)
+	}
+
+	if _, err := LoadRules(strings.NewReader("forbid emoji\n")); err == nil {
+		t.Error("LoadRules() should reject unknown character classes")
+	}
+}
+
+// TestSeverity は重大度の指定と -fail-on による終了コードの切り替え、表形式への反映を確認します
+func TestSeverity(t *testing.T) {
+	rules, err := LoadRules(strings.NewReader("query \"WARN\" severity=warning\nquery \"INFO\" max=5 severity=info\ninfo pua\n"))
+	if err != nil {
+		t.Fatalf("LoadRules() error = %v", err)
+	}
+	if rules[0].Max == nil || *rules[0].Max != 0 || rules[0].Severity != SeverityWarning {
+		t.Errorf("Rule[0] = %+v, want max 0 with warning severity", rules[0])
+	}
+	if _, err := LoadRules(strings.NewReader("query \"WARN\" severity=fatal\n")); err == nil {
+		t.Error("LoadRules() should reject unknown severities")
+	}
+
+	newResults := func() map[string]*SearchResult {
+		return map[string]*SearchResult{"WARN": {Query: "WARN", Count: 1}, "INFO": {Query: "INFO", Count: 1}}
+	}
+	if ApplyRules(newResults(), rules, SeverityError) {
+		t.Error("Warning breach should not fail with -fail-on error")
+	}
+	results := newResults()
+	if !ApplyRules(results, rules, SeverityWarning) {
+		t.Error("Warning breach should fail with -fail-on warning")
+	}
+
+	var buf bytes.Buffer
+	WriteTable(&buf, results, []string{"WARN", "INFO"})
+	want := "クエリ | 該当数 | 重大度         | No | 行 | スニペット\n" +
+		"-------+--------+----------------+----+----+-----------\n" +
+		"WARN   | 1      | warning (警告) |    |    | \n" +
+		"INFO   | 1      | info           |    |    | \n"
+	if buf.String() != want {
+		t.Errorf("WriteTable() =\n%s\nwant\n%s", buf.String(), want)
+	}
+}
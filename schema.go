@@ -0,0 +1,99 @@
+package main
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// SchemaVersion は機械可読出力のスキーマの版です。
+// フィールドの意味を変える・削除する変更を行う場合は版を上げ、ConvertReport に移行処理を追加すること。
+const SchemaVersion = "objis/v2"
+
+// ReportSchema は機械可読出力(JSONの文書全体、またはJSONLの1行)のJSON Schemaです。
+// 出力の構造を変えた場合はこのスキーマも更新すること(テストで出力と照合している)。
+//
+//go:embed report.schema.json
+var ReportSchema []byte
+
+// ConvertReport は旧版のJSON/JSONLレポートを現行のスキーマに変換します。
+// schema フィールドを持たないレポートは版管理導入前の v1 として扱います。
+func ConvertReport(r io.Reader, w io.Writer) error {
+	dec := json.NewDecoder(r)
+	enc := json.NewEncoder(w)
+
+	for {
+		var doc map[string]json.RawMessage
+		if err := dec.Decode(&doc); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("invalid report: %w", err)
+		}
+
+		upgraded, err := upgradeDocument(doc)
+		if err != nil {
+			return err
+		}
+
+		// JSON文書は整形して、JSONLの各行はそのまま1行で出力する
+		if _, ok := upgraded["results"]; ok {
+			enc.SetIndent("", "  ")
+		} else {
+			enc.SetIndent("", "")
+		}
+		if err := enc.Encode(upgraded); err != nil {
+			return err
+		}
+	}
+}
+
+// upgradeDocument はレポート(またはJSONLの1行)を現行の版に移行します
+func upgradeDocument(doc map[string]json.RawMessage) (map[string]json.RawMessage, error) {
+	var schema string
+	if raw, ok := doc["schema"]; ok {
+		if err := json.Unmarshal(raw, &schema); err != nil {
+			return nil, fmt.Errorf("invalid schema field: %w", err)
+		}
+	}
+
+	switch schema {
+	case "", "objis/v1":
+		// v1 -> v2: 版の明示のみ。結果のない検索語の snippets は空配列に揃える
+		if err := normalizeSnippets(doc); err != nil {
+			return nil, err
+		}
+		if results, ok := doc["results"]; ok {
+			var items []map[string]json.RawMessage
+			if err := json.Unmarshal(results, &items); err != nil {
+				return nil, fmt.Errorf("invalid results: %w", err)
+			}
+			for _, item := range items {
+				if err := normalizeSnippets(item); err != nil {
+					return nil, err
+				}
+			}
+			doc["results"], _ = json.Marshal(items)
+		}
+	case SchemaVersion:
+		return doc, nil
+	default:
+		return nil, fmt.Errorf("unsupported schema version: %s", schema)
+	}
+
+	doc["schema"], _ = json.Marshal(SchemaVersion)
+	return doc, nil
+}
+
+// normalizeSnippets は検索語の結果で snippets が null の場合に空配列に置き換えます
+func normalizeSnippets(item map[string]json.RawMessage) error {
+	if _, ok := item["query"]; !ok {
+		return nil
+	}
+	if raw, ok := item["snippets"]; !ok || string(raw) == "null" {
+		item["snippets"] = json.RawMessage("[]")
+	}
+	return nil
+}
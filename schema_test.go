@@ -0,0 +1,200 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// TestConvertReport は版のないv1レポートが現行の版に変換されるか確認します
+func TestConvertReport(t *testing.T) {
+	v1 := `{"results":[{"query":"WARN","count":0,"lines":1,"bytes":2,"snippets":null}]}`
+
+	out := new(bytes.Buffer)
+	if err := ConvertReport(strings.NewReader(v1), out); err != nil {
+		t.Fatalf("ConvertReport() error = %v", err)
+	}
+
+	var report JSONReport
+	if err := json.Unmarshal(out.Bytes(), &report); err != nil {
+		t.Fatalf("Output is not valid JSON: %v\n%s", err, out.String())
+	}
+	if report.Schema != SchemaVersion {
+		t.Errorf("Schema = %q, want %q", report.Schema, SchemaVersion)
+	}
+	if report.Results[0].Snippets == nil {
+		t.Error("Snippets should be normalized to an empty array")
+	}
+
+	// JSONLは各行が変換され、行単位のまま出力される
+	out.Reset()
+	jsonl := `{"query":"a","count":1,"snippets":[]}` + "\n" + `{"query":"b","count":0}` + "\n"
+	if err := ConvertReport(strings.NewReader(jsonl), out); err != nil {
+		t.Fatalf("ConvertReport() error = %v", err)
+	}
+	if lines := strings.Split(strings.TrimSpace(out.String()), "\n"); len(lines) != 2 || !strings.Contains(lines[1], `"schema":"objis/v2"`) {
+		t.Errorf("JSONL conversion mismatch.\n%s", out.String())
+	}
+
+	if err := ConvertReport(strings.NewReader(`{"schema":"objis/v9"}`), out); err == nil {
+		t.Error("ConvertReport() should reject unknown schema versions")
+	}
+}
+
+// TestOutputMatchesSchema は -format json/jsonl の出力が公開しているJSON Schemaに適合するか確認します
+func TestOutputMatchesSchema(t *testing.T) {
+	var schema map[string]any
+	if err := json.Unmarshal(ReportSchema, &schema); err != nil {
+		t.Fatalf("ReportSchema is not valid JSON: %v", err)
+	}
+
+	input := "user=alice 髙橋\nuser=bob 髙橋\nuser=alice 髙橋\n"
+	queries := []string{"髙", `re:user=(?P<user>\w+)`, "none"}
+	results, err := SearchStreamWithOptions(strings.NewReader(input), queries,
+		SearchOptions{ContextSize: 3, FoldDuplicates: true, KeepRaw: true, SampleEvery: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	limit := 1
+	results["髙"].Max = &limit
+	file := &FileMeta{Path: "a.log", Size: 42, SHA256: strings.Repeat("ab", 32), Verified: true,
+		Ownership: &FileOwnership{Owner: "alice", Group: "audit", Mode: "-rw-r-----"}}
+
+	for _, format := range []string{FormatJSON, FormatJSONL} {
+		out := new(bytes.Buffer)
+		opts := OutputOptions{Format: format, File: file, GroupBy: "user", InputPath: "a.log"}
+		if err := WriteFormatted(out, results, queries, opts); err != nil {
+			t.Fatal(err)
+		}
+
+		dec := json.NewDecoder(out)
+		for dec.More() {
+			var doc any
+			if err := dec.Decode(&doc); err != nil {
+				t.Fatal(err)
+			}
+			if err := validateSchema(schema, schema, doc, "$"); err != nil {
+				t.Errorf("%s output does not match the schema: %v", format, err)
+			}
+		}
+	}
+
+	// スキーマにない項目は検出される
+	bad := map[string]any{"schema": SchemaVersion, "results": []any{}, "extra": 1}
+	if err := validateSchema(schema, schema, bad, "$"); err == nil {
+		t.Error("validateSchema() should reject unknown properties")
+	}
+}
+
+// validateSchema は出力の検証に必要な範囲のJSON Schemaのキーワードのみを解釈する簡易検証器です
+func validateSchema(root, s map[string]any, v any, path string) error {
+	if ref, ok := s["$ref"].(string); ok {
+		def := root
+		for _, key := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+			def = def[key].(map[string]any)
+		}
+		if err := validateSchema(root, def, v, path); err != nil {
+			return err
+		}
+	}
+	for _, sub := range asSchemas(s["allOf"]) {
+		if err := validateSchema(root, sub, v, path); err != nil {
+			return err
+		}
+	}
+	if subs := asSchemas(s["oneOf"]); subs != nil {
+		matched := 0
+		for _, sub := range subs {
+			if validateSchema(root, sub, v, path) == nil {
+				matched++
+			}
+		}
+		if matched != 1 {
+			return fmt.Errorf("%s: matches %d of oneOf", path, matched)
+		}
+	}
+	if c, ok := s["const"]; ok && c != v {
+		return fmt.Errorf("%s: %v, want %v", path, v, c)
+	}
+
+	switch typ, _ := s["type"].(string); typ {
+	case "object":
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return fmt.Errorf("%s: not an object", path)
+		}
+		for _, key := range asSlice(s["required"]) {
+			if _, ok := obj[key.(string)]; !ok {
+				return fmt.Errorf("%s: missing %s", path, key)
+			}
+		}
+		props, _ := s["properties"].(map[string]any)
+		for key, val := range obj {
+			if prop, ok := props[key].(map[string]any); ok {
+				if err := validateSchema(root, prop, val, path+"."+key); err != nil {
+					return err
+				}
+				continue
+			}
+			switch extra := s["additionalProperties"].(type) {
+			case bool:
+				if !extra {
+					return fmt.Errorf("%s: unexpected property %s", path, key)
+				}
+			case map[string]any:
+				if err := validateSchema(root, extra, val, path+"."+key); err != nil {
+					return err
+				}
+			}
+		}
+	case "array":
+		arr, ok := v.([]any)
+		if !ok {
+			return fmt.Errorf("%s: not an array", path)
+		}
+		if items, ok := s["items"].(map[string]any); ok {
+			for i, item := range arr {
+				if err := validateSchema(root, items, item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+					return err
+				}
+			}
+		}
+	case "string":
+		str, ok := v.(string)
+		if !ok {
+			return fmt.Errorf("%s: not a string", path)
+		}
+		if p, ok := s["pattern"].(string); ok && !regexp.MustCompile(p).MatchString(str) {
+			return fmt.Errorf("%s: %q does not match %s", path, str, p)
+		}
+	case "integer":
+		n, ok := v.(float64)
+		if !ok || n != float64(int64(n)) {
+			return fmt.Errorf("%s: not an integer", path)
+		}
+		if lower, ok := s["minimum"].(float64); ok && n < lower {
+			return fmt.Errorf("%s: %v is less than %v", path, n, lower)
+		}
+	case "boolean":
+		if _, ok := v.(bool); !ok {
+			return fmt.Errorf("%s: not a boolean", path)
+		}
+	}
+	return nil
+}
+
+func asSlice(v any) []any {
+	s, _ := v.([]any)
+	return s
+}
+
+func asSchemas(v any) []map[string]any {
+	var schemas []map[string]any
+	for _, s := range asSlice(v) {
+		schemas = append(schemas, s.(map[string]any))
+	}
+	return schemas
+}
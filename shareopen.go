@@ -0,0 +1,80 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// DefaultRetryOpenInterval は -retry-open で開き直すまでの間隔です
+const DefaultRetryOpenInterval = 250 * time.Millisecond
+
+// sharedDirFS は dir 以下のファイルを openShared で開く fs.FS を返します。ディレクトリは os.DirFS で開きます。
+// openShared は他のプロセスが書き込み・削除(ローテーション)のために開いているファイルも開けるよう、
+// Windows では共有モードに書き込み・削除も含めて開きます(os.Open は削除を共有しないため、書き込み中のログで失敗することがある)。
+func sharedDirFS(dir string) fs.FS {
+	return sharedFS{dir: dir, FS: os.DirFS(dir)}
+}
+
+type sharedFS struct {
+	dir string
+	fs.FS
+}
+
+func (s sharedFS) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+	if info, err := fs.Stat(s.FS, name); err != nil || info.IsDir() {
+		return s.FS.Open(name)
+	}
+	return openShared(filepath.Join(s.dir, filepath.FromSlash(name)))
+}
+
+// isSharingViolation は他のプロセスがファイルを開いているために開けなかったエラーであるかを返します
+func isSharingViolation(err error) bool {
+	for _, errno := range sharingViolations {
+		if errors.Is(err, errno) {
+			return true
+		}
+	}
+	return false
+}
+
+// retryOpen は共有違反で開けなかった場合に、interval 間隔で retries 回まで開き直します
+func retryOpen[T any](open func() (T, error), retries int, interval time.Duration) (T, error) {
+	f, err := open()
+	for i := 0; i < retries && err != nil && isSharingViolation(err); i++ {
+		time.Sleep(interval)
+		f, err = open()
+	}
+	return f, err
+}
+
+// retryingReader は ctx.FileReader を、共有違反で開けなかった場合に開き直すようにします
+func retryingReader(open func(string) (io.ReadCloser, error), retries int, interval time.Duration) func(string) (io.ReadCloser, error) {
+	return func(path string) (io.ReadCloser, error) {
+		return retryOpen(func() (io.ReadCloser, error) { return open(path) }, retries, interval)
+	}
+}
+
+// retryingDirFS は ctx.DirFS を、共有違反で開けなかったファイルを開き直すようにします
+func retryingDirFS(dirFS func(string) fs.FS, retries int, interval time.Duration) func(string) fs.FS {
+	return func(dir string) fs.FS {
+		return retryFS{FS: dirFS(dir), retries: retries, interval: interval}
+	}
+}
+
+// retryFS は共有違反で開けなかったファイルを開き直す fs.FS です
+type retryFS struct {
+	fs.FS
+	retries  int
+	interval time.Duration
+}
+
+func (r retryFS) Open(name string) (fs.File, error) {
+	return retryOpen(func() (fs.File, error) { return r.FS.Open(name) }, r.retries, r.interval)
+}
@@ -0,0 +1,16 @@
+//go:build !windows
+
+package main
+
+import (
+	"os"
+	"syscall"
+)
+
+// sharingViolations は他のプロセスがファイルを使用中であることを表すエラーです(Windows 以外には共有違反はない)
+var sharingViolations = []error{syscall.EBUSY}
+
+// openShared は Windows 以外では os.Open と同じです(ファイルを開く際に共有モードの制約がない)
+func openShared(path string) (*os.File, error) {
+	return os.Open(path)
+}
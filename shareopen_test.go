@@ -0,0 +1,80 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+// lockedOpener は最初の locked 回は共有違反で失敗する FileReader です(他のプロセスが開いているログを模す)
+func lockedOpener(locked int, content string) (func(string) (io.ReadCloser, error), *int) {
+	calls := 0
+	return func(path string) (io.ReadCloser, error) {
+		calls++
+		if calls <= locked {
+			return nil, &fs.PathError{Op: "open", Path: path, Err: sharingViolations[0]}
+		}
+		return io.NopCloser(strings.NewReader(content)), nil
+	}, &calls
+}
+
+func TestRetryOpen(t *testing.T) {
+	open, calls := lockedOpener(2, "")
+	if _, err := retryingReader(open, 2, 0)("a.log"); err != nil {
+		t.Errorf("2 retries: err = %v", err)
+	}
+	if *calls != 3 {
+		t.Errorf("calls = %d, want 3", *calls)
+	}
+
+	open, _ = lockedOpener(2, "")
+	if _, err := retryingReader(open, 1, 0)("a.log"); !isSharingViolation(err) {
+		t.Errorf("1 retry: err = %v, want a sharing violation", err)
+	}
+
+	// 共有違反以外のエラーは開き直さない
+	notFound := 0
+	_, err := retryingReader(func(string) (io.ReadCloser, error) {
+		notFound++
+		return nil, fs.ErrNotExist
+	}, 3, 0)("a.log")
+	if !errors.Is(err, fs.ErrNotExist) || notFound != 1 {
+		t.Errorf("err = %v after %d calls, want ErrNotExist after 1", err, notFound)
+	}
+}
+
+func TestSharedDirFS(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "sub"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"a.log", "sub/b.log"} {
+		if err := os.WriteFile(filepath.Join(dir, filepath.FromSlash(name)), []byte("WARN\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := fstest.TestFS(sharedDirFS(dir), "a.log", "sub/b.log"); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRun_RetryOpen(t *testing.T) {
+	for _, tt := range []struct {
+		args []string
+		want int
+	}{
+		{[]string{"app", "a.log"}, ExitError},
+		{[]string{"app", "-retry-open", "1", "a.log"}, ExitMatch},
+	} {
+		open, _ := lockedOpener(1, "WARN a\n")
+		code := Run(AppContext{Args: tt.args, ExecPath: "app_WARN", Stdout: io.Discard, Stderr: io.Discard, FileReader: open})
+		if code != tt.want {
+			t.Errorf("%v: exit code = %d, want %d", tt.args, code, tt.want)
+		}
+	}
+}
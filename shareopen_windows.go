@@ -0,0 +1,29 @@
+//go:build windows
+
+package main
+
+import (
+	"os"
+	"syscall"
+)
+
+// sharingViolations は他のプロセスがファイルを開いている(ロックしている)ことを表すエラーです
+var sharingViolations = []error{
+	syscall.Errno(32), // ERROR_SHARING_VIOLATION
+	syscall.Errno(33), // ERROR_LOCK_VIOLATION
+}
+
+// openShared は読み込み・書き込み・削除のすべてを共有してファイルを読み込み用に開きます
+func openShared(path string) (*os.File, error) {
+	p, err := syscall.UTF16PtrFromString(path)
+	if err != nil {
+		return nil, &os.PathError{Op: "open", Path: path, Err: err}
+	}
+	h, err := syscall.CreateFile(p, syscall.GENERIC_READ,
+		syscall.FILE_SHARE_READ|syscall.FILE_SHARE_WRITE|syscall.FILE_SHARE_DELETE,
+		nil, syscall.OPEN_EXISTING, syscall.FILE_ATTRIBUTE_NORMAL, 0)
+	if err != nil {
+		return nil, &os.PathError{Op: "open", Path: path, Err: err}
+	}
+	return os.NewFile(uintptr(h), path), nil
+}
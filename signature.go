@@ -0,0 +1,141 @@
+package main
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// 署名方式
+const (
+	SignEd25519 = "ed25519"
+	SignECDSA   = "ecdsa-sha256"
+	SignRSA     = "rsa-sha256"
+	SignHMAC    = "hmac-sha256"
+)
+
+// SignatureExt は分離署名ファイルの拡張子です
+const SignatureExt = ".sig"
+
+// SignReport はレポートの分離署名を "方式 base64署名" の1行で返します。
+// key がPEM形式の秘密鍵(PKCS#8)なら公開鍵署名、PEMでなければ共有鍵としてHMACを計算します。
+func SignReport(report, key []byte) (string, error) {
+	block, _ := pem.Decode(key)
+	if block == nil {
+		return formatSignature(SignHMAC, reportHMAC(report, key)), nil
+	}
+
+	priv, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return "", fmt.Errorf("invalid private key: %w", err)
+	}
+
+	digest := sha256.Sum256(report)
+	switch k := priv.(type) {
+	case ed25519.PrivateKey:
+		return formatSignature(SignEd25519, ed25519.Sign(k, report)), nil
+	case *ecdsa.PrivateKey:
+		sig, err := ecdsa.SignASN1(rand.Reader, k, digest[:])
+		if err != nil {
+			return "", err
+		}
+		return formatSignature(SignECDSA, sig), nil
+	case *rsa.PrivateKey:
+		sig, err := rsa.SignPKCS1v15(rand.Reader, k, crypto.SHA256, digest[:])
+		if err != nil {
+			return "", err
+		}
+		return formatSignature(SignRSA, sig), nil
+	default:
+		return "", fmt.Errorf("unsupported private key type: %T", priv)
+	}
+}
+
+// VerifyReport はレポートが署名時から改変されていないかを確認します。
+// key には公開鍵(PEM)、署名に使った秘密鍵、またはHMACの共有鍵を指定します。
+// 方式は署名ではなく key から決めます。PEM形式の鍵ではHMACの署名を受け付けません
+// (公開された検証用の鍵を共有鍵としてHMACを計算すれば、誰でも署名を偽造できるため)。
+func VerifyReport(report []byte, signature string, key []byte) error {
+	alg, encoded, ok := strings.Cut(strings.TrimSpace(signature), " ")
+	if !ok {
+		return errors.New("invalid signature format")
+	}
+	sig, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return fmt.Errorf("invalid signature encoding: %w", err)
+	}
+
+	if block, _ := pem.Decode(key); block == nil {
+		if alg != SignHMAC {
+			return fmt.Errorf("%s signature requires a PEM key, not an HMAC secret", alg)
+		}
+		if !hmac.Equal(sig, reportHMAC(report, key)) {
+			return errors.New("signature mismatch")
+		}
+		return nil
+	}
+
+	if alg == SignHMAC {
+		return errors.New("HMAC signature cannot be verified with a PEM key")
+	}
+	pub, err := parsePublicKey(key)
+	if err != nil {
+		return err
+	}
+	digest := sha256.Sum256(report)
+	valid := false
+	switch k := pub.(type) {
+	case ed25519.PublicKey:
+		valid = alg == SignEd25519 && ed25519.Verify(k, report, sig)
+	case *ecdsa.PublicKey:
+		valid = alg == SignECDSA && ecdsa.VerifyASN1(k, digest[:], sig)
+	case *rsa.PublicKey:
+		valid = alg == SignRSA && rsa.VerifyPKCS1v15(k, crypto.SHA256, digest[:], sig) == nil
+	default:
+		return fmt.Errorf("unsupported public key type: %T", pub)
+	}
+	if !valid {
+		return errors.New("signature mismatch")
+	}
+	return nil
+}
+
+// parsePublicKey はPEM形式の公開鍵(または秘密鍵から導出した公開鍵)を返します
+func parsePublicKey(key []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(key)
+	if block == nil {
+		return nil, errors.New("key is not in PEM format")
+	}
+	if block.Type == "PUBLIC KEY" {
+		return x509.ParsePKIXPublicKey(block.Bytes)
+	}
+	priv, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid key: %w", err)
+	}
+	signer, ok := priv.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported private key type: %T", priv)
+	}
+	return signer.Public(), nil
+}
+
+func reportHMAC(report, key []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(report)
+	return mac.Sum(nil)
+}
+
+func formatSignature(alg string, sig []byte) string {
+	return alg + " " + base64.StdEncoding.EncodeToString(sig) + "\n"
+}
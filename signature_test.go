@@ -0,0 +1,66 @@
+package main
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"strings"
+	"testing"
+)
+
+// TestSignReport は署名したレポートが検証でき、改変すると検証に失敗するか確認します
+func TestSignReport(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, _ := x509.MarshalPKCS8PrivateKey(priv)
+	privPEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+	pubDER, _ := x509.MarshalPKIXPublicKey(pub)
+	pubPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER})
+
+	report := []byte(`{"schema":"objis/v2","results":[]}` + "\n")
+	tampered := []byte(`{"schema":"objis/v2","results":[{}]}` + "\n")
+
+	tests := []struct {
+		name    string
+		signKey []byte
+		verKey  []byte
+		alg     string
+	}{
+		{"ed25519", privPEM, pubPEM, SignEd25519},
+		{"hmac", []byte("shared-secret"), []byte("shared-secret"), SignHMAC},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sig, err := SignReport(report, tt.signKey)
+			if err != nil {
+				t.Fatalf("SignReport() error = %v", err)
+			}
+			if !strings.HasPrefix(sig, tt.alg+" ") {
+				t.Errorf("Signature = %q, want %s prefix", sig, tt.alg)
+			}
+			if err := VerifyReport(report, sig, tt.verKey); err != nil {
+				t.Errorf("VerifyReport() error = %v", err)
+			}
+			if err := VerifyReport(tampered, sig, tt.verKey); err == nil {
+				t.Error("VerifyReport() should reject a modified report")
+			}
+		})
+	}
+
+	// 公開鍵を共有鍵として計算したHMACの署名は偽造なので受け付けない
+	for _, key := range [][]byte{pubPEM, privPEM} {
+		forged := formatSignature(SignHMAC, reportHMAC(report, key))
+		if err := VerifyReport(report, forged, key); err == nil {
+			t.Error("VerifyReport() should reject an HMAC signature computed with the PEM key")
+		}
+	}
+	// 共有鍵では公開鍵署名の方式を受け付けない
+	sig, _ := SignReport(report, privPEM)
+	if err := VerifyReport(report, sig, []byte("shared-secret")); err == nil {
+		t.Error("VerifyReport() should reject an ed25519 signature with an HMAC secret")
+	}
+}
@@ -0,0 +1,228 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"sort"
+	"strings"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/transform"
+)
+
+// SimulateCommand は変換の往復を試すサブコマンドの名前です(app simulate -via sjis FILE...)
+const SimulateCommand = "simulate"
+
+// RoundTripChange は変換先の文字コードを往復すると変わる、または失われる1文字の出現状況です
+type RoundTripChange struct {
+	Char   string         `json:"char"`
+	Code   string         `json:"code"`
+	Result string         `json:"result,omitempty"` // 読み戻した文字(失われる場合は空)
+	Lost   bool           `json:"lost"`             // 変換先の文字コードで表現できない
+	Count  int            `json:"count"`
+	Files  []CharLocation `json:"files"` // ファイルごとの初出位置(走査順)
+}
+
+// RoundTripSimulator は入力を変換先の文字コードに変換して読み戻した場合に、元と異なる結果になる文字を集めます。
+// CharInventory と同じく各文字は一度だけ記録し、ファイルごとの初出位置のみを保持します。
+type RoundTripSimulator struct {
+	encoder  *encoding.Encoder
+	decoder  *encoding.Decoder
+	checked  map[rune]*RoundTripChange // 文字ごとの判定結果のキャッシュ(nil なら変わらない)
+	entries  map[rune]*RoundTripChange
+	lastPath map[rune]string // 文字ごとに最後に初出を記録したファイル
+}
+
+// NewRoundTripSimulator は enc を往復させる RoundTripSimulator を生成します
+func NewRoundTripSimulator(enc encoding.Encoding) *RoundTripSimulator {
+	return &RoundTripSimulator{
+		encoder:  enc.NewEncoder(),
+		decoder:  enc.NewDecoder(),
+		checked:  make(map[rune]*RoundTripChange),
+		entries:  make(map[rune]*RoundTripChange),
+		lastPath: make(map[rune]string),
+	}
+}
+
+// Scan は1ファイル分の入力(UTF-8)を走査して、往復で変わる文字を記録します
+func (s *RoundTripSimulator) Scan(r io.Reader, path string) error {
+	scanner := newLineReader(r)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := scanner.Text()
+		if lineNum == 1 {
+			line = strings.TrimPrefix(line, utf8BOM)
+		}
+		col := 0
+		for _, c := range line {
+			col++
+			change := s.roundTrip(c)
+			if change == nil {
+				continue
+			}
+
+			entry, ok := s.entries[c]
+			if !ok {
+				entry = change
+				s.entries[c] = entry
+			}
+			entry.Count++
+			if s.lastPath[c] != path {
+				s.lastPath[c] = path
+				entry.Files = append(entry.Files, CharLocation{Path: path, Line: lineNum, Col: col})
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("error reading stream: %w", err)
+	}
+	return nil
+}
+
+// roundTrip は文字を変換して読み戻し、元と異なる場合はその結果を返します(変わらない場合は nil)
+func (s *RoundTripSimulator) roundTrip(c rune) *RoundTripChange {
+	change, ok := s.checked[c]
+	if ok {
+		return change
+	}
+	change = &RoundTripChange{Char: string(c), Code: fmt.Sprintf("U+%04X", c)}
+	encoded, err := s.encoder.String(string(c))
+	if err == nil {
+		var decoded string
+		decoded, err = s.decoder.String(encoded)
+		change.Result = decoded
+	}
+	switch {
+	case err != nil:
+		change.Result = ""
+		change.Lost = true
+	case change.Result == change.Char:
+		change = nil
+	}
+	s.checked[c] = change
+	return change
+}
+
+// Entries は記録した文字をコードポイント順に返します
+func (s *RoundTripSimulator) Entries() []*RoundTripChange {
+	runes := make([]rune, 0, len(s.entries))
+	for c := range s.entries {
+		runes = append(runes, c)
+	}
+	sort.Slice(runes, func(i, j int) bool { return runes[i] < runes[j] })
+
+	entries := make([]*RoundTripChange, len(runes))
+	for i, c := range runes {
+		entries[i] = s.entries[c]
+	}
+	return entries
+}
+
+// WriteRoundTrip は往復で変わる文字の一覧を出力します。
+// テキスト形式では1文字1行で "U+9DD7 鷗 → 変換不可 3件: a.log:2:1" のように出力します。
+// 文字は制御文字等をエスケープして表示します。
+func WriteRoundTrip(w io.Writer, via string, entries []*RoundTripChange, format string) error {
+	if format == FormatJSON || format == FormatJSONL {
+		enc := json.NewEncoder(w)
+		if format == FormatJSON {
+			enc.SetIndent("", "  ")
+			return enc.Encode(struct {
+				Schema string             `json:"schema"`
+				Via    string             `json:"via"`
+				Chars  []*RoundTripChange `json:"chars"`
+			}{SchemaVersion, via, entries})
+		}
+		for _, e := range entries {
+			if err := enc.Encode(e); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	for _, e := range entries {
+		result := "変換不可"
+		if !e.Lost {
+			result = strings.TrimSpace(fmt.Sprintf("%s %s", runeCodes(e.Result), EscapeSnippet(e.Result, EscapeNonPrintable)))
+		}
+		locs := make([]string, len(e.Files))
+		for i, loc := range e.Files {
+			locs[i] = fmt.Sprintf("%s:%d:%d", loc.Path, loc.Line, loc.Col)
+		}
+		if _, err := fmt.Fprintf(w, "%s %s → %s %d件: %s\n", e.Code, EscapeSnippet(e.Char, EscapeNonPrintable), result, e.Count, strings.Join(locs, ", ")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runeCodes は文字列の各文字のコードポイントを "U+XXXX U+YYYY" の形式で返します
+func runeCodes(s string) string {
+	codes := make([]string, 0, len(s))
+	for _, r := range s {
+		codes = append(codes, fmt.Sprintf("U+%04X", r))
+	}
+	return strings.Join(codes, " ")
+}
+
+// runSimulate は simulate サブコマンドを実行します。
+// 入力を -enc で読み、-via の文字コードに変換して読み戻した場合に変わる・失われる文字を一覧にします。
+// 実際の変換の前に「この変換で何が起きるか」を確かめるためのもので、入力は変更しません。
+func runSimulate(ctx AppContext, logger *slog.Logger, args []string) int {
+	fs := flag.NewFlagSet(SimulateCommand, flag.ContinueOnError)
+	via := fs.String("via", "", "Target encoding to round-trip through (sjis|sjis2004|eucjp|iso2022jp|...)")
+	inputEnc := fs.String("enc", "", "Input file encoding (sjis|sjis2004|eucjp|iso2022jp|utf-16le|utf-16be); default UTF-8")
+	format := fs.String("format", FormatText, "Output format (text|json|jsonl)")
+	if err := fs.Parse(args); err != nil {
+		return ExitError
+	}
+
+	if *via == "" {
+		logger.Error("simulate requires -via with the target encoding", "code", CodeUsage)
+		return ExitError
+	}
+	enc, err := LookupEncoding(*via)
+	if err != nil {
+		logger.Error("Invalid -via encoding", "code", CodeInvalidOption, "error", err)
+		return ExitError
+	}
+	var decoder encoding.Encoding
+	if *inputEnc != "" && *inputEnc != encodingUTF8 {
+		if decoder, err = LookupEncoding(*inputEnc); err != nil {
+			logger.Error("Invalid input encoding", "code", CodeInvalidOption, "error", err)
+			return ExitError
+		}
+	}
+	if fs.NArg() == 0 {
+		logger.Error("input file path is required", "code", CodeUsage)
+		return ExitError
+	}
+
+	sim := NewRoundTripSimulator(enc)
+	for _, path := range fs.Args() {
+		f, err := openInput(ctx, path)
+		if err != nil {
+			logger.Error("Failed to open input file", "code", CodeOpenFailed, "path", path, "error", err)
+			return ExitError
+		}
+		var r io.Reader = f
+		if decoder != nil {
+			r = transform.NewReader(f, decoder.NewDecoder())
+		}
+		err = sim.Scan(r, path)
+		f.Close()
+		if err != nil {
+			logger.Error("Simulation scan failed", "code", CodeSearchFailed, "path", path, "error", err)
+			return ExitError
+		}
+	}
+	if err := WriteRoundTrip(ctx.Stdout, strings.ToLower(*via), sim.Entries(), *format); err != nil {
+		logger.Error("Failed to write results", "code", CodeWriteFailed, "error", err)
+		return ExitError
+	}
+	return 0
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"golang.org/x/text/encoding/japanese"
+)
+
+// TestRoundTripSimulator は往復で失われる文字と変わる文字が、ファイルごとの初出位置付きで記録されるか確認します
+func TestRoundTripSimulator(t *testing.T) {
+	sim := NewRoundTripSimulator(japanese.ISO2022JP)
+	if err := sim.Scan(strings.NewReader("高橋\n鷗外 \x1b\n鷗\n"), "a.log"); err != nil {
+		t.Fatal(err)
+	}
+	if err := sim.Scan(strings.NewReader("x 鷗\n"), "b.log"); err != nil {
+		t.Fatal(err)
+	}
+
+	out := new(bytes.Buffer)
+	if err := WriteRoundTrip(out, "iso2022jp", sim.Entries(), FormatText); err != nil {
+		t.Fatal(err)
+	}
+	want := "U+001B \\u001B → U+FFFD � 1件: a.log:2:4\n" +
+		"U+9DD7 鷗 → 変換不可 3件: a.log:2:1, b.log:1:3\n"
+	if out.String() != want {
+		t.Errorf("Round trip mismatch.\nGot:\n%s\nWant:\n%s", out.String(), want)
+	}
+}
+
+// TestRun_Simulate は simulate サブコマンドが -enc で読んだ入力を -via の文字コードで往復させるか確認します
+func TestRun_Simulate(t *testing.T) {
+	input, _ := japanese.EUCJP.NewEncoder().String("髙橋と鷗外\n")
+	stdout := new(bytes.Buffer)
+	code := Run(AppContext{
+		Args:       []string{"app", "simulate", "-via", "sjis", "-enc", "eucjp", "-format", "jsonl", "input.log"},
+		ExecPath:   "app_WARN",
+		Stdout:     stdout,
+		Stderr:     io.Discard,
+		FileReader: func(path string) (io.ReadCloser, error) { return io.NopCloser(strings.NewReader(input)), nil },
+	})
+	if code != 0 {
+		t.Fatalf("Run() exit code = %d", code)
+	}
+	want := `{"char":"鷗","code":"U+9DD7","lost":true,"count":1,"files":[{"path":"input.log","line":1,"col":4}]}` + "\n"
+	if stdout.String() != want {
+		t.Errorf("Output = %s, want %s", stdout.String(), want)
+	}
+
+	if code := Run(AppContext{Args: []string{"app", "simulate", "input.log"}, Stdout: io.Discard, Stderr: io.Discard}); code != ExitError {
+		t.Errorf("Run() without -via exit code = %d, want %d", code, ExitError)
+	}
+}
@@ -0,0 +1,197 @@
+package main
+
+//go:generate sh -c "python3 tools/gen_sjis2004.py > sjis2004_table.go"
+
+import (
+	"errors"
+	"sync"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/transform"
+)
+
+// ShiftJIS2004 は Shift_JIS-2004 (JIS X 0213:2004 の Shift_JIS 表現)です。
+// 0x00-0x7F は CP932 と同様にASCIIとして扱います(0x5C は円記号ではなくバックスラッシュ)。
+var ShiftJIS2004 encoding.Encoding = sjis2004Encoding{}
+
+type sjis2004Encoding struct{}
+
+func (sjis2004Encoding) NewDecoder() *encoding.Decoder {
+	return &encoding.Decoder{Transformer: &sjis2004Decoder{}}
+}
+
+func (sjis2004Encoding) NewEncoder() *encoding.Encoder {
+	return &encoding.Encoder{Transformer: &sjis2004Encoder{}}
+}
+
+func (sjis2004Encoding) String() string { return "Shift_JIS-2004" }
+
+// sjis2004Index は2バイト文字の対応表の添字を返します(範囲外なら -1)
+func sjis2004Index(lead, trail byte) int {
+	var row int
+	switch {
+	case lead >= 0x81 && lead <= 0x9F:
+		row = int(lead - 0x81)
+	case lead >= 0xE0 && lead <= 0xFC:
+		row = int(lead-0xE0) + 0x1F
+	default:
+		return -1
+	}
+	if trail < 0x40 || trail > 0xFC {
+		return -1
+	}
+	return row*0xBD + int(trail-0x40)
+}
+
+type sjis2004Decoder struct{ transform.NopResetter }
+
+func (d *sjis2004Decoder) Transform(dst, src []byte, atEOF bool) (nDst, nSrc int, err error) {
+	for nSrc < len(src) {
+		b := src[nSrc]
+		var runes [2]rune
+		n, size := 1, 1
+
+		switch {
+		case b < utf8.RuneSelf:
+			runes[0] = rune(b)
+		case b >= 0xA1 && b <= 0xDF:
+			// 半角カタカナ
+			runes[0] = 0xFF61 + rune(b-0xA1)
+		case sjis2004Index(b, 0x40) >= 0:
+			if nSrc+1 >= len(src) {
+				if !atEOF {
+					return nDst, nSrc, transform.ErrShortSrc
+				}
+				runes[0] = utf8.RuneError
+				break
+			}
+			trail := src[nSrc+1]
+			i := sjis2004Index(b, trail)
+			if i < 0 {
+				runes[0] = utf8.RuneError
+				break
+			}
+			size = 2
+			if pair, ok := sjis2004Pairs[uint16(b)<<8|uint16(trail)]; ok {
+				runes, n = pair, 2
+			} else if r := sjis2004Table[i]; r != 0 {
+				runes[0] = r
+			} else {
+				runes[0] = utf8.RuneError
+			}
+		default:
+			runes[0] = utf8.RuneError
+		}
+
+		need := 0
+		for _, r := range runes[:n] {
+			need += utf8.RuneLen(r)
+		}
+		if nDst+need > len(dst) {
+			return nDst, nSrc, transform.ErrShortDst
+		}
+		for _, r := range runes[:n] {
+			nDst += utf8.EncodeRune(dst[nDst:], r)
+		}
+		nSrc += size
+	}
+	return nDst, nSrc, nil
+}
+
+var (
+	sjis2004EncodeOnce  sync.Once
+	sjis2004EncodeTable map[rune]uint16
+	sjis2004EncodePairs map[[2]rune]uint16
+)
+
+// buildSjis2004EncodeTable は文字から符号位置への逆引き表を作成します
+func buildSjis2004EncodeTable() {
+	sjis2004EncodeTable = make(map[rune]uint16, len(sjis2004Table))
+	for i, r := range sjis2004Table {
+		if r == 0 {
+			continue
+		}
+		row, col := i/0xBD, i%0xBD
+		lead := byte(0x81 + row)
+		if row >= 0x1F {
+			lead = byte(0xE0 + row - 0x1F)
+		}
+		// 同じ文字に複数の符号位置がある場合は先に現れたものを使う
+		if _, ok := sjis2004EncodeTable[r]; !ok {
+			sjis2004EncodeTable[r] = uint16(lead)<<8 | uint16(0x40+col)
+		}
+	}
+	sjis2004EncodePairs = make(map[[2]rune]uint16, len(sjis2004Pairs))
+	for code, pair := range sjis2004Pairs {
+		sjis2004EncodePairs[pair] = code
+	}
+}
+
+// errSjis2004Unsupported は Shift_JIS-2004 で表現できない文字を変換しようとしたことを表します
+var errSjis2004Unsupported = errors.New("encoding: rune not supported by Shift_JIS-2004")
+
+type sjis2004Encoder struct{ transform.NopResetter }
+
+func (e *sjis2004Encoder) Transform(dst, src []byte, atEOF bool) (nDst, nSrc int, err error) {
+	sjis2004EncodeOnce.Do(buildSjis2004EncodeTable)
+
+	for nSrc < len(src) {
+		r, size := utf8.DecodeRune(src[nSrc:])
+		if r == utf8.RuneError && size <= 1 && !atEOF && !utf8.FullRune(src[nSrc:]) {
+			return nDst, nSrc, transform.ErrShortSrc
+		}
+
+		var code uint16
+		switch {
+		case r < utf8.RuneSelf:
+			code = uint16(r)
+		case r >= 0xFF61 && r <= 0xFF9F:
+			code = uint16(0xA1 + r - 0xFF61)
+		default:
+			// 結合文字と組になる文字は次の文字を確認してから決める
+			rest := src[nSrc+size:]
+			if len(rest) == 0 && !atEOF && sjis2004IsPairBase(r) {
+				return nDst, nSrc, transform.ErrShortSrc
+			}
+			if next, nextSize := utf8.DecodeRune(rest); len(rest) > 0 {
+				if c, ok := sjis2004EncodePairs[[2]rune{r, next}]; ok {
+					code = c
+					size += nextSize
+					break
+				}
+			}
+			c, ok := sjis2004EncodeTable[r]
+			if !ok {
+				return nDst, nSrc, errSjis2004Unsupported
+			}
+			code = c
+		}
+
+		if code < 0x100 {
+			if nDst >= len(dst) {
+				return nDst, nSrc, transform.ErrShortDst
+			}
+			dst[nDst] = byte(code)
+			nDst++
+		} else {
+			if nDst+2 > len(dst) {
+				return nDst, nSrc, transform.ErrShortDst
+			}
+			dst[nDst], dst[nDst+1] = byte(code>>8), byte(code)
+			nDst += 2
+		}
+		nSrc += size
+	}
+	return nDst, nSrc, nil
+}
+
+// sjis2004IsPairBase は r が結合文字との組で1つの符号位置になり得るかを返します
+func sjis2004IsPairBase(r rune) bool {
+	for pair := range sjis2004EncodePairs {
+		if pair[0] == r {
+			return true
+		}
+	}
+	return false
+}
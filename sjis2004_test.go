@@ -0,0 +1,47 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// TestShiftJIS2004 は JIS X 0213 の文字(第3・4水準、結合文字の組、半角カナ)が往復変換できるか確認します
+func TestShiftJIS2004(t *testing.T) {
+	raw := []byte("\xef\xe3\x8a\x4f \x82\xf5 \x87\xa0 \xb1\x5c")
+	text := "鷗外 か゚ 𠀋 ｱ\\"
+
+	decoded, err := ShiftJIS2004.NewDecoder().Bytes(raw)
+	if err != nil {
+		t.Fatalf("Decode error = %v", err)
+	}
+	if string(decoded) != text {
+		t.Errorf("Decoded = %q, want %q", decoded, text)
+	}
+
+	encoded, err := ShiftJIS2004.NewEncoder().String(text)
+	if err != nil {
+		t.Fatalf("Encode error = %v", err)
+	}
+	if !bytes.Equal([]byte(encoded), raw) {
+		t.Errorf("Encoded = % x, want % x", encoded, raw)
+	}
+
+	// JIS X 0213 にない文字(IBM拡張の髙)は変換できない
+	if got := SimulateConversion("髙橋", ShiftJIS2004); got != "〓橋" {
+		t.Errorf("SimulateConversion() = %q, want 〓橋", got)
+	}
+}
+
+// TestSearchShiftJIS2004Input は Shift_JIS-2004 の入力を検索し、UTF-8のスニペットを返すか確認します
+func TestSearchShiftJIS2004Input(t *testing.T) {
+	input := strings.Repeat("\x82\xa0", 3) + "\xef\xe3\x8a\x4f\n"
+	results, err := SearchStreamWithOptions(strings.NewReader(input), []string{"鷗"},
+		SearchOptions{ContextSize: 1, InputEncoding: "sjis2004"})
+	if err != nil {
+		t.Fatalf("SearchStreamWithOptions() error = %v", err)
+	}
+	if res := results["鷗"]; res.Count != 1 || res.Snippets[0] != "あ鷗外" {
+		t.Errorf("Result = %d %q, want 1 hit with snippet あ鷗外", res.Count, res.Snippets)
+	}
+}
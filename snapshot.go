@@ -0,0 +1,135 @@
+package main
+
+import (
+	"io"
+	"io/fs"
+	"os"
+	"sync"
+)
+
+// takeSnapshot は src を一時ディレクトリ(TMPDIR 等)にコピーし、コピーのパスを返します。
+// src のサイズが分かる場合はその時点のサイズまでをコピーするため、書き込み中のファイルでも開いた時点の内容で検索できます。
+// src は閉じません。
+func takeSnapshot(src io.Reader) (string, error) {
+	tmp, err := os.CreateTemp("", "objis-snapshot-*")
+	if err != nil {
+		return "", err
+	}
+	if file, ok := src.(interface{ Stat() (fs.FileInfo, error) }); ok {
+		if info, err := file.Stat(); err == nil && info.Mode().IsRegular() {
+			src = io.LimitReader(src, info.Size())
+		}
+	}
+	_, err = io.Copy(tmp, src)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return "", err
+	}
+	return tmp.Name(), nil
+}
+
+// inputSnapshots は -snapshot で作成した入力ファイルのコピーです。
+// 入力ごとに最初に開いた時点でコピーし、以降はハッシュの計算・照合・走査のいずれも同じコピーを開くため、
+// 書き込み中のファイルでも一貫した内容を検索できます。
+// Windows のボリュームシャドウコピー(VSS)は使わず、どの環境でも通常のコピーを作成します。
+type inputSnapshots struct {
+	open   func(string) (io.ReadCloser, error)
+	inputs map[string]bool
+
+	mu     sync.Mutex
+	copies map[string]string // 入力のパスからコピーのパス
+}
+
+// newInputSnapshots は inputs を開く際にコピーを開くようにする inputSnapshots を生成します(それ以外のパスは open でそのまま開く)
+func newInputSnapshots(open func(string) (io.ReadCloser, error), inputs []string) *inputSnapshots {
+	s := &inputSnapshots{open: open, inputs: make(map[string]bool, len(inputs)), copies: make(map[string]string)}
+	for _, p := range inputs {
+		s.inputs[p] = true
+	}
+	return s
+}
+
+// Open は入力であればそのコピーを、それ以外はファイルそのものを開きます
+func (s *inputSnapshots) Open(path string) (io.ReadCloser, error) {
+	if !s.inputs[path] {
+		return s.open(path)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tmp, ok := s.copies[path]
+	if !ok {
+		f, err := s.open(path)
+		if err != nil {
+			return nil, err
+		}
+		tmp, err = takeSnapshot(f)
+		f.Close()
+		if err != nil {
+			return nil, err
+		}
+		s.copies[path] = tmp
+	}
+	return os.Open(tmp)
+}
+
+// Remove は作成したコピーをすべて削除します
+func (s *inputSnapshots) Remove() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, tmp := range s.copies {
+		os.Remove(tmp)
+	}
+	s.copies = make(map[string]string)
+}
+
+// snapshotDirFS は ctx.DirFS を、ディレクトリ内の通常のファイルをコピーしてから読むようにします(-snapshot)
+func snapshotDirFS(dirFS func(string) fs.FS) func(string) fs.FS {
+	return func(dir string) fs.FS {
+		return snapshotFS{dirFS(dir)}
+	}
+}
+
+// snapshotFS は通常のファイルを一時的なコピーとして開く fs.FS です。コピーは閉じた時点で削除します。
+type snapshotFS struct {
+	fs.FS
+}
+
+func (s snapshotFS) Open(name string) (fs.File, error) {
+	f, err := s.FS.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	if !info.Mode().IsRegular() {
+		return f, nil
+	}
+	tmp, err := takeSnapshot(f)
+	f.Close()
+	if err != nil {
+		return nil, err
+	}
+	snap, err := os.Open(tmp)
+	if err != nil {
+		os.Remove(tmp)
+		return nil, err
+	}
+	return snapshotFile{snap}, nil
+}
+
+// snapshotFile はディレクトリ内のファイルの一時的なコピーです。閉じるとコピーを削除します。
+type snapshotFile struct {
+	*os.File
+}
+
+func (s snapshotFile) Close() error {
+	err := s.File.Close()
+	os.Remove(s.Name())
+	return err
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestInputSnapshots(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	if err := os.WriteFile(path, []byte("WARN 1\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	open := func(p string) (io.ReadCloser, error) { return os.Open(p) }
+	snaps := newInputSnapshots(open, []string{path})
+
+	read := func(p string) string {
+		t.Helper()
+		f, err := snaps.Open(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+		b, err := io.ReadAll(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(b)
+	}
+	if got := read(path); got != "WARN 1\n" {
+		t.Fatalf("first read = %q", got)
+	}
+
+	// コピーした後の追記は、同じ実行の中で開き直しても見えない
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteString("WARN 2\n")
+	f.Close()
+	if got := read(path); got != "WARN 1\n" {
+		t.Errorf("second read = %q, want the snapshot", got)
+	}
+
+	tmp := snaps.copies[path]
+	snaps.Remove()
+	if _, err := os.Stat(tmp); !os.IsNotExist(err) {
+		t.Errorf("snapshot %s was not removed: %v", tmp, err)
+	}
+}
+
+func TestSnapshotFS(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "a.log"), []byte("WARN\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	fsys := snapshotDirFS(os.DirFS)(dir)
+	f, err := fsys.Open("a.log")
+	if err != nil {
+		t.Fatal(err)
+	}
+	snap, ok := f.(snapshotFile)
+	if !ok {
+		t.Fatalf("Open() = %T, want a snapshot", f)
+	}
+	if b, _ := io.ReadAll(f); string(b) != "WARN\n" {
+		t.Errorf("content = %q", b)
+	}
+	f.Close()
+	if _, err := os.Stat(snap.Name()); !os.IsNotExist(err) {
+		t.Errorf("snapshot %s was not removed: %v", snap.Name(), err)
+	}
+
+	names, err := WalkFiles(fsys, WalkOptions{})
+	if err != nil || len(names) != 1 {
+		t.Errorf("WalkFiles() = %v, %v", names, err)
+	}
+}
+
+func TestRun_Snapshot(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "a.log")
+	if err := os.WriteFile(path, []byte("WARN a\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	ctx := AppContext{
+		ExecPath:   "app_WARN",
+		Stdout:     io.Discard,
+		Stderr:     io.Discard,
+		FileReader: func(p string) (io.ReadCloser, error) { return os.Open(p) },
+		FileStat:   os.Stat,
+		DirFS:      os.DirFS,
+	}
+	for _, tt := range []struct {
+		args []string
+		want int
+	}{
+		{[]string{"app", "-snapshot", path}, ExitMatch},
+		{[]string{"app", "-snapshot", dir}, ExitMatch},
+		{[]string{"app", "-snapshot", "-follow", path}, ExitError},
+	} {
+		ctx.Args = tt.args
+		if code := Run(ctx); code != tt.want {
+			t.Errorf("%v: exit code = %d, want %d", tt.args, code, tt.want)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package main
+
+import "unicode/utf8"
+
+// TruncationMarker は -max-snippet-bytes で切り詰めた箇所に付ける目印です
+const TruncationMarker = "…"
+
+// clampSnippet はスニペットの前・ヒット・後ろの合計が limit バイト以下になるよう切り詰めます(limit が0以下なら切り詰めない)。
+// ヒット部分を優先して残し、残りのバイト数を前後に半分ずつ割り当てます。
+// 切り詰めた側には TruncationMarker を付けます(目印のバイト数は limit に含めない)。
+func clampSnippet(pre, hit, post string, limit int) (string, string, string) {
+	if limit <= 0 || len(pre)+len(hit)+len(post) <= limit {
+		return pre, hit, post
+	}
+	if len(hit) >= limit {
+		hit = truncateTail(hit, limit)
+		if pre != "" {
+			pre = TruncationMarker
+		}
+		if post != "" {
+			post = TruncationMarker
+		}
+		return pre, hit, post
+	}
+
+	rest := limit - len(hit)
+	preLimit := max(rest/2, rest-len(post))
+	pre = truncateHead(pre, preLimit)
+	post = truncateTail(post, rest-min(len(pre), preLimit))
+	return pre, hit, post
+}
+
+// truncateHead は s の末尾 limit バイト以内を残し、切り詰めた場合は先頭に目印を付けます。文字の途中では切りません。
+func truncateHead(s string, limit int) string {
+	if len(s) <= limit {
+		return s
+	}
+	i := len(s) - limit
+	for i < len(s) && !utf8.RuneStart(s[i]) {
+		i++
+	}
+	return TruncationMarker + s[i:]
+}
+
+// truncateTail は s の先頭 limit バイト以内を残し、切り詰めた場合は末尾に目印を付けます。文字の途中では切りません。
+func truncateTail(s string, limit int) string {
+	if len(s) <= limit {
+		return s
+	}
+	i := limit
+	for i > 0 && !utf8.RuneStart(s[i]) {
+		i--
+	}
+	return s[:i] + TruncationMarker
+}
+
+// clampRaw はスニペットの元のバイト列(-hex-snippets)を先頭 limit バイトに切り詰めます(limit が0以下なら切り詰めない)
+func clampRaw(raw []byte, limit int) []byte {
+	if limit <= 0 || len(raw) <= limit {
+		return raw
+	}
+	return raw[:limit]
+}
+
+// clampLine はヒット行全体の書き出し(-lines-out)を limit バイト以内に切り詰めます(limit が0以下なら切り詰めない)
+func clampLine(line []byte, limit int) []byte {
+	if limit <= 0 || len(line) <= limit {
+		return line
+	}
+	i := limit
+	for i > 0 && !utf8.RuneStart(line[i]) {
+		i--
+	}
+	return append(line[:i:i], TruncationMarker...)
+}
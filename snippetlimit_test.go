@@ -0,0 +1,58 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestClampSnippet はヒット部分を優先し、文字の途中で切らずに上限まで切り詰めるか確認します
+func TestClampSnippet(t *testing.T) {
+	tests := []struct {
+		name           string
+		pre, hit, post string
+		limit          int
+		want           [3]string
+	}{
+		{"WithinLimit", "ab", "髙", "cd", 7, [3]string{"ab", "髙", "cd"}},
+		{"Unlimited", strings.Repeat("a", 100), "髙", "", 0, [3]string{strings.Repeat("a", 100), "髙", ""}},
+		{"BothSides", "abcdef", "髙", "ghijkl", 9, [3]string{"…def", "髙", "ghi…"}},
+		{"ShortPost", "abcdef", "髙", "g", 8, [3]string{"…cdef", "髙", "g"}},
+		{"RuneBoundary", "あいう", "X", "えおか", 8, [3]string{"…う", "X", "え…"}},
+		{"LongHit", "a", "髙橋髙橋", "b", 7, [3]string{"…", "髙橋…", "…"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pre, hit, post := clampSnippet(tt.pre, tt.hit, tt.post, tt.limit)
+			if got := [3]string{pre, hit, post}; got != tt.want {
+				t.Errorf("clampSnippet() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if got := string(clampLine([]byte("髙橋様"), 7)); got != "髙橋…" {
+		t.Errorf("clampLine() = %q, want %q", got, "髙橋…")
+	}
+}
+
+// TestSearchStream_MaxSnippetBytes は1行の巨大な入力でもスニペットが上限内に収まり、ヒット位置が保たれるか確認します
+func TestSearchStream_MaxSnippetBytes(t *testing.T) {
+	line := "{" + strings.Repeat(`"k":"v",`, 1000) + `"err":"外字"` + strings.Repeat(`,"k":"v"`, 1000) + "}"
+	results, err := SearchStreamWithOptions(strings.NewReader(line+"\n"), []string{"re:\"err\":\"[^\"]*\""}, SearchOptions{
+		ContextSize:     100000,
+		MaxSnippetBytes: 32,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	res := results["re:\"err\":\"[^\"]*\""]
+	if res.Count != 1 || len(res.Snippets) != 1 {
+		t.Fatalf("Result = %d hits, %d snippets", res.Count, len(res.Snippets))
+	}
+	snippet, info := res.Snippets[0], res.Infos[0]
+	if len(snippet) > 32+2*len(TruncationMarker) || !strings.HasPrefix(snippet, TruncationMarker) || !strings.HasSuffix(snippet, TruncationMarker) {
+		t.Errorf("Snippet not clamped: %q", snippet)
+	}
+	if got := snippet[info.MatchStart:info.MatchEnd]; got != `"err":"外字"` {
+		t.Errorf("Match = %q", got)
+	}
+}
@@ -0,0 +1,386 @@
+package main
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ScanState は増分走査(--state)で、ファイルごとにどこまで走査したかを記録します。
+// 繰り返し実行しても、前回以降に追記された行のヒットのみを報告するために使います。
+type ScanState struct {
+	Files map[string]FileState `json:"files"`
+}
+
+// FileState は1ファイルの走査済みの位置です
+type FileState struct {
+	Offset   int64  `json:"offset"`             // 走査済みのバイト数(最後の改行の直後)
+	Lines    int    `json:"lines"`              // 走査済みの行数
+	Encoding string `json:"encoding,omitempty"` // 走査に使った文字コード(自動判定の結果を続きの走査でも使う)
+	Inode    uint64 `json:"inode,omitempty"`    // 走査したファイルの識別子(取得できない環境では0)
+	Size     int64  `json:"size"`               // 走査時のファイルサイズ
+	Prefix   string `json:"prefix,omitempty"`   // 走査済みの先頭(最大 statePrefixSize バイト)のSHA-256
+}
+
+// statePrefixSize は切り詰め後に同じ内容で書き直されていないかを確かめるために、ハッシュを記録する先頭のバイト数です
+const statePrefixSize = 4096
+
+// prefixLen はハッシュを記録する(照合する)先頭のバイト数です
+func (s FileState) prefixLen() int64 {
+	return min(s.Offset, statePrefixSize)
+}
+
+// prefixHash はファイルの先頭 n バイトのSHA-256を返します
+func prefixHash(ctx AppContext, path string, n int64) (string, error) {
+	f, err := ctx.FileReader(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.CopyN(h, f, n); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// LoadScanState は状態ファイルを読み込みます。ファイルが存在しなければ空の状態を返します。
+func LoadScanState(open func(string) (io.ReadCloser, error), path string) (*ScanState, error) {
+	state := &ScanState{Files: make(map[string]FileState)}
+	f, err := open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	if err := json.NewDecoder(f).Decode(state); err != nil {
+		return nil, fmt.Errorf("invalid state file: %w", err)
+	}
+	if state.Files == nil {
+		state.Files = make(map[string]FileState)
+	}
+	return state, nil
+}
+
+// Save は状態ファイルを一時ファイルに書き出してから置き換えます(書き込みに失敗しても前回の状態が残る)
+func (s *ScanState) Save(ctx AppContext, path string) error {
+	f, err := createAtomic(ctx, path)
+	if err != nil {
+		return err
+	}
+	defer f.Abort()
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(s); err != nil {
+		return err
+	}
+	return f.Commit()
+}
+
+// stateKey は状態ファイル中でファイルを識別するキー(絶対パス)を返します
+func stateKey(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+	return path
+}
+
+// resumable は前回の位置から走査を再開できるかを返します。
+// ファイルが別のもの(inodeが異なる)に置き換わったか、前回の走査時より小さくなった(切り詰められた)場合、
+// 先頭の内容(prefix は現在のファイルの先頭 prefixLen バイトのハッシュ)が前回と異なる場合は先頭から読み直します。
+// 先頭の照合により、切り詰めた後に前回の位置を超えて書き込まれた場合(copytruncate)も検出します。
+// info が nil の場合はファイルの情報を確認できないため、先頭の内容のみで判断します。
+func (s FileState) resumable(info os.FileInfo, prefix string) bool {
+	if s.Prefix != "" && prefix != s.Prefix {
+		return false
+	}
+	if info == nil {
+		return true
+	}
+	if ino := fileInode(info); s.Inode != 0 && ino != 0 && ino != s.Inode {
+		return false
+	}
+	return info.Size() >= s.Offset && info.Size() >= s.Size
+}
+
+// skipTo は入力を offset バイト目まで読み進めます。シーク可能であればシークします。
+func skipTo(r io.Reader, offset int64) error {
+	if offset == 0 {
+		return nil
+	}
+	if s, ok := r.(io.Seeker); ok {
+		_, err := s.Seek(offset, io.SeekStart)
+		return err
+	}
+	_, err := io.CopyN(io.Discard, r, offset)
+	return err
+}
+
+// completeLinesReader は改行で終わる行のみを返し、書き込み途中の最終行は次回の走査に残します
+type completeLinesReader struct {
+	r       *bufio.Reader
+	newline []byte // 入力の文字コードでの改行(UTF-16 では2バイト)
+	buf     []byte
+	read    int64 // 返した(走査済みとなる)バイト数
+}
+
+func newCompleteLinesReader(r io.Reader, encoding string) *completeLinesReader {
+	return &completeLinesReader{r: bufio.NewReader(r), newline: encodedNewline(encoding)}
+}
+
+func (c *completeLinesReader) Read(p []byte) (int, error) {
+	if len(c.buf) == 0 {
+		line, err := c.readLine()
+		if err != nil {
+			// 改行のない最終行は読まなかったことにする
+			return 0, err
+		}
+		c.buf = line
+	}
+	n := copy(p, c.buf)
+	c.buf = c.buf[n:]
+	c.read += int64(n)
+	return n, nil
+}
+
+// readLine は行の区切り(LF・CRLF・CR。行単位の走査と同じ)までの1行を読みます。
+// UTF-16 では符号単位の境界にある改行のみを行の終わりとします。
+// 読める範囲の末尾がCRの場合は、続けてLFが書き込まれるか分からないため書き込み途中として扱います。
+func (c *completeLinesReader) readLine() ([]byte, error) {
+	unit := len(c.newline)
+	var line []byte
+	for {
+		if _, err := c.r.Peek(unit); err != nil {
+			return nil, err
+		}
+		buf, _ := c.r.Peek(c.r.Buffered() / unit * unit)
+		for i := 0; i < len(buf); i += unit {
+			switch c.char(buf[i:]) {
+			case '\n':
+				line = append(line, buf[:i+unit]...)
+				c.r.Discard(i + unit)
+				return line, nil
+			case '\r':
+				line = append(line, buf[:i+unit]...)
+				c.r.Discard(i + unit)
+				next, err := c.r.Peek(unit)
+				if err != nil {
+					return nil, err
+				}
+				if c.char(next) == '\n' {
+					line = append(line, next...)
+					c.r.Discard(unit)
+				}
+				return line, nil
+			}
+		}
+		line = append(line, buf...)
+		c.r.Discard(len(buf))
+	}
+}
+
+// char は符号単位 b が改行の文字(CR・LF)であればその文字を、それ以外は0を返します
+func (c *completeLinesReader) char(b []byte) byte {
+	switch {
+	case len(c.newline) == 1:
+		return b[0]
+	case c.newline[0] == 0: // UTF-16BE: 0x00 0x0A
+		if b[0] == 0 {
+			return b[1]
+		}
+	case b[1] == 0: // UTF-16LE: 0x0A 0x00
+		return b[0]
+	}
+	return 0
+}
+
+// shiftResults は途中から走査した結果の行番号とオフセットを、ファイル先頭からの値に直します
+func shiftResults(results map[string]*SearchResult, lines int, offset int64) {
+	if lines == 0 && offset == 0 {
+		return
+	}
+	shift := func(o *Occurrence) *Occurrence {
+		if o == nil {
+			return nil
+		}
+		return &Occurrence{Path: o.Path, Line: o.Line + lines, Offset: o.Offset + offset}
+	}
+	for _, res := range results {
+		res.First, res.Last = shift(res.First), shift(res.Last)
+		for i := range res.Infos {
+			info := &res.Infos[i]
+			info.Line += lines
+			info.Offset += offset
+			for j := range info.Lines {
+				info.Lines[j] += lines
+			}
+		}
+	}
+}
+
+// compressedExts はローテート先の候補から除く圧縮済みファイルの拡張子です(途中から読み直せない)
+var compressedExts = []string{".gz", ".bz2", ".xz", ".zst", ".zip"}
+
+// findRotated は前回走査したファイルのローテート先(app.log.1, app.log-20240101 等)を探します。
+// inodeが前回と一致するファイルを優先し、見つからず現在のファイルが同じinodeのまま切り詰められた
+// (copytruncate)場合は、前回の位置より大きい候補のうち最も新しく更新されたものを選びます。
+func findRotated(ctx AppContext, path string, prev FileState, current os.FileInfo) string {
+	if ctx.DirFS == nil || ctx.FileStat == nil || prev.Offset == 0 {
+		return ""
+	}
+	dir, base := filepath.Split(path)
+	if dir == "" {
+		dir = "."
+	}
+	entries, err := fs.ReadDir(ctx.DirFS(dir), ".")
+	if err != nil {
+		return ""
+	}
+
+	truncated := prev.Inode == 0 || (current != nil && fileInode(current) == prev.Inode)
+	var best string
+	var bestTime time.Time
+	for _, e := range entries {
+		rest, ok := strings.CutPrefix(e.Name(), base)
+		if !ok || rest == "" || !strings.ContainsRune(".-_", rune(rest[0])) || slices.Contains(compressedExts, filepath.Ext(rest)) {
+			continue
+		}
+		candidate := filepath.Join(dir, e.Name())
+		info, err := ctx.FileStat(candidate)
+		if err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+		if prev.Inode != 0 && fileInode(info) == prev.Inode {
+			return candidate
+		}
+		if truncated && info.Size() >= prev.Offset && info.ModTime().After(bestTime) {
+			best, bestTime = candidate, info.ModTime()
+		}
+	}
+	return best
+}
+
+// scanIncremental は状態に記録された位置から入力ファイルを走査し、状態を更新します。
+// 前回以降にローテートされていた場合は、ローテート先の未走査部分と新しいファイルの先頭からを合わせて走査します。
+func scanIncremental(ctx AppContext, config *Config, state *ScanState) (map[string]*SearchResult, error) {
+	var info os.FileInfo
+	if ctx.FileStat != nil {
+		var err error
+		if info, err = ctx.FileStat(config.InputFilePath); err != nil {
+			return nil, fmt.Errorf("failed to stat input file: %w", err)
+		}
+	}
+
+	key := stateKey(config.InputFilePath)
+	prev := state.Files[key]
+	var prefix string
+	if prev.Prefix != "" {
+		// 読めない・短くなった場合は空のまま(前回と一致しないため先頭から読み直す)
+		prefix, _ = prefixHash(ctx, config.InputFilePath, prev.prefixLen())
+	}
+	var rotated map[string]*SearchResult
+	var rotatedPath string
+	if !prev.resumable(info, prefix) {
+		if rotatedPath = findRotated(ctx, config.InputFilePath, prev, info); rotatedPath != "" {
+			var err error
+			// ローテート先にはもう追記されないため、改行のない最終行も走査する
+			if rotated, _, _, err = scanFrom(ctx, rotatedPath, config, prev, false); err != nil {
+				return nil, fmt.Errorf("%s: %w", rotatedPath, err)
+			}
+		}
+		prev = FileState{}
+	}
+
+	results, read, enc, err := scanFrom(ctx, config.InputFilePath, config, prev, true)
+	if err != nil {
+		return nil, err
+	}
+
+	next := FileState{
+		Offset:   prev.Offset + read,
+		Lines:    prev.Lines + results[config.Queries[0]].Lines,
+		Encoding: enc,
+	}
+	if info != nil {
+		next.Inode = fileInode(info)
+		next.Size = info.Size()
+	}
+	if next.prefixLen() > 0 {
+		if prev.Prefix != "" && next.prefixLen() == prev.prefixLen() {
+			next.Prefix = prefix
+		} else if next.Prefix, err = prefixHash(ctx, config.InputFilePath, next.prefixLen()); err != nil {
+			return nil, fmt.Errorf("failed to read input file: %w", err)
+		}
+	}
+	state.Files[key] = next
+
+	if rotated == nil {
+		return results, nil
+	}
+	merged := NewResults(config.Queries)
+	for _, part := range []struct {
+		path    string
+		results map[string]*SearchResult
+	}{{rotatedPath, rotated}, {config.InputFilePath, results}} {
+		MergeResults(merged, part.results, part.path, config.Options.MaxSnippets)
+		for q, res := range part.results {
+			if d, ok := merged[q]; ok && res.Count > 0 {
+				d.Files = append(d.Files, FileCount{Path: part.path, Count: res.Count})
+			}
+		}
+	}
+	return merged, nil
+}
+
+// scanFrom はファイルを from の位置から走査し、行番号とオフセットをファイル先頭からの値に直した結果と、
+// 走査したバイト数、走査に使った文字コードを返します。complete が真なら改行で終わる行のみを走査します。
+// 文字コードはファイルの先頭で判定し、続きの走査では前回の判定結果を使います(途中からではBOM等で判定できない)。
+// 報告するオフセットは、ファイル全体を一度に走査した場合と同じくファイル中のバイト位置です。
+func scanFrom(ctx AppContext, path string, config *Config, from FileState, complete bool) (map[string]*SearchResult, int64, string, error) {
+	f, err := ctx.FileReader(path)
+	if err != nil {
+		return nil, 0, "", fmt.Errorf("failed to open input file: %w", err)
+	}
+	defer f.Close()
+	if err := skipTo(f, from.Offset); err != nil {
+		return nil, 0, "", &offsetError{from.Offset, fmt.Errorf("failed to resume at offset %d: %w", from.Offset, err)}
+	}
+
+	br := bufio.NewReaderSize(f, DetectSampleSize)
+	opts := config.Options
+	if from.Offset == 0 {
+		opts.InputEncoding = sniffEncoding(br, opts.InputEncoding)
+	} else if from.Encoding != "" {
+		opts.InputEncoding = from.Encoding
+	}
+
+	var input io.Reader = br
+	lines := newCompleteLinesReader(br, opts.InputEncoding)
+	if complete {
+		input = lines
+	}
+	results, err := SearchStreamWithOptions(input, config.Queries, opts)
+	if err != nil {
+		return nil, 0, "", err
+	}
+	if opts.InputEncoding != config.Options.InputEncoding {
+		for _, res := range results {
+			res.Encoding = opts.InputEncoding
+		}
+	}
+	shiftResults(results, from.Lines, from.Offset)
+	return results, lines.read, opts.InputEncoding, nil
+}
@@ -0,0 +1,220 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/encoding/unicode"
+)
+
+// TestRun_State は2回目以降の実行で追記された行のヒットのみが報告されるか確認します
+func TestRun_State(t *testing.T) {
+	dir := t.TempDir()
+	logPath := filepath.Join(dir, "app.log")
+	statePath := filepath.Join(dir, "state.json")
+
+	run := func() string { return runWithState(t, statePath, logPath) }
+	write := func(content string, flag int) { writeLogFile(t, logPath, content, flag) }
+
+	write("WARN a\nWARN b\n", os.O_TRUNC)
+	if out := run(); !strings.Contains(out, "該当数: 2\n") {
+		t.Errorf("First run should report both hits.\n Output: %s", out)
+	}
+
+	// 書き込み途中の最終行は次回に持ち越す
+	write("INFO\nWARN c\nWARN d", os.O_APPEND)
+	if out := run(); !strings.Contains(out, "該当数: 1\n") || !strings.Contains(out, "4行目 (offset 19)") {
+		t.Errorf("Second run should report only the appended complete line.\n Output: %s", out)
+	}
+
+	write(" done\n", os.O_APPEND)
+	if out := run(); !strings.Contains(out, "該当数: 1\n") || !strings.Contains(out, "5行目") {
+		t.Errorf("Third run should report the completed line.\n Output: %s", out)
+	}
+	if out := run(); !strings.Contains(out, "該当数: 0\n") {
+		t.Errorf("Run without new data should report nothing.\n Output: %s", out)
+	}
+
+	// 切り詰められたファイルは先頭から読み直す
+	write("WARN new\n", os.O_TRUNC)
+	if out := run(); !strings.Contains(out, "該当数: 1\n") || !strings.Contains(out, "1行目") {
+		t.Errorf("Truncated file should be rescanned from the start.\n Output: %s", out)
+	}
+
+	// 切り詰めた後に前回の位置を超えて書き込まれた場合も、先頭の内容の違いで検出する
+	write("INFO x\nWARN y\nWARN z\n", os.O_TRUNC)
+	if out := run(); !strings.Contains(out, "該当数: 2\n") || !strings.Contains(out, "2行目") {
+		t.Errorf("File truncated and regrown past the offset should be rescanned from the start.\n Output: %s", out)
+	}
+
+	// 状態ファイルは一時ファイル経由で置き換える
+	if tmp, _ := filepath.Glob(filepath.Join(dir, "*.tmp")); len(tmp) > 0 {
+		t.Errorf("Temporary state files left behind: %v", tmp)
+	}
+}
+
+// TestRun_StateRotation はローテートされたファイルの未走査部分も取りこぼさないか確認します
+func TestRun_StateRotation(t *testing.T) {
+	dir := t.TempDir()
+	logPath := filepath.Join(dir, "app.log")
+	statePath := filepath.Join(dir, "state.json")
+	writeLogFile(t, logPath, "WARN a\n", os.O_TRUNC)
+	runWithState(t, statePath, logPath)
+
+	// 移動によるローテート: 古い世代(app.log.2)は前回より前の内容なので対象外
+	writeLogFile(t, filepath.Join(dir, "app.log.2"), "WARN old\nWARN old\n", os.O_TRUNC)
+	writeLogFile(t, logPath, "WARN b\n", os.O_APPEND)
+	if err := os.Rename(logPath, filepath.Join(dir, "app.log.1")); err != nil {
+		t.Fatal(err)
+	}
+	writeLogFile(t, logPath, "WARN c\n", os.O_TRUNC)
+	out := runWithState(t, statePath, logPath)
+	for _, want := range []string{"該当数: 2\n", "app.log.1: 1\n", "app.log: 1\n", "app.log.1:2行目"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Rename rotation output should contain %q.\n Output: %s", want, out)
+		}
+	}
+
+	// copytruncate: 同じinodeのまま切り詰められ、内容は日付付きのファイルへ複製される
+	writeLogFile(t, logPath, "WARN d\n", os.O_APPEND)
+	writeLogFile(t, filepath.Join(dir, "app.log-20260101"), "WARN c\nWARN d\n", os.O_TRUNC)
+	writeLogFile(t, logPath, "INFO\n", os.O_TRUNC)
+	out = runWithState(t, statePath, logPath)
+	if !strings.Contains(out, "該当数: 1\n") || !strings.Contains(out, "app.log-20260101:2行目") {
+		t.Errorf("Copytruncate rotation should report only the copied unread line.\n Output: %s", out)
+	}
+}
+
+// TestRun_StateEncoding は UTF-8 以外の入力でも、続きから走査した行のオフセットが
+// ファイル全体を走査した場合と同じ(ファイル中のバイト位置)になるか確認します
+func TestRun_StateEncoding(t *testing.T) {
+	utf16le := unicode.UTF16(unicode.LittleEndian, unicode.UseBOM)
+	tests := []struct {
+		name    string
+		args    []string
+		enc     func(first bool) encoding.Encoding
+		offsets [3]int // 2・4・5行目の行頭のバイト位置
+	}{
+		{"sjis", []string{"-enc", "sjis"}, func(bool) encoding.Encoding { return japanese.ShiftJIS }, [3]int{11, 29, 36}},
+		{"auto", []string{"-enc", "auto"}, func(bool) encoding.Encoding { return japanese.ShiftJIS }, [3]int{11, 29, 36}},
+		// BOMはファイルの先頭にのみある
+		{"utf16 bom", nil, func(first bool) encoding.Encoding {
+			if first {
+				return utf16le
+			}
+			return unicode.UTF16(unicode.LittleEndian, unicode.IgnoreBOM)
+		}, [3]int{14, 40, 54}},
+		{"utf16be", []string{"-enc", "utf-16be"}, func(bool) encoding.Encoding {
+			return unicode.UTF16(unicode.BigEndian, unicode.IgnoreBOM)
+		}, [3]int{12, 38, 52}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			logPath := filepath.Join(dir, "app.log")
+			statePath := filepath.Join(dir, "state.json")
+			write := func(content string, first bool) {
+				encoded, err := tt.enc(first).NewEncoder().String(content)
+				if err != nil {
+					t.Fatal(err)
+				}
+				flag := os.O_APPEND
+				if first {
+					flag = os.O_TRUNC
+				}
+				writeLogFile(t, logPath, encoded, flag)
+			}
+
+			write("あいうえお\nWARN a\n", true)
+			if out := runWithState(t, statePath, logPath, tt.args...); !strings.Contains(out, fmt.Sprintf("2行目 (offset %d)", tt.offsets[0])) {
+				t.Errorf("First run should report the hit at offset %d.\n Output: %s", tt.offsets[0], out)
+			}
+			// 書き込み途中の行は次回に持ち越す
+			write("かきくけこ\nWARN b\nWARN c", false)
+			if out := runWithState(t, statePath, logPath, tt.args...); !strings.Contains(out, "該当数: 1\n") || !strings.Contains(out, fmt.Sprintf("4行目 (offset %d)", tt.offsets[1])) {
+				t.Errorf("Second run should report the file offset from the start of the file.\n Output: %s", out)
+			}
+			write("\n", false)
+			if out := runWithState(t, statePath, logPath, tt.args...); !strings.Contains(out, "該当数: 1\n") || !strings.Contains(out, fmt.Sprintf("5行目 (offset %d)", tt.offsets[2])) {
+				t.Errorf("Third run should report the completed line.\n Output: %s", out)
+			}
+		})
+	}
+}
+
+// TestCompleteLinesReader は LF・CRLF・CR を行の終わりとし、UTF-16 の入力では符号単位の境界にある改行のみを
+// 行の終わりとするか確認します
+func TestCompleteLinesReader(t *testing.T) {
+	tests := []struct {
+		name     string
+		encoding string
+		input    []byte
+		want     []byte
+	}{
+		// U+0A0A と LF
+		{"le", "utf-16le", []byte{0x0A, 0x0A, 0x0A, 0x00, 'A', 0x00}, []byte{0x0A, 0x0A, 0x0A, 0x00}},
+		// U+0A00 の後に書き込み途中の LF(0x00 のみ)
+		{"le partial", "utf-16le", []byte{0x00, 0x0A, 0x0A}, nil},
+		// U+0A00、LF、書き込み途中の U+0A00
+		{"be", "utf-16be", []byte{0x0A, 0x00, 0x00, 0x0A, 0x0A, 0x00}, []byte{0x0A, 0x00, 0x00, 0x0A}},
+		{"be misaligned", "utf-16be", []byte{0x0A, 0x00, 0x0A, 0x00}, nil},
+		{"cr", "", []byte("a\rb\r\nc\rd"), []byte("a\rb\r\nc\r")},
+		// 末尾のCRは後にLFが続くか分からないため次回に残す
+		{"trailing cr", "", []byte("a\rb\r"), []byte("a\r")},
+		{"le cr", "utf-16le", []byte{'a', 0x00, 0x0D, 0x00, 'b', 0x00}, []byte{'a', 0x00, 0x0D, 0x00}},
+		{"be crlf", "utf-16be", []byte{0x00, 'a', 0x00, 0x0D, 0x00, 0x0A}, []byte{0x00, 'a', 0x00, 0x0D, 0x00, 0x0A}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newCompleteLinesReader(bytes.NewReader(tt.input), tt.encoding)
+			got, err := io.ReadAll(r)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got, tt.want) || r.read != int64(len(tt.want)) {
+				t.Errorf("read %x (%d bytes), want %x", got, r.read, tt.want)
+			}
+		})
+	}
+}
+
+// runWithState は -state を指定して実際のファイルを走査し、標準出力の内容を返します
+// args には -enc 等の追加のオプションを指定します
+func runWithState(t *testing.T, statePath, logPath string, args ...string) string {
+	t.Helper()
+	stdout := new(bytes.Buffer)
+	code := Run(AppContext{
+		Args:        append(append([]string{"app", "-state", statePath}, args...), logPath),
+		ExecPath:    "app_WARN",
+		Stdout:      stdout,
+		Stderr:      io.Discard,
+		FileReader:  func(path string) (io.ReadCloser, error) { return os.Open(path) },
+		FileCreator: func(path string) (io.WriteCloser, error) { return os.Create(path) },
+		FileStat:    os.Stat,
+		DirFS:       os.DirFS,
+		Rename:      os.Rename,
+		Remove:      os.Remove,
+	})
+	if code != ExitMatch && code != ExitNoMatch {
+		t.Fatalf("Run() exit code = %d", code)
+	}
+	return stdout.String()
+}
+
+// writeLogFile はファイルに内容を書き込みます(flag に os.O_TRUNC か os.O_APPEND を指定する)
+func writeLogFile(t *testing.T, path, content string, flag int) {
+	t.Helper()
+	f, err := os.OpenFile(path, flag|os.O_WRONLY|os.O_CREATE, 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteString(content)
+	f.Close()
+}
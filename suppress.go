@@ -0,0 +1,56 @@
+package main
+
+import (
+	"strings"
+)
+
+// DefaultSuppressMarker は確認済みの例外として、その行と次の行のヒットを除外する目印です。
+// `// objis:allow` のようにコメントとして書きます。`objis:allow=外字,WARN` のように
+// '=' に続けてカンマ区切りでクエリを指定すると、それらのクエリのヒットのみ除外します。
+const DefaultSuppressMarker = "objis:allow"
+
+// suppression は1行に書かれた除外指定です
+type suppression struct {
+	queries map[string]bool // nilなら全クエリを除外する
+}
+
+// parseSuppression は行中の除外指定を解析します。目印がなければnilを返します。
+func parseSuppression(line, marker string) *suppression {
+	if marker == "" {
+		return nil
+	}
+	i := strings.Index(line, marker)
+	if i < 0 {
+		return nil
+	}
+
+	rest, ok := strings.CutPrefix(line[i+len(marker):], "=")
+	if !ok {
+		return &suppression{}
+	}
+	// クエリの列挙は空白またはコメントの終わりまで
+	if end := strings.IndexAny(rest, " \t"); end >= 0 {
+		rest = rest[:end]
+	}
+	rest = strings.TrimSuffix(rest, "*/")
+	rest = strings.TrimSuffix(rest, "-->")
+
+	s := &suppression{queries: make(map[string]bool)}
+	for _, q := range strings.Split(rest, ",") {
+		if q != "" {
+			s.queries[q] = true
+		}
+	}
+	if len(s.queries) == 0 {
+		s.queries = nil
+	}
+	return s
+}
+
+// covers はクエリのヒットを除外するかを返します
+func (s *suppression) covers(query string) bool {
+	if s == nil {
+		return false
+	}
+	return s.queries == nil || s.queries[query]
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// TestParseSuppression は除外指定の解析を確認します
+func TestParseSuppression(t *testing.T) {
+	tests := []struct {
+		line    string
+		query   string
+		want    bool
+		present bool
+	}{
+		{line: "x := \"髙\" // objis:allow", query: "髙", want: true, present: true},
+		{line: "# objis:allow=髙,WARN reviewed", query: "WARN", want: true, present: true},
+		{line: "<!-- objis:allow=髙-->", query: "髙", want: true, present: true},
+		{line: "/* objis:allow=髙 */", query: "WARN", want: false, present: true},
+		{line: "plain line", query: "髙", want: false, present: false},
+	}
+	for _, tt := range tests {
+		s := parseSuppression(tt.line, DefaultSuppressMarker)
+		if (s != nil) != tt.present {
+			t.Errorf("parseSuppression(%q) present = %t, want %t", tt.line, s != nil, tt.present)
+		}
+		if got := s.covers(tt.query); got != tt.want {
+			t.Errorf("parseSuppression(%q).covers(%q) = %t, want %t", tt.line, tt.query, got, tt.want)
+		}
+	}
+
+	if parseSuppression("// objis:allow", "") != nil {
+		t.Error("empty marker should disable suppression")
+	}
+}
+
+// TestSearchStream_Suppress は目印のある行と次の行のヒットが該当数から除かれるか確認します
+func TestSearchStream_Suppress(t *testing.T) {
+	src := strings.Join([]string{
+		"name = 髙橋 // objis:allow",
+		"// objis:allow=WARN",
+		"WARN 髙島",
+		"髙田 WARN",
+	}, "\n")
+	opts := SearchOptions{ContextSize: 5, SuppressMarker: DefaultSuppressMarker}
+	results, err := SearchStreamWithOptions(strings.NewReader(src), []string{"髙", "WARN"}, opts)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if res := results["髙"]; res.Count != 2 || res.Suppressed != 1 {
+		t.Errorf("髙: count = %d, suppressed = %d, want 2, 1", res.Count, res.Suppressed)
+	}
+	// 目印の行自体("objis:allow=WARN")と次の行のWARNが除外される
+	if res := results["WARN"]; res.Count != 1 || res.Suppressed != 2 || res.Infos[0].Line != 4 {
+		t.Errorf("WARN: count = %d, suppressed = %d, infos = %+v", res.Count, res.Suppressed, res.Infos)
+	}
+
+	out := new(bytes.Buffer)
+	WriteResults(out, results, []string{"髙"})
+	if !strings.Contains(out.String(), "該当数: 2\n除外: 1\n") {
+		t.Errorf("Output should report suppressed hits.\n Output: %s", out.String())
+	}
+}
@@ -0,0 +1,41 @@
+#!/usr/bin/env python3
+"""Shift_JIS-2004 (JIS X 0213:2004) の2バイト文字の対応表 sjis2004_table.go を生成する。
+
+Python 標準の shift_jis_2004 コーデックを元データとして使う。
+    python3 tools/gen_sjis2004.py > sjis2004_table.go
+"""
+
+LEADS = list(range(0x81, 0xA0)) + list(range(0xE0, 0xFD))
+TRAILS = list(range(0x40, 0xFD))
+
+singles = []
+pairs = []
+for lead in LEADS:
+    for trail in TRAILS:
+        try:
+            s = bytes([lead, trail]).decode("shift_jis_2004")
+        except UnicodeDecodeError:
+            singles.append(0)
+            continue
+        if len(s) == 1:
+            singles.append(ord(s))
+        else:
+            singles.append(0)
+            pairs.append(((lead << 8) | trail, ord(s[0]), ord(s[1])))
+
+print("// Code generated by tools/gen_sjis2004.py; DO NOT EDIT.")
+print()
+print("package main")
+print()
+print("// sjis2004Table は2バイト文字の符号位置(先頭バイト・後続バイトの順)に対応する文字です。")
+print("// 0 は未定義、または sjis2004Pairs に登録された2文字の組み合わせを表します。")
+print("var sjis2004Table = [%d]rune{" % len(singles))
+for i in range(0, len(singles), 12):
+    print("\t" + " ".join("0x%05X," % c for c in singles[i:i + 12]))
+print("}")
+print()
+print("// sjis2004Pairs は基底文字と結合文字の2文字に対応する符号位置です(か゚ など)")
+print("var sjis2004Pairs = map[uint16][2]rune{")
+for code, a, b in pairs:
+    print("\t0x%04X: {0x%04X, 0x%04X}," % (code, a, b))
+print("}")
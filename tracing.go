@@ -0,0 +1,377 @@
+package main
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// traceServiceName は OTEL_SERVICE_NAME が未指定の場合のサービス名です
+const traceServiceName = "go-ObuJIS2004"
+
+// traceDefaultEndpoint は OTEL_TRACES_EXPORTER=otlp のみ指定された場合の送信先です(OTLP/HTTP の既定)
+const traceDefaultEndpoint = "http://localhost:4318/v1/traces"
+
+// Tracer は実行・ファイル・処理の段階ごとのスパンを記録し、終了時に OTLP/HTTP (JSON) で送信します。
+// バッチ処理の中でこのツールが占める時間を既存のトレースのダッシュボードで確認するためのものです。
+// nil の Tracer・Span のメソッドは何もしないため、トレースが無効でも呼び出し側で分岐する必要はありません。
+type Tracer struct {
+	endpoint string
+	headers  map[string]string
+	resource []otlpAttribute
+	timeout  time.Duration
+	traceID  [16]byte
+	parentID [8]byte // TRACEPARENT で指定された親スパン(ゼロなら親なし)
+
+	mu    sync.Mutex
+	spans []otlpSpan
+}
+
+// NewTracerFromEnv は OpenTelemetry の標準の環境変数からトレースの設定を読み取ります。
+// OTEL_EXPORTER_OTLP_TRACES_ENDPOINT・OTEL_EXPORTER_OTLP_ENDPOINT のいずれか、または OTEL_TRACES_EXPORTER=otlp が
+// 指定されている場合のみ有効で、それ以外は nil を返します。送信は http/json のみに対応します。
+func NewTracerFromEnv(getenv func(string) string) (*Tracer, error) {
+	if getenv == nil || strings.EqualFold(getenv("OTEL_SDK_DISABLED"), "true") {
+		return nil, nil
+	}
+	exporter := getenv("OTEL_TRACES_EXPORTER")
+	endpoint := getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT")
+	if endpoint == "" {
+		if base := getenv("OTEL_EXPORTER_OTLP_ENDPOINT"); base != "" {
+			endpoint = strings.TrimSuffix(base, "/") + "/v1/traces"
+		}
+	}
+	switch exporter {
+	case "":
+		if endpoint == "" {
+			return nil, nil
+		}
+	case "otlp":
+		if endpoint == "" {
+			endpoint = traceDefaultEndpoint
+		}
+	case "none":
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unsupported OTEL_TRACES_EXPORTER: %s (otlp|none)", exporter)
+	}
+
+	protocol := firstEnv(getenv, "OTEL_EXPORTER_OTLP_TRACES_PROTOCOL", "OTEL_EXPORTER_OTLP_PROTOCOL")
+	if protocol != "" && protocol != "http/json" {
+		return nil, fmt.Errorf("unsupported OTLP protocol: %s (http/json)", protocol)
+	}
+
+	t := &Tracer{endpoint: endpoint, timeout: 10 * time.Second}
+	if ms := firstEnv(getenv, "OTEL_EXPORTER_OTLP_TRACES_TIMEOUT", "OTEL_EXPORTER_OTLP_TIMEOUT"); ms != "" {
+		n, err := strconv.Atoi(ms)
+		if err != nil || n <= 0 {
+			return nil, fmt.Errorf("invalid OTLP timeout: %s", ms)
+		}
+		t.timeout = time.Duration(n) * time.Millisecond
+	}
+
+	var err error
+	if t.headers, err = parseOTELList(firstEnv(getenv, "OTEL_EXPORTER_OTLP_TRACES_HEADERS", "OTEL_EXPORTER_OTLP_HEADERS")); err != nil {
+		return nil, fmt.Errorf("invalid OTLP headers: %w", err)
+	}
+	resource, err := parseOTELList(getenv("OTEL_RESOURCE_ATTRIBUTES"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid OTEL_RESOURCE_ATTRIBUTES: %w", err)
+	}
+	if name := getenv("OTEL_SERVICE_NAME"); name != "" {
+		resource["service.name"] = name
+	} else if resource["service.name"] == "" {
+		resource["service.name"] = traceServiceName
+	}
+	for _, k := range slices.Sorted(maps.Keys(resource)) {
+		t.resource = append(t.resource, stringAttr(k, resource[k]))
+	}
+
+	// パイプラインから渡された W3C traceparent があれば、そのトレースの子として記録する
+	if tp := getenv("TRACEPARENT"); tp != "" {
+		if traceID, spanID, ok := parseTraceparent(tp); ok {
+			t.traceID, t.parentID = traceID, spanID
+		}
+	}
+	if t.traceID == [16]byte{} {
+		rand.Read(t.traceID[:])
+	}
+	return t, nil
+}
+
+// firstEnv は最初に空でない環境変数の値を返します
+func firstEnv(getenv func(string) string, names ...string) string {
+	for _, name := range names {
+		if v := getenv(name); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// parseOTELList は "k1=v1,k2=v2" の形式(値はURLエンコード)の環境変数を解釈します
+func parseOTELList(s string) (map[string]string, error) {
+	m := make(map[string]string)
+	for _, item := range strings.Split(s, ",") {
+		if strings.TrimSpace(item) == "" {
+			continue
+		}
+		k, v, ok := strings.Cut(item, "=")
+		if !ok || strings.TrimSpace(k) == "" {
+			return nil, fmt.Errorf("%q is not key=value", item)
+		}
+		decoded, err := url.PathUnescape(strings.TrimSpace(v))
+		if err != nil {
+			return nil, err
+		}
+		m[strings.TrimSpace(k)] = decoded
+	}
+	return m, nil
+}
+
+// parseTraceparent は W3C Trace Context の traceparent (00-<trace-id>-<parent-id>-<flags>)を解釈します
+func parseTraceparent(s string) (traceID [16]byte, spanID [8]byte, ok bool) {
+	parts := strings.Split(strings.TrimSpace(s), "-")
+	if len(parts) != 4 || len(parts[1]) != 32 || len(parts[2]) != 16 {
+		return traceID, spanID, false
+	}
+	if _, err := hex.Decode(traceID[:], []byte(parts[1])); err != nil {
+		return traceID, spanID, false
+	}
+	if _, err := hex.Decode(spanID[:], []byte(parts[2])); err != nil {
+		return traceID, spanID, false
+	}
+	return traceID, spanID, traceID != [16]byte{} && spanID != [8]byte{}
+}
+
+// Start は実行全体のスパンを開始します(TRACEPARENT があればその子になります)
+func (t *Tracer) Start(name string, attrs ...otlpAttribute) *Span {
+	if t == nil {
+		return nil
+	}
+	return t.newSpan(t.parentID, name, time.Now(), attrs)
+}
+
+func (t *Tracer) newSpan(parent [8]byte, name string, start time.Time, attrs []otlpAttribute) *Span {
+	s := &Span{tracer: t, parent: parent, name: name, start: start, attrs: attrs}
+	rand.Read(s.id[:])
+	return s
+}
+
+// Flush は記録したスパンを送信します。スパンがなければ何もしません。
+func (t *Tracer) Flush() error {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	spans := t.spans
+	t.spans = nil
+	t.mu.Unlock()
+	if len(spans) == 0 {
+		return nil
+	}
+
+	body, err := json.Marshal(otlpTraces{ResourceSpans: []otlpResourceSpans{{
+		Resource:   otlpResource{Attributes: t.resource},
+		ScopeSpans: []otlpScopeSpans{{Scope: otlpScope{Name: traceServiceName}, Spans: spans}},
+	}}})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, t.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range t.headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := (&http.Client{Timeout: t.timeout}).Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
+
+// Span は記録中のスパンです
+type Span struct {
+	tracer *Tracer
+	id     [8]byte
+	parent [8]byte
+	name   string
+	start  time.Time
+	attrs  []otlpAttribute
+	err    error
+	ended  bool
+}
+
+// Start は子スパンを開始します
+func (s *Span) Start(name string, attrs ...otlpAttribute) *Span {
+	if s == nil {
+		return nil
+	}
+	return s.tracer.newSpan(s.id, name, time.Now(), attrs)
+}
+
+// SetAttributes はスパンに属性を追加します
+func (s *Span) SetAttributes(attrs ...otlpAttribute) {
+	if s != nil {
+		s.attrs = append(s.attrs, attrs...)
+	}
+}
+
+// SetError はスパンを失敗として記録します(err が nil なら何もしません)
+func (s *Span) SetError(err error) {
+	if s != nil && err != nil {
+		s.err = err
+	}
+}
+
+// End はスパンを終了して送信待ちに加えます。2回目以降の呼び出しは無視します。
+func (s *Span) End() {
+	if s != nil && !s.ended {
+		s.finish(time.Now())
+	}
+}
+
+// Record は開始・終了時刻が分かっている子スパンを記録します
+func (s *Span) Record(name string, start, end time.Time, attrs ...otlpAttribute) {
+	if s != nil {
+		s.tracer.newSpan(s.id, name, start, attrs).finish(end)
+	}
+}
+
+func (s *Span) finish(end time.Time) {
+	s.ended = true
+	span := otlpSpan{
+		TraceID:           hex.EncodeToString(s.tracer.traceID[:]),
+		SpanID:            hex.EncodeToString(s.id[:]),
+		Name:              s.name,
+		Kind:              otlpSpanKindInternal,
+		StartTimeUnixNano: strconv.FormatInt(s.start.UnixNano(), 10),
+		EndTimeUnixNano:   strconv.FormatInt(end.UnixNano(), 10),
+		Attributes:        s.attrs,
+	}
+	if s.parent != [8]byte{} {
+		span.ParentSpanID = hex.EncodeToString(s.parent[:])
+	}
+	if s.err != nil {
+		span.Status = &otlpStatus{Code: otlpStatusError, Message: s.err.Error()}
+	}
+	s.tracer.mu.Lock()
+	s.tracer.spans = append(s.tracer.spans, span)
+	s.tracer.mu.Unlock()
+}
+
+// traceSearch は1ファイルの検索を、読み込み・復号(decode)と照合(match)の段階のスパンとして記録します。
+// decode は入力の読み込みと UTF-8 への変換に実際に掛かった時間、match はそれ以外(照合とスニペットの抽出)の時間です。
+// 2つの段階は行ごとに交互に進むため、どちらのスパンも検索の開始時刻から始め、長さを各段階の時間の合計とします。
+func traceSearch(r io.Reader, queries []string, opts SearchOptions) (map[string]*SearchResult, error) {
+	span := opts.Trace
+	var decoded time.Duration
+	opts.Trace = nil
+	opts.decodeTime = &decoded
+
+	start := time.Now()
+	results, err := SearchStreamWithOptions(r, queries, opts)
+	elapsed := time.Since(start)
+
+	span.Record("decode", start, start.Add(decoded))
+	span.Record("match", start, start.Add(elapsed-decoded), intAttr("objis.queries", len(queries)))
+	span.SetError(err)
+	return results, err
+}
+
+// timedReader は Read に掛かった時間を合計します
+type timedReader struct {
+	r       io.Reader
+	elapsed *time.Duration
+}
+
+func (t timedReader) Read(p []byte) (int, error) {
+	start := time.Now()
+	n, err := t.r.Read(p)
+	*t.elapsed += time.Since(start)
+	return n, err
+}
+
+// 以下は OTLP/JSON (opentelemetry-proto の JSON 表現)のうち、トレースの送信に使う部分です
+
+const (
+	otlpSpanKindInternal = 1
+	otlpStatusError      = 2
+)
+
+type otlpTraces struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpAttribute `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpSpan struct {
+	TraceID           string          `json:"traceId"`
+	SpanID            string          `json:"spanId"`
+	ParentSpanID      string          `json:"parentSpanId,omitempty"`
+	Name              string          `json:"name"`
+	Kind              int             `json:"kind"`
+	StartTimeUnixNano string          `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string          `json:"endTimeUnixNano"`
+	Attributes        []otlpAttribute `json:"attributes,omitempty"`
+	Status            *otlpStatus     `json:"status,omitempty"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+type otlpAttribute struct {
+	Key   string    `json:"key"`
+	Value otlpValue `json:"value"`
+}
+
+type otlpValue struct {
+	StringValue *string `json:"stringValue,omitempty"`
+	IntValue    *string `json:"intValue,omitempty"` // int64 は文字列で表す
+}
+
+func stringAttr(key, value string) otlpAttribute {
+	return otlpAttribute{Key: key, Value: otlpValue{StringValue: &value}}
+}
+
+func intAttr(key string, value int) otlpAttribute {
+	s := strconv.Itoa(value)
+	return otlpAttribute{Key: key, Value: otlpValue{IntValue: &s}}
+}
@@ -0,0 +1,176 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewTracerFromEnv(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		enabled bool
+		wantErr bool
+	}{
+		{"未設定", map[string]string{}, false, false},
+		{"エンドポイント", map[string]string{"OTEL_EXPORTER_OTLP_ENDPOINT": "http://collector:4318"}, true, false},
+		{"exporter=otlp のみ", map[string]string{"OTEL_TRACES_EXPORTER": "otlp"}, true, false},
+		{"exporter=none", map[string]string{"OTEL_TRACES_EXPORTER": "none", "OTEL_EXPORTER_OTLP_ENDPOINT": "http://collector:4318"}, false, false},
+		{"SDK無効", map[string]string{"OTEL_SDK_DISABLED": "true", "OTEL_EXPORTER_OTLP_ENDPOINT": "http://collector:4318"}, false, false},
+		{"grpc は非対応", map[string]string{"OTEL_EXPORTER_OTLP_ENDPOINT": "http://collector:4317", "OTEL_EXPORTER_OTLP_PROTOCOL": "grpc"}, false, true},
+		{"未知の exporter", map[string]string{"OTEL_TRACES_EXPORTER": "zipkin"}, false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracer, err := NewTracerFromEnv(func(k string) string { return tt.env[k] })
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if (tracer != nil) != tt.enabled {
+				t.Errorf("enabled = %v, want %v", tracer != nil, tt.enabled)
+			}
+		})
+	}
+
+	tracer, _ := NewTracerFromEnv(func(k string) string {
+		return map[string]string{"OTEL_EXPORTER_OTLP_ENDPOINT": "http://collector:4318/"}[k]
+	})
+	if tracer.endpoint != "http://collector:4318/v1/traces" {
+		t.Errorf("endpoint = %q", tracer.endpoint)
+	}
+}
+
+func TestParseTraceparent(t *testing.T) {
+	traceID, spanID, ok := parseTraceparent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	if !ok || traceID[0] != 0x4b || spanID[7] != 0xb7 {
+		t.Errorf("parseTraceparent() = %x, %x, %v", traceID, spanID, ok)
+	}
+	if _, _, ok := parseTraceparent("00-00000000000000000000000000000000-00f067aa0ba902b7-01"); ok {
+		t.Error("all-zero trace id should be rejected")
+	}
+}
+
+func TestRun_Tracing(t *testing.T) {
+	var received otlpTraces
+	var auth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Errorf("invalid OTLP body: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	for name, content := range map[string]string{"a.log": "WARN a\n", "b.log": "ok\n"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	env := map[string]string{
+		"OTEL_EXPORTER_OTLP_ENDPOINT": server.URL,
+		"OTEL_EXPORTER_OTLP_HEADERS":  "Authorization=Bearer%20token",
+		"OTEL_SERVICE_NAME":           "nightly-batch",
+		"TRACEPARENT":                 "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+	}
+	code := Run(AppContext{
+		Args:       []string{"app", "-jobs", "1", dir},
+		ExecPath:   "app_WARN",
+		Stdout:     io.Discard,
+		Stderr:     io.Discard,
+		FileReader: func(p string) (io.ReadCloser, error) { return os.Open(p) },
+		FileStat:   os.Stat,
+		DirFS:      os.DirFS,
+		Getenv:     func(k string) string { return env[k] },
+	})
+	if code != ExitMatch {
+		t.Fatalf("Run() exit code = %d", code)
+	}
+	if auth != "Bearer token" {
+		t.Errorf("Authorization = %q", auth)
+	}
+	if len(received.ResourceSpans) != 1 {
+		t.Fatalf("resourceSpans = %+v", received.ResourceSpans)
+	}
+	rs := received.ResourceSpans[0]
+	if v := rs.Resource.Attributes[0].Value.StringValue; v == nil || *v != "nightly-batch" {
+		t.Errorf("service.name = %v", v)
+	}
+
+	byID := make(map[string]otlpSpan)
+	var names []string
+	for _, s := range rs.ScopeSpans[0].Spans {
+		byID[s.SpanID] = s
+		names = append(names, s.Name)
+		if s.TraceID != "4bf92f3577b34da6a3ce929d0e0e4736" {
+			t.Errorf("span %s traceId = %s", s.Name, s.TraceID)
+		}
+	}
+	sort.Strings(names)
+	want := []string{"decode", "decode", "file", "file", "match", "match", "run", "write"}
+	if len(names) != len(want) {
+		t.Fatalf("spans = %v, want %v", names, want)
+	}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Fatalf("spans = %v, want %v", names, want)
+		}
+	}
+	for _, s := range byID {
+		var wantParent string
+		switch s.Name {
+		case "run":
+			if s.ParentSpanID != "00f067aa0ba902b7" {
+				t.Errorf("run parent = %s, want the TRACEPARENT span", s.ParentSpanID)
+			}
+			continue
+		case "file", "write":
+			wantParent = "run"
+		case "decode", "match":
+			wantParent = "file"
+		}
+		if parent := byID[s.ParentSpanID]; parent.Name != wantParent {
+			t.Errorf("%s parent = %q, want %q", s.Name, parent.Name, wantParent)
+		}
+	}
+}
+
+// slowReader は読み込みのたびに待つ入力です(読み込みの時間を decode に数えることの確認用)
+type slowReader struct {
+	r     io.Reader
+	delay time.Duration
+}
+
+func (s slowReader) Read(p []byte) (int, error) {
+	time.Sleep(s.delay)
+	return s.r.Read(p)
+}
+
+// TestSearchStream_DecodeTime は行単位・行をつなげた照合のどちらでも、入力の読み込みと変換の時間を計るか確認します
+func TestSearchStream_DecodeTime(t *testing.T) {
+	for _, whole := range []bool{false, true} {
+		var decoded time.Duration
+		opts := SearchOptions{ContextSize: 2, WholeText: whole, InputEncoding: "sjis", decodeTime: &decoded}
+		r := slowReader{strings.NewReader("WARN a\nWARN b\n"), 10 * time.Millisecond}
+		start := time.Now()
+		results, err := SearchStreamWithOptions(r, []string{"WARN"}, opts)
+		elapsed := time.Since(start)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if results["WARN"].Count != 2 {
+			t.Errorf("WholeText=%t: Count = %d, want 2", whole, results["WARN"].Count)
+		}
+		if decoded < 10*time.Millisecond || decoded > elapsed {
+			t.Errorf("WholeText=%t: decode time = %v, want between the read delay and %v", whole, decoded, elapsed)
+		}
+	}
+}
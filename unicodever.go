@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// DefaultUnicodeVersion は -unicode-version を指定しない場合に使うUnicodeの版です(組み込みの表の最新版)
+const DefaultUnicodeVersion = "17.0"
+
+// unicodeVersions は -unicode-version で指定できるUnicodeの版です(古い順)
+var unicodeVersions = []string{
+	"3.0", "3.1", "3.2", "4.0", "4.1", "5.0", "5.1", "5.2", "6.0", "6.1", "6.2", "6.3",
+	"7.0", "8.0", "9.0", "10.0", "11.0", "12.0", "12.1", "13.0", "14.0", "15.0", "15.1", "16.0", "17.0",
+}
+
+// unicodeAge は文字の範囲 [lo, hi] が追加されたUnicodeの版です
+type unicodeAge struct {
+	lo, hi  rune
+	version string
+}
+
+// trackedBlocks は版ごとの割り当てを組み込みの表(unicodeAges)で判定するブロックです。
+// 漢字・仮名・異体字セレクタなど、国内の文字コード対応で問題になる範囲に限ります。
+// それ以外の文字はビルドに使ったGoの unicode パッケージの表で判定します。
+var trackedBlocks = [][2]rune{
+	{0x3040, 0x30FF},   // 平仮名・片仮名
+	{0x31F0, 0x31FF},   // 片仮名拡張
+	{0x3400, 0x4DBF},   // CJK統合漢字拡張A
+	{0x4E00, 0x9FFF},   // CJK統合漢字
+	{0xF900, 0xFAFF},   // CJK互換漢字
+	{0x1AFF0, 0x1B16F}, // 仮名拡張B・仮名補助・仮名拡張A・小書き仮名拡張
+	{0x20000, 0x2FA1F}, // CJK統合漢字拡張B〜F, I・CJK互換漢字補助
+	{0x30000, 0x3347F}, // CJK統合漢字拡張G, H, J
+	{0xE0100, 0xE01EF}, // 異体字セレクタ補助
+}
+
+// unicodeAges は trackedBlocks 内で割り当て済みの範囲と、追加されたUnicodeの版です(DerivedAge.txt による)
+var unicodeAges = []unicodeAge{
+	{0x3041, 0x3094, "3.0"}, {0x3095, 0x3096, "3.2"}, {0x3099, 0x309E, "3.0"}, {0x309F, 0x30A0, "3.2"},
+	{0x30A1, 0x30FE, "3.0"}, {0x30FF, 0x30FF, "3.2"}, {0x31F0, 0x31FF, "3.2"},
+	{0x3400, 0x4DB5, "3.0"}, {0x4DB6, 0x4DBF, "13.0"},
+	{0x4E00, 0x9FA5, "3.0"}, {0x9FA6, 0x9FBB, "4.1"}, {0x9FBC, 0x9FC3, "5.1"}, {0x9FC4, 0x9FCB, "5.2"},
+	{0x9FCC, 0x9FCC, "6.1"}, {0x9FCD, 0x9FD5, "8.0"}, {0x9FD6, 0x9FEA, "10.0"}, {0x9FEB, 0x9FEF, "11.0"},
+	{0x9FF0, 0x9FFC, "13.0"}, {0x9FFD, 0x9FFF, "14.0"},
+	{0xF900, 0xFA2D, "3.0"}, {0xFA2E, 0xFA2F, "6.1"}, {0xFA30, 0xFA6A, "3.2"}, {0xFA6B, 0xFA6D, "5.2"},
+	{0xFA70, 0xFAD9, "4.1"},
+	{0x1AFF0, 0x1AFF3, "14.0"}, {0x1AFF5, 0x1AFFB, "14.0"}, {0x1AFFD, 0x1AFFE, "14.0"},
+	{0x1B000, 0x1B001, "6.0"}, {0x1B002, 0x1B11E, "10.0"}, {0x1B11F, 0x1B122, "14.0"},
+	{0x1B132, 0x1B132, "15.0"}, {0x1B150, 0x1B152, "12.0"}, {0x1B155, 0x1B155, "15.0"}, {0x1B164, 0x1B167, "12.0"},
+	{0x20000, 0x2A6D6, "3.1"}, {0x2A6D7, 0x2A6DD, "13.0"}, {0x2A6DE, 0x2A6DF, "14.0"},
+	{0x2A700, 0x2B734, "5.2"}, {0x2B735, 0x2B738, "14.0"}, {0x2B739, 0x2B739, "15.0"},
+	{0x2B740, 0x2B81D, "6.0"}, {0x2B820, 0x2CEA1, "8.0"}, {0x2CEB0, 0x2EBE0, "10.0"},
+	{0x2EBF0, 0x2EE5D, "15.1"}, {0x2F800, 0x2FA1D, "3.1"},
+	{0x30000, 0x3134A, "13.0"}, {0x31350, 0x323AF, "15.0"}, {0x323B0, 0x33479, "17.0"},
+	{0xE0100, 0xE01EF, "4.0"},
+}
+
+// LookupUnicodeVersion はUnicodeの版を検証し、正規化した表記("15" → "15.0")で返します。
+// 空の場合は DefaultUnicodeVersion を返します。
+func LookupUnicodeVersion(version string) (string, error) {
+	if version == "" {
+		return DefaultUnicodeVersion, nil
+	}
+	v := version
+	if !strings.Contains(v, ".") {
+		v += ".0"
+	}
+	if slices.Contains(unicodeVersions, v) {
+		return v, nil
+	}
+	return "", fmt.Errorf("unsupported unicode version: %s (available: %s)", version, strings.Join(unicodeVersions, ", "))
+}
+
+// versionLess は版 a が版 b より古いかを返します
+func versionLess(a, b string) bool {
+	amaj, amin, _ := strings.Cut(a, ".")
+	bmaj, bmin, _ := strings.Cut(b, ".")
+	if amaj != bmaj {
+		x, _ := strconv.Atoi(amaj)
+		y, _ := strconv.Atoi(bmaj)
+		return x < y
+	}
+	x, _ := strconv.Atoi(amin)
+	y, _ := strconv.Atoi(bmin)
+	return x < y
+}
+
+// assignedIn は文字がUnicodeの版 version で割り当て済みであるかを返します
+func assignedIn(version string, r rune) bool {
+	for _, b := range trackedBlocks {
+		if r < b[0] || r > b[1] {
+			continue
+		}
+		for _, a := range unicodeAges {
+			if r >= a.lo && r <= a.hi {
+				return !versionLess(version, a.version)
+			}
+		}
+		return false
+	}
+	// 組み込みの表の対象外の文字は、制御文字・私用領域を含めGoの表で割り当て済みかを判定する
+	return unicode.In(r, unicode.L, unicode.M, unicode.N, unicode.P, unicode.S, unicode.Z, unicode.C)
+}
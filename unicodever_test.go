@@ -0,0 +1,57 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestUnicodeVersion はUnicodeの版によって未割り当ての文字の判定が変わるか確認します
+func TestUnicodeVersion(t *testing.T) {
+	tests := []struct {
+		version string
+		r       rune
+		want    bool // unassigned であるか
+	}{
+		{"3.0", '亜', false},
+		{"3.0", '𠮷', true}, // 拡張B(3.1)
+		{"3.1", '𠮷', false},
+		{"12.1", '鿰', true}, // 13.0 で追加
+		{"13.0", '鿰', false},
+		{"15.0", '\U0002EBF0', true}, // 拡張I(15.1)
+		{"15.1", '\U0002EBF0', false},
+		{"17.0", '\U00033479', false},
+		{"17.0", '\U0003347A', true},
+		{"17.0", '﩮', true}, // 互換漢字ブロック内の未割り当て
+		{"3.0", 'A', false},
+	}
+	for _, tt := range tests {
+		pred, _, err := lookupCharClass(CharClassPrefix+"unassigned", tt.version)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := pred(tt.r); got != tt.want {
+			t.Errorf("unassigned(%q) in %s = %v, want %v", tt.r, tt.version, got, tt.want)
+		}
+	}
+
+	if v, err := LookupUnicodeVersion("15"); err != nil || v != "15.0" {
+		t.Errorf("LookupUnicodeVersion(15) = %q, %v", v, err)
+	}
+	if _, err := LookupUnicodeVersion("2.0"); err == nil {
+		t.Error("LookupUnicodeVersion() should reject versions without tables")
+	}
+	if !versionLess("9.0", "10.0") || versionLess("15.1", "15.0") {
+		t.Error("versionLess() should compare numerically")
+	}
+
+	input := "𠮷野家\n鿰\n"
+	for version, want := range map[string]int{"3.0": 2, "13.0": 0} {
+		results, err := SearchStreamWithOptions(strings.NewReader(input), []string{"@class:unassigned"}, SearchOptions{UnicodeVersion: version})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := results["@class:unassigned"].Count; got != want {
+			t.Errorf("%s: Count = %d, want %d", version, got, want)
+		}
+	}
+}
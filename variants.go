@@ -0,0 +1,134 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// VariantTable は文字からその異体字の集合(自身を含む)を引く表です
+type VariantTable map[rune][]rune
+
+// builtinVariants は人名・地名でよく使われる異体字の組です
+var builtinVariants = []string{
+	"高 髙",
+	"崎 﨑 嵜",
+	"辺 邊 邉",
+	"斉 斎 齊 齋",
+	"吉 𠮷",
+	"沢 澤",
+	"浜 濱",
+	"島 嶋 嶌",
+	"徳 德",
+	"桜 櫻",
+	"柳 栁",
+	"広 廣",
+	"国 國",
+	"関 關",
+	"瀬 瀨",
+	"槙 槇",
+	"塚 塚",
+	"隆 隆",
+}
+
+// DefaultVariants は組み込みの異体字表を返します
+func DefaultVariants() VariantTable {
+	table := make(VariantTable)
+	for _, line := range builtinVariants {
+		table.addClass(strings.Fields(line))
+	}
+	return table
+}
+
+// LoadVariants は異体字表ファイルを読み込み table に追加します。
+// 1行に1組、互いに同一視する文字を空白区切りで並べます。'#' で始まる行は無視します。
+func LoadVariants(r io.Reader, table VariantTable) error {
+	scanner := bufio.NewScanner(r)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		chars := strings.Fields(line)
+		for _, c := range chars {
+			if utf8.RuneCountInString(c) != 1 {
+				return fmt.Errorf("variants line %d: each variant must be a single character: %s", lineNum, c)
+			}
+		}
+		table.addClass(chars)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("error reading variants: %w", err)
+	}
+	return nil
+}
+
+// addClass は互いに同一視する文字の組を登録します。既存の組と共通する文字があれば併合します。
+func (t VariantTable) addClass(chars []string) {
+	set := make(map[rune]bool)
+	for _, c := range chars {
+		r, _ := utf8.DecodeRuneInString(c)
+		set[r] = true
+		for _, v := range t[r] {
+			set[v] = true
+		}
+	}
+
+	class := make([]rune, 0, len(set))
+	for r := range set {
+		class = append(class, r)
+	}
+	sort.Slice(class, func(i, j int) bool { return class[i] < class[j] })
+
+	for _, r := range class {
+		t[r] = class
+	}
+}
+
+// String は表の内容を決定的な順序で文字列化します(キャッシュキー用)
+func (t VariantTable) String() string {
+	keys := make([]rune, 0, len(t))
+	for r := range t {
+		keys = append(keys, r)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+	var sb strings.Builder
+	for _, r := range keys {
+		fmt.Fprintf(&sb, "%c=%s;", r, string(t[r]))
+	}
+	return sb.String()
+}
+
+// ivsPattern は異体字セレクタ(IVS)に一致する正規表現の断片です
+const ivsPattern = `[\x{E0100}-\x{E01EF}]?`
+
+// variantRegexp はクエリの各文字を異体字の選択に置き換え、IVS付きの表記にも一致する正規表現を生成します
+func variantRegexp(query string, table VariantTable) *regexp.Regexp {
+	var sb strings.Builder
+	for _, r := range query {
+		class, ok := table[r]
+		if !ok || len(class) < 2 {
+			sb.WriteString(regexp.QuoteMeta(string(r)))
+		} else {
+			sb.WriteString("(?:")
+			for i, v := range class {
+				if i > 0 {
+					sb.WriteByte('|')
+				}
+				sb.WriteString(regexp.QuoteMeta(string(v)))
+			}
+			sb.WriteByte(')')
+		}
+		sb.WriteString(ivsPattern)
+	}
+	return regexp.MustCompile(sb.String())
+}
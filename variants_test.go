@@ -0,0 +1,63 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+// TestSearchStream_Variants は異体字・IVS付きの表記にも一致し、表記別に集計されるか確認します
+func TestSearchStream_Variants(t *testing.T) {
+	table := DefaultVariants()
+	if err := LoadVariants(strings.NewReader("# custom\n橋 槗\n"), table); err != nil {
+		t.Fatalf("LoadVariants() error = %v", err)
+	}
+
+	content := "高橋\n髙橋\n髙\U000E0100橋\n高槗\n佐藤\n"
+	results, err := SearchStreamWithOptions(strings.NewReader(content), []string{"高橋"}, SearchOptions{
+		ContextSize: 1,
+		Variants:    table,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	res := results["高橋"]
+	if res.Count != 4 {
+		t.Errorf("Count = %d, want 4", res.Count)
+	}
+	want := map[string]int{"高橋": 1, "髙橋": 1, "髙\U000E0100橋": 1, "高槗": 1}
+	for v, n := range want {
+		if res.Variants[v] != n {
+			t.Errorf("Variants[%q] = %d, want %d", v, res.Variants[v], n)
+		}
+	}
+
+	if err := LoadVariants(strings.NewReader("高橋 髙橋\n"), table); err == nil {
+		t.Error("LoadVariants() should reject multi-character variants")
+	}
+}
+
+// TestRun_ExpandVariants は -expand-variants が検索に反映されるか確認します
+func TestRun_ExpandVariants(t *testing.T) {
+	mockStdout := new(bytes.Buffer)
+	ctx := AppContext{
+		Args:     []string{"app", "-expand-variants", "in.txt"},
+		ExecPath: "app_高橋",
+		Stdout:   mockStdout,
+		Stderr:   io.Discard,
+		FileReader: func(_ string) (io.ReadCloser, error) {
+			return io.NopCloser(strings.NewReader("高橋\n髙橋\n")), nil
+		},
+	}
+
+	if code := Run(ctx); code != 0 {
+		t.Fatalf("Run() exit code = %d", code)
+	}
+	for _, want := range []string{"該当数: 2", "表記別: 高橋=1, 髙橋=1"} {
+		if !strings.Contains(mockStdout.String(), want) {
+			t.Errorf("Output should contain %q.\n Output: %s", want, mockStdout.String())
+		}
+	}
+}
@@ -0,0 +1,324 @@
+package main
+
+import (
+	"archive/zip"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// WalkOptions はディレクトリを走査する際の対象ファイルの絞り込みです。
+// パターンは path.Match 形式で、ファイル名またはディレクトリからの相対パスのどちらかに一致すれば該当とみなします。
+type WalkOptions struct {
+	Include []string // いずれかに一致するファイルのみ対象にする(空なら全て)
+	Exclude []string // いずれかに一致するファイル・ディレクトリを除外する
+	Jobs    int      // 同時に検索するファイル数(1以下なら1つずつ検索する)
+}
+
+// FileCount はディレクトリ検索時の1ファイルの該当数です
+type FileCount struct {
+	Path      string         `json:"path"`
+	Count     int            `json:"count"`
+	Ownership *FileOwnership `json:"ownership,omitempty"` // -file-owner 指定時のみ
+}
+
+// ParsePatterns はカンマ区切りのパターンを検証して返します
+func ParsePatterns(spec string) ([]string, error) {
+	if spec == "" {
+		return nil, nil
+	}
+	var patterns []string
+	for _, p := range strings.Split(spec, ",") {
+		p = strings.TrimSpace(p)
+		if _, err := path.Match(p, ""); err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", p, err)
+		}
+		patterns = append(patterns, p)
+	}
+	return patterns, nil
+}
+
+// matchPattern は相対パス rel がパターンのいずれかに一致するかを返します
+func matchPattern(patterns []string, rel string) bool {
+	for _, p := range patterns {
+		if ok, _ := path.Match(p, path.Base(rel)); ok {
+			return true
+		}
+		if ok, _ := path.Match(p, rel); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// WalkFiles は fsys を再帰的に走査し、検索対象となるファイルの相対パスを名前順に返します。
+// 除外パターンに一致したディレクトリの中は走査しません。
+func WalkFiles(fsys fs.FS, opts WalkOptions) ([]string, error) {
+	var files []string
+	err := fs.WalkDir(fsys, ".", func(rel string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return nil
+		}
+		if matchPattern(opts.Exclude, rel) {
+			if d.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		if len(opts.Include) > 0 && !matchPattern(opts.Include, rel) {
+			return nil
+		}
+		files = append(files, rel)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk directory: %w", err)
+	}
+	return files, nil
+}
+
+// SearchDirectory は root 以下のファイルを検索し、結果を統合します。
+// 各結果の Files にはヒットのあったファイルごとの該当数を記録します。
+// ZIPアーカイブはエントリごとに検索します。
+func SearchDirectory(fsys fs.FS, root string, queries []string, opts SearchOptions, wopts WalkOptions, password func() (string, error)) (map[string]*SearchResult, error) {
+	targets, err := directoryTargets(fsys, root, queries, opts, wopts, password)
+	if err != nil {
+		return nil, err
+	}
+	return searchTargets(targets, queries, opts, wopts.Jobs)
+}
+
+// SearchInputs は複数の入力(ファイルまたはディレクトリ)を検索し、指定された順に結果を統合します
+func SearchInputs(ctx AppContext, paths []string, queries []string, opts SearchOptions, wopts WalkOptions, password func() (string, error)) (map[string]*SearchResult, error) {
+	var targets []searchTarget
+	for _, p := range paths {
+		if p != StdinPath && ctx.FileStat != nil && ctx.DirFS != nil {
+			if info, err := ctx.FileStat(p); err == nil && info.IsDir() {
+				dirTargets, err := directoryTargets(ctx.DirFS(p), p, queries, opts, wopts, password)
+				if err != nil {
+					return nil, fmt.Errorf("%s: %w", p, err)
+				}
+				targets = append(targets, dirTargets...)
+				continue
+			}
+		}
+		targets = append(targets, searchTarget{path: p, search: func() (map[string]*SearchResult, error) {
+			f, err := openInput(ctx, p)
+			if err != nil {
+				return nil, err
+			}
+			defer f.Close()
+			return searchFile(f, p, queries, opts, password)
+		}})
+	}
+	return searchTargets(targets, queries, opts, wopts.Jobs)
+}
+
+// searchTarget は検索対象の1ファイルです
+type searchTarget struct {
+	path   string // 結果に表示するパス
+	search func() (map[string]*SearchResult, error)
+}
+
+// directoryTargets は root 以下の検索対象のファイルを名前順に返します
+func directoryTargets(fsys fs.FS, root string, queries []string, opts SearchOptions, wopts WalkOptions, password func() (string, error)) ([]searchTarget, error) {
+	files, err := WalkFiles(fsys, wopts)
+	if err != nil {
+		return nil, err
+	}
+	targets := make([]searchTarget, len(files))
+	for i, rel := range files {
+		display := filepath.Join(root, filepath.FromSlash(rel))
+		targets[i] = searchTarget{path: display, search: func() (map[string]*SearchResult, error) {
+			f, err := fsys.Open(rel)
+			if err != nil {
+				return nil, err
+			}
+			defer f.Close()
+			return searchFile(f, display, queries, opts, password)
+		}}
+	}
+	return targets, nil
+}
+
+// searchTargets は jobs 個のワーカーでファイルを並行して検索し、結果を targets の順に統合します。
+// 統合の順序は検索の完了順によらないため、結果は1つずつ検索した場合と同じになります。
+// パススルーや行の書き出し等、走査中に書き出す設定がある場合は出力が混ざらないよう1つずつ検索します。
+func searchTargets(targets []searchTarget, queries []string, opts SearchOptions, jobs int) (map[string]*SearchResult, error) {
+	if opts.PassThrough != nil || opts.LineSinks != nil || opts.OnMatch != nil {
+		jobs = 1
+	}
+	jobs = max(min(jobs, len(targets)), 1)
+
+	results := make([]map[string]*SearchResult, len(targets))
+	errs := make([]error, len(targets))
+
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < jobs; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				results[i], errs[i] = targets[i].search()
+			}
+		}()
+	}
+	for i := range targets {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+
+	merged := NewResults(queries)
+	for i, t := range targets {
+		if errs[i] != nil {
+			return nil, fmt.Errorf("%s: %w", t.path, errs[i])
+		}
+		MergeResults(merged, results[i], t.path, opts.MaxSnippets)
+		for q, res := range results[i] {
+			d, ok := merged[q]
+			switch {
+			case !ok:
+			case len(res.Files) > 0:
+				// ZIPアーカイブはエントリごとの該当数を残す
+				d.Files = append(d.Files, res.Files...)
+			case res.Count > 0:
+				d.Files = append(d.Files, FileCount{Path: t.path, Count: res.Count})
+			}
+		}
+	}
+	return merged, nil
+}
+
+// searchFile は1ファイルを検索します。ZIPアーカイブはエントリごとに、gzipファイルは展開しながら検索します。
+func searchFile(f io.Reader, display string, queries []string, opts SearchOptions, password func() (string, error)) (map[string]*SearchResult, error) {
+	// 通常のファイルは走査中のサイズの変化を検出する(scanInput で既に包んでいる場合は何もしない)。
+	// ZIPアーカイブは末尾の中央ディレクトリから位置を指定して読むため対象外とする。
+	if guard := newSizeGuard(f, opts.AllowGrowth); guard != nil && !isZipPath(display) {
+		results, err := searchFile(guard, display, queries, opts, password)
+		if err == nil {
+			guard.Annotate(results, display)
+		}
+		return results, err
+	}
+	if opts.Trace != nil {
+		span := opts.Trace.Start("file", stringAttr("file.path", display))
+		defer span.End()
+		opts.Trace = span
+	}
+	switch {
+	case isZipPath(display):
+		archive, size, err := readZipInput(f)
+		if err != nil {
+			return nil, err
+		}
+		return SearchZip(archive, size, display, queries, opts, password)
+	case isGzipPath(display):
+		return SearchGzip(f, queries, opts)
+	}
+	return SearchStreamWithOptions(f, queries, opts)
+}
+
+// scanInputOptions は検索語を使わない走査モード(-audit 等)で入力を開く方法です
+type scanInputOptions struct {
+	Encoding string // -enc (auto を含む。空なら UTF-8、UTF-16 のBOMがあれば UTF-16)
+	Walk     WalkOptions
+	Password func() (string, error) // 暗号化されたZIPエントリのパスワード
+}
+
+// scanInputs は検索語を使わない走査モードで、paths のすべての入力を検索と同じ手順で開いて fn に渡します。
+// ディレクトリは Walk に従って名前順に再帰的に走査し、ZIPアーカイブはエントリごと("a.zip:b.txt")に、
+// gzipファイルは展開して渡します。内容は Encoding に従って UTF-8 に変換します。
+func scanInputs(ctx AppContext, paths []string, opts scanInputOptions, fn func(r io.Reader, path string) error) error {
+	for _, p := range paths {
+		if p != StdinPath && ctx.FileStat != nil && ctx.DirFS != nil {
+			if info, err := ctx.FileStat(p); err == nil && info.IsDir() {
+				fsys := ctx.DirFS(p)
+				files, err := WalkFiles(fsys, opts.Walk)
+				if err != nil {
+					return fmt.Errorf("%s: %w", p, err)
+				}
+				for _, rel := range files {
+					display := filepath.Join(p, filepath.FromSlash(rel))
+					f, err := fsys.Open(rel)
+					if err != nil {
+						return fmt.Errorf("%s: %w", display, err)
+					}
+					err = scanFile(f, display, opts, fn)
+					f.Close()
+					if err != nil {
+						return fmt.Errorf("%s: %w", display, err)
+					}
+				}
+				continue
+			}
+		}
+		f, err := openInput(ctx, p)
+		if err != nil {
+			return fmt.Errorf("%s: %w", p, err)
+		}
+		err = scanFile(f, p, opts, fn)
+		f.Close()
+		if err != nil {
+			return fmt.Errorf("%s: %w", p, err)
+		}
+	}
+	return nil
+}
+
+// scanFile は searchFile と同じく、ZIPアーカイブはエントリごとに、gzipファイルは展開して fn に渡します
+func scanFile(f io.Reader, display string, opts scanInputOptions, fn func(r io.Reader, path string) error) error {
+	decode := func(r io.Reader, path string) error {
+		r, _, err := decodeInput(r, opts.Encoding)
+		if err != nil {
+			return err
+		}
+		return fn(r, path)
+	}
+	switch {
+	case isZipPath(display):
+		archive, size, err := readZipInput(f)
+		if err != nil {
+			return err
+		}
+		zr, err := zip.NewReader(archive, size)
+		if err != nil {
+			return fmt.Errorf("invalid zip archive: %w", err)
+		}
+		for _, zf := range zr.File {
+			if zf.FileInfo().IsDir() {
+				continue
+			}
+			rc, err := openZipEntry(zf, opts.Password)
+			if err != nil {
+				return fmt.Errorf("%s: %w", zf.Name, err)
+			}
+			err = decode(rc, display+zipEntrySeparator+zf.Name)
+			rc.Close()
+			if err != nil {
+				return fmt.Errorf("%s: %w", zf.Name, err)
+			}
+		}
+		return nil
+	case isGzipPath(display):
+		zr, err := gzip.NewReader(f)
+		if err != nil {
+			return fmt.Errorf("invalid gzip file: %w", err)
+		}
+		defer zr.Close()
+		return decode(zr, display)
+	}
+	return decode(f, display)
+}
@@ -0,0 +1,196 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/fs"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"golang.org/x/text/encoding/unicode"
+)
+
+// TestSearchDirectory は配下のファイルが再帰的に検索され、ファイルごとの該当数が記録されるか確認します
+func TestSearchDirectory(t *testing.T) {
+	fsys := fstest.MapFS{
+		"a.log":            {Data: []byte("髙橋\n髙橋と齋藤\n")},
+		"sub/b.log":        {Data: []byte("齋藤\n髙橋\n")},
+		"sub/c.bin":        {Data: []byte("髙橋\n")},
+		"vendor/d.log":     {Data: []byte("髙橋\n")},
+		"sub/deep/e.log":   {Data: []byte("なし\n")},
+		"sub/deep/f.log.1": {Data: []byte("髙橋\n")},
+	}
+	wopts := WalkOptions{Include: []string{"*.log"}, Exclude: []string{"vendor"}}
+
+	files, err := WalkFiles(fsys, wopts)
+	if err != nil {
+		t.Fatalf("WalkFiles() error = %v", err)
+	}
+	if got := strings.Join(files, ","); got != "a.log,sub/b.log,sub/deep/e.log" {
+		t.Errorf("WalkFiles() = %s", got)
+	}
+
+	results, err := SearchDirectory(fsys, "logs", []string{"髙橋", "齋藤"}, SearchOptions{ContextSize: 1}, wopts, nil)
+	if err != nil {
+		t.Fatalf("SearchDirectory() error = %v", err)
+	}
+	res := results["髙橋"]
+	if res.Count != 3 {
+		t.Errorf("Count = %d, want 3", res.Count)
+	}
+	want := []FileCount{{Path: "logs/a.log", Count: 2}, {Path: "logs/sub/b.log", Count: 1}}
+	if len(res.Files) != len(want) || res.Files[0] != want[0] || res.Files[1] != want[1] {
+		t.Errorf("Files = %+v, want %+v", res.Files, want)
+	}
+
+	var buf bytes.Buffer
+	WriteResults(&buf, results, []string{"髙橋"})
+	if !strings.Contains(buf.String(), "ファイル別:\n  logs/a.log: 2\n  logs/sub/b.log: 1\n") {
+		t.Errorf("WriteResults() output missing per-file section:\n%s", buf.String())
+	}
+
+	if _, err := ParsePatterns("*.log,[x"); err == nil {
+		t.Error("ParsePatterns() should reject malformed patterns")
+	}
+}
+
+// TestSearchDirectory_Jobs は並行して検索しても、1つずつ検索した場合と同じ順序の結果になるか確認します
+func TestSearchDirectory_Jobs(t *testing.T) {
+	fsys := fstest.MapFS{}
+	for i := 0; i < 40; i++ {
+		content := strings.Repeat("INFO\n", i*50) + fmt.Sprintf("髙橋 %d\n", i)
+		fsys[fmt.Sprintf("d%02d/app.log", i)] = &fstest.MapFile{Data: []byte(content)}
+	}
+	queries := []string{"髙橋"}
+
+	var want map[string]*SearchResult
+	for _, jobs := range []int{1, 8} {
+		got, err := SearchDirectory(fsys, "logs", queries, SearchOptions{ContextSize: 3}, WalkOptions{Jobs: jobs}, nil)
+		if err != nil {
+			t.Fatalf("jobs=%d: SearchDirectory() error = %v", jobs, err)
+		}
+		if want == nil {
+			want = got
+			continue
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("jobs=%d: results differ from sequential search", jobs)
+		}
+	}
+	if res := want["髙橋"]; res.Count != 40 || res.First.Path != "logs/d00/app.log" || res.Last.Path != "logs/d39/app.log" {
+		t.Errorf("Count = %d, First = %+v, Last = %+v", res.Count, res.First, res.Last)
+	}
+}
+
+// TestRun_MultipleInputs は複数の入力パスを指定した順に統合して報告するか確認します
+func TestRun_MultipleInputs(t *testing.T) {
+	files := map[string]string{"b.log": "WARN b\n", "a.log": "WARN a\nWARN a\n"}
+	stdout := new(bytes.Buffer)
+	code := Run(AppContext{
+		Args:     []string{"app", "-jobs", "2", "b.log", "a.log"},
+		ExecPath: "app_WARN",
+		Stdout:   stdout,
+		Stderr:   io.Discard,
+		FileReader: func(path string) (io.ReadCloser, error) {
+			content, ok := files[path]
+			if !ok {
+				return nil, fs.ErrNotExist
+			}
+			return io.NopCloser(strings.NewReader(content)), nil
+		},
+	})
+	if code != 0 {
+		t.Fatalf("Run() exit code = %d", code)
+	}
+	if out := stdout.String(); !strings.Contains(out, "該当数: 3\n") || !strings.Contains(out, "ファイル別:\n  b.log: 1\n  a.log: 2\n") {
+		t.Errorf("Output should merge both inputs in the given order.\n Output: %s", out)
+	}
+}
+
+// TestRun_ScanModes は検索語を使わない走査モードが、検索と同じ手順(ディレクトリ・ZIP/gzip の展開・文字コードの判定)で入力を読むか確認します
+func TestRun_ScanModes(t *testing.T) {
+	var gz bytes.Buffer
+	gw := gzip.NewWriter(&gz)
+	gw.Write([]byte("𩸽\n"))
+	gw.Close()
+	var zipped bytes.Buffer
+	zw := zip.NewWriter(&zipped)
+	w, _ := zw.Create("in.txt")
+	w.Write([]byte("𠮟\n"))
+	zw.Close()
+	utf16, _ := unicode.UTF16(unicode.LittleEndian, unicode.UseBOM).NewEncoder().String("𠮟る\n")
+
+	fsys := fstest.MapFS{
+		"audit.txt":  {Data: []byte("森鷗外\n")},
+		"glyph.txt":  {Data: []byte("亜\n辻堂駅\n")},
+		"plain.txt":  {Data: []byte("亜\n")},
+		"ivs.txt":    {Data: []byte("辻\U000E0100\n")},
+		"nonbmp.txt": {Data: []byte("𠮟責する\n")},
+		"u16.txt":    {Data: []byte(utf16)},
+		"log.gz":     {Data: gz.Bytes()},
+		"arc.zip":    {Data: zipped.Bytes()},
+		"dir/a.txt":  {Data: []byte("𠮟\n")},
+		"dir/b.log":  {Data: []byte("𩸽\n")},
+	}
+	ctx := AppContext{
+		Stderr:     io.Discard,
+		FileReader: func(p string) (io.ReadCloser, error) { return fsys.Open(p) },
+		FileStat:   func(p string) (fs.FileInfo, error) { return fsys.Stat(p) },
+		DirFS: func(dir string) fs.FS {
+			sub, _ := fs.Sub(fsys, dir)
+			return sub
+		},
+	}
+
+	tests := []struct {
+		name string
+		args []string
+		code int
+		want string
+	}{
+		{"inventory", []string{"-inventory", "sjis", "arc.zip", "u16.txt"}, ExitMatch, "U+20B9F 𠮟 2件: arc.zip:in.txt:1:1, u16.txt:1:1\n"},
+		{"column stats", []string{"-column-stats", "sjis", "-columns", "tsv", "-format", "jsonl", "-include", "*.txt", "arc.zip", "dir"}, ExitMatch,
+			`{"column":1,"rows":2,"problem_rows":2,"chars":["𠮟"]}` + "\n"},
+		{"audit", []string{"-audit", "jis2004", "audit.txt"}, ExitMatch,
+			"第1面 第3水準漢字: 1種 1件\n  U+9DD7 鷗 1件: audit.txt:1:2\n    森鷗外\n"},
+		{"unknown audit", []string{"-audit", "jis90", "audit.txt"}, ExitError, ""},
+		{"glyph changes", []string{"-glyph-changes", "glyph.txt", "plain.txt"}, ExitMatch, "glyph.txt:2: 辻 U+8FBB: 辻堂駅\n"},
+		{"no glyph changes", []string{"-glyph-changes", "plain.txt"}, ExitNoMatch, ""},
+		{"ivs report", []string{"-ivs-report", "ivs.txt"}, ExitMatch, "U+8FBB U+E0100 辻\U000E0100 1件: ivs.txt:1:1\n"},
+		{"non-bmp", []string{"-non-bmp", "-n", "2", "nonbmp.txt", "plain.txt"}, ExitMatch, "nonbmp.txt:1:1: U+20B9F 𠮟 (第1面 第3水準漢字): 𠮟責す\n"},
+		{"no non-bmp", []string{"-non-bmp", "plain.txt"}, ExitNoMatch, ""},
+		{"utf-16 bom", []string{"-non-bmp", "-n", "1", "u16.txt"}, ExitMatch, "u16.txt:1:1: U+20B9F 𠮟 (第1面 第3水準漢字): 𠮟る\n"},
+		{"gzip", []string{"-non-bmp", "-n", "1", "log.gz"}, ExitMatch, "log.gz:1:1: U+29E3D 𩸽 (第2面 第4水準漢字): 𩸽\n"},
+		{"zip entry", []string{"-non-bmp", "-n", "1", "arc.zip"}, ExitMatch, "arc.zip:in.txt:1:1: U+20B9F 𠮟 (第1面 第3水準漢字): 𠮟\n"},
+		{"directory", []string{"-non-bmp", "-n", "1", "-include", "*.txt", "dir"}, ExitMatch, "dir/a.txt:1:1: U+20B9F 𠮟 (第1面 第3水準漢字): 𠮟\n"},
+		{"missing file", []string{"-ivs-report", "missing.txt"}, ExitError, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stdout := new(bytes.Buffer)
+			ctx := ctx
+			ctx.Args = append([]string{"app"}, tt.args...)
+			ctx.Stdout = stdout
+			if code := Run(ctx); code != tt.code {
+				t.Fatalf("Run() exit code = %d, want %d", code, tt.code)
+			}
+			if stdout.String() != tt.want {
+				t.Errorf("output = %q, want %q", stdout.String(), tt.want)
+			}
+		})
+	}
+
+	// 結果を書き込めない場合は書き込みエラーの終了コードを返す
+	ctx.Stdout = fullDisk{}
+	for _, args := range [][]string{{"-inventory", "sjis", "arc.zip"}, {"-column-stats", "sjis", "arc.zip"}} {
+		ctx.Args = append([]string{"app"}, args...)
+		if code := Run(ctx); code != ExitWriteError {
+			t.Errorf("Run(%v) exit code = %d, want %d", ctx.Args, code, ExitWriteError)
+		}
+	}
+}
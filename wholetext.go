@@ -0,0 +1,147 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// DefaultWholeTextPrefix は -no-line-mode のテキスト出力で使うスニペットの接頭辞です
+const DefaultWholeTextPrefix = "char {char} (line {line}): "
+
+// DefaultMaxTextBytes は -no-line-mode で読み込む入力(UTF-8に変換後)の既定の上限(バイト)です。
+// 入力全体と、スニペット用にその数倍のルーン列をメモリに保持するため上限を設けます。
+const DefaultMaxTextBytes = 64 << 20
+
+// textLine はつなげたテキスト中の1行の位置です
+type textLine struct {
+	start  int   // つなげたテキストでの開始位置(バイト)
+	offset int64 // 元の入力での行頭のバイト位置
+}
+
+// joinLines は入力の改行(CRLF・CR・LF)を取り除いて行をつなげたテキストと、各行の位置を返します。
+// 前処理(-filter)は行ごとに行ってからつなげます。
+func joinLines(data []byte, filters []LineFilter) (string, []textLine) {
+	var sb strings.Builder
+	sb.Grow(len(data))
+	var lines []textLine
+	var offset int64
+	for rest := data; len(rest) > 0; {
+		line, nl := rest, 0
+		if i := bytes.IndexAny(rest, "\r\n"); i >= 0 {
+			line, nl = rest[:i], 1
+			if rest[i] == '\r' && i+1 < len(rest) && rest[i+1] == '\n' {
+				nl = 2
+			}
+		}
+		lines = append(lines, textLine{start: sb.Len(), offset: offset})
+		text := string(line)
+		if len(lines) == 1 {
+			text = strings.TrimPrefix(text, utf8BOM)
+		}
+		if filters != nil {
+			text = applyFilters(text, filters)
+		}
+		sb.WriteString(text)
+		offset += int64(len(line) + nl)
+		rest = rest[len(line)+nl:]
+	}
+	return sb.String(), lines
+}
+
+// searchWholeText は入力を1つの続いたテキストとして照合します(-no-line-mode)。
+// 行をつなげて照合するため、折り返しで行をまたいだ語もヒットします。
+// 該当数はヒットした行の数ではなくヒットした箇所の数で、スニペットにはテキスト全体での文字位置(Char)と
+// ヒットの始まる行を記録します。除外指定(objis:allow)は行単位の指定のため使いません。
+// 入力全体をメモリに読み込むため、opts.MaxTextBytes を超える入力はエラーにします。
+func searchWholeText(r io.Reader, queries []string, opts SearchOptions) (map[string]*SearchResult, error) {
+	if opts.MaxTextBytes > 0 {
+		r = io.LimitReader(r, opts.MaxTextBytes+1)
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("error reading stream: %w", err)
+	}
+	if opts.MaxTextBytes > 0 && int64(len(data)) > opts.MaxTextBytes {
+		return nil, fmt.Errorf("input exceeds %d bytes; raise -max-text-bytes to search it with -no-line-mode", opts.MaxTextBytes)
+	}
+	text, lines := joinLines(data, resolveFilters(opts.Filters))
+
+	var arena snippetArena
+	var runes []rune // 遅延初期化: スニペットが必要になった時だけ変換する
+	results := make(map[string]*SearchResult, len(queries))
+	for _, q := range queries {
+		res := &SearchResult{Query: q, Lines: len(lines), Bytes: int64(len(data))}
+		results[q] = res
+		m := newMatcher(q, opts)
+		capture, _, captures := captureSource(m, text)
+
+		var redacted []rune
+		pos, chars := 0, 0 // 直前のヒットまでのバイト数と文字数
+		for _, loc := range m.findAll(text) {
+			chars += utf8.RuneCountInString(text[pos:loc[0]])
+			pos = loc[0]
+
+			k := sort.Search(len(lines), func(i int) bool { return lines[i].start > loc[0] }) - 1
+			line := lines[k]
+			res.Count++
+			res.Last = &Occurrence{Line: k + 1, Offset: line.offset}
+			if res.First == nil {
+				res.First = res.Last
+			}
+			if opts.Variants != nil {
+				if res.Variants == nil {
+					res.Variants = make(map[string]int)
+				}
+				res.Variants[text[loc[0]:loc[1]]]++
+			}
+			if captures {
+				recordCaptures(res, capture.re, text[loc[0]:loc[1]])
+			}
+
+			if opts.CountOnly || snippetsFull(len(res.Snippets), opts.MaxSnippets) {
+				continue
+			}
+			if runes == nil {
+				runes = []rune(text)
+			}
+			src := runes
+			if mask := redactMask(m, opts); mask != 0 {
+				if redacted == nil {
+					redacted = redactRunes(runes, text, m, mask)
+				}
+				src = redacted
+			}
+			start := chars
+			end := start + utf8.RuneCountInString(text[loc[0]:loc[1]])
+			from, to := contextRange(runes, start, end, opts)
+			pre := EscapeSnippet(string(src[from:start]), opts.Escape)
+			hit := EscapeSnippet(string(src[start:end]), opts.Escape)
+			post := EscapeSnippet(string(src[end:to]), opts.Escape)
+			pre, hit, post = clampSnippet(pre, hit, post, opts.MaxSnippetBytes)
+			snippet := arena.join(pre, hit, post)
+			res.Snippets = append(res.Snippets, snippet)
+			info := SnippetInfo{
+				Line:       k + 1,
+				Offset:     line.offset,
+				Repeats:    1,
+				Lines:      []int{k + 1},
+				Col:        utf8.RuneCountInString(text[line.start:loc[0]]) + 1,
+				Char:       start + 1,
+				MatchStart: len(pre),
+				MatchEnd:   len(pre) + len(hit),
+			}
+			if opts.CompareEncoding != nil {
+				info.Converted = SimulateConversion(snippet, opts.CompareEncoding)
+			}
+			if opts.KeepRaw && redactMask(m, opts) == 0 {
+				info.Raw = clampRaw([]byte(string(runes[from:to])), opts.MaxSnippetBytes)
+			}
+			res.Infos = append(res.Infos, info)
+		}
+	}
+	return results, nil
+}
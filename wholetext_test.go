@@ -0,0 +1,63 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+// TestSearchStream_WholeText は行をまたいだ語がヒットし、文字位置と行が記録されるか確認します
+func TestSearchStream_WholeText(t *testing.T) {
+	input := "担当: 山田太\r\n郎、山田太郎\nend\n"
+	results, err := SearchStreamWithOptions(strings.NewReader(input), []string{"山田太郎"}, SearchOptions{ContextSize: 2, WholeText: true})
+	if err != nil {
+		t.Fatalf("SearchStreamWithOptions() error = %v", err)
+	}
+	res := results["山田太郎"]
+	if res.Count != 2 || res.Lines != 3 {
+		t.Fatalf("Count = %d, Lines = %d, want 2 hits in 3 lines", res.Count, res.Lines)
+	}
+	if res.Snippets[0] != ": 山田太郎、山" {
+		t.Errorf("Snippets[0] = %q", res.Snippets[0])
+	}
+	first, second := res.Infos[0], res.Infos[1]
+	if first.Char != 5 || first.Line != 1 || first.Col != 5 {
+		t.Errorf("first hit = char %d line %d col %d, want char 5 line 1 col 5", first.Char, first.Line, first.Col)
+	}
+	if second.Char != 10 || second.Line != 2 || second.Col != 3 || second.Offset != 19 {
+		t.Errorf("second hit = char %d line %d col %d offset %d", second.Char, second.Line, second.Col, second.Offset)
+	}
+}
+
+// TestRun_NoLineMode は文字位置の接頭辞で出力し、行単位の機能との併用を拒否するか確認します
+func TestRun_NoLineMode(t *testing.T) {
+	run := func(args ...string) (string, int) {
+		stdout := new(bytes.Buffer)
+		code := Run(AppContext{
+			Args:     append([]string{"app", "-no-line-mode"}, args...),
+			ExecPath: "app_ERROR",
+			Stdout:   stdout,
+			Stderr:   io.Discard,
+			FileReader: func(path string) (io.ReadCloser, error) {
+				return io.NopCloser(strings.NewReader("disk ERR\nOR\n")), nil
+			},
+		})
+		return stdout.String(), code
+	}
+
+	if out, code := run("input.log"); code != 0 || !strings.Contains(out, "char 6 (line 1): disk ERROR\n") {
+		t.Errorf("Output should report the character offset (code %d).\n Output: %s", code, out)
+	}
+	if _, code := run("-fold-duplicates", "input.log"); code == 0 {
+		t.Error("-no-line-mode with -fold-duplicates should fail")
+	}
+
+	// 入力全体を保持するため、上限を超える入力はエラーにする
+	if _, code := run("-max-text-bytes", "12", "input.log"); code != ExitMatch {
+		t.Errorf("Input within -max-text-bytes exit code = %d, want %d", code, ExitMatch)
+	}
+	if _, code := run("-max-text-bytes", "11", "input.log"); code != ExitError {
+		t.Errorf("Input over -max-text-bytes exit code = %d, want %d", code, ExitError)
+	}
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+	"golang.org/x/text/width"
+)
+
+// 半角の濁点・半濁点
+const (
+	halfwidthVoicedMark     = 'ﾞ'
+	halfwidthSemiVoicedMark = 'ﾟ'
+)
+
+// foldWidthLine は半角カタカナを全角に、全角英数字・記号を半角に揃えた行と、
+// 揃えた後の位置を元の行の位置へ戻すための区間の一覧を返します(-fold-width)。
+// 半角の濁点・半濁点は直前の文字と合成できれば1文字にします(ｶﾞ → ガ)。
+func foldWidthLine(line string) (string, []normSegment) {
+	var sb strings.Builder
+	sb.Grow(len(line))
+	var segs []normSegment
+	for i := 0; i < len(line); {
+		r, size := utf8.DecodeRuneInString(line[i:])
+		end := i + size
+		if folded := width.LookupRune(r).Folded(); folded != 0 {
+			r = folded
+		}
+		if mark, markSize := utf8.DecodeRuneInString(line[end:]); mark == halfwidthVoicedMark || mark == halfwidthSemiVoicedMark {
+			if composed, ok := composeSoundMark(r, mark); ok {
+				r, end = composed, end+markSize
+			}
+		}
+		segs = append(segs, normSegment{norm: sb.Len(), orig: i, origEnd: end})
+		sb.WriteRune(r)
+		i = end
+	}
+	return sb.String(), segs
+}
+
+// composeSoundMark は仮名に半角の濁点・半濁点を合成した文字を返します(合成できる文字がなければ ok は false)
+func composeSoundMark(r, mark rune) (rune, bool) {
+	combining := '゙'
+	if mark == halfwidthSemiVoicedMark {
+		combining = '゚'
+	}
+	composed := norm.NFC.String(string(r) + string(combining))
+	c, size := utf8.DecodeRuneInString(composed)
+	return c, size == len(composed) && c != r
+}
+
+// needsWidthFold は行に幅を揃える対象の文字(半角・全角形, U+FF00〜U+FFEF)が含まれるかを返します
+func needsWidthFold(line string) bool {
+	return strings.IndexFunc(line, func(r rune) bool { return r >= 0xFF00 && r <= 0xFFEF }) >= 0
+}
+
+// widthMatcher は行の幅を揃えた写しで照合し、ヒット位置は元の行の位置で返します。
+// スニペットは元の行から切り出されます。
+type widthMatcher struct {
+	inner matcher // 幅を揃えたクエリで照合するmatcher
+}
+
+func (m widthMatcher) find(line string) []int {
+	if !needsWidthFold(line) {
+		return m.inner.find(line)
+	}
+	text, segs := foldWidthLine(line)
+	loc := m.inner.find(text)
+	if loc == nil {
+		return nil
+	}
+	return origRange(segs, loc)
+}
+
+func (m widthMatcher) findAll(line string) [][]int {
+	if !needsWidthFold(line) {
+		return m.inner.findAll(line)
+	}
+	text, segs := foldWidthLine(line)
+	locs := m.inner.findAll(text)
+	for i, loc := range locs {
+		locs[i] = origRange(segs, loc)
+	}
+	return locs
+}
+
+func (m widthMatcher) alwaysRedact() bool { return m.inner.alwaysRedact() }
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestFoldWidthLine は半角カタカナ(濁点・半濁点の合成を含む)と全角英数字の幅を揃えるか確認します
+func TestFoldWidthLine(t *testing.T) {
+	tests := map[string]string{
+		"ｶﾀﾛｸﾞ":   "カタログ",
+		"ﾊﾟﾝﾌﾚｯﾄ": "パンフレット",
+		"ＡＢＣ１２３":  "ABC123",
+		"ﾞｱﾞ":     "\u3099ア\u3099", // 合成できない濁点は結合文字のまま
+		"カタログ":    "カタログ",
+	}
+	for in, want := range tests {
+		if got, _ := foldWidthLine(in); got != want {
+			t.Errorf("foldWidthLine(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+// TestSearchStream_FoldWidth は -fold-width で半角・全角のどちらのクエリも両方の表記にヒットし、スニペットは元の表記のまま切り出すか確認します
+func TestSearchStream_FoldWidth(t *testing.T) {
+	content := "新ｶﾀﾛｸﾞ配布\nカタログ請求\nＩＤ：Ａ１\n"
+	queries := []string{"カタログ", "ｶﾀﾛｸﾞ", "ID：A1"}
+
+	results, err := SearchStreamWithOptions(strings.NewReader(content), queries, SearchOptions{ContextSize: 1, FoldWidth: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := map[string]struct {
+		count   int
+		snippet string
+		match   string
+	}{
+		"カタログ":  {2, "新ｶﾀﾛｸﾞ配", "ｶﾀﾛｸﾞ"},
+		"ｶﾀﾛｸﾞ": {2, "新ｶﾀﾛｸﾞ配", "ｶﾀﾛｸﾞ"},
+		"ID：A1": {1, "ＩＤ：Ａ１", "ＩＤ：Ａ１"},
+	}
+	for q, want := range tests {
+		res := results[q]
+		if res.Count != want.count || len(res.Snippets) == 0 || res.Snippets[0] != want.snippet {
+			t.Errorf("%s: count = %d, snippets = %q, want %d, %q", q, res.Count, res.Snippets, want.count, want.snippet)
+			continue
+		}
+		info := res.Infos[0]
+		if got := res.Snippets[0][info.MatchStart:info.MatchEnd]; got != want.match {
+			t.Errorf("%s: match = %q, want %q", q, got, want.match)
+		}
+	}
+
+	results, err = SearchStreamWithOptions(strings.NewReader(content), queries[:1], SearchOptions{ContextSize: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res := results["カタログ"]; res.Count != 1 {
+		t.Errorf("Without -fold-width count = %d, want 1", res.Count)
+	}
+}
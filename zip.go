@@ -0,0 +1,211 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/flate"
+	"errors"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"strings"
+)
+
+// zipMethodAES はWinZip形式のAES暗号化を示す圧縮方式番号です
+const zipMethodAES = 99
+
+// isZipPath はパスがZIPアーカイブを指しているかを拡張子で判定します
+func isZipPath(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), ".zip")
+}
+
+// zipEntrySeparator はZIPのエントリを示すパス(a.zip:dir/b.txt)のアーカイブとエントリの区切りです
+const zipEntrySeparator = ":"
+
+// splitZipEntry はZIPのエントリを示すパスをアーカイブのパスとエントリ(区切りを含む)に分けます。
+// エントリを示すパスでなければ、そのままのパスと空のエントリを返します。
+func splitZipEntry(path string) (archive, entry string) {
+	if i := strings.Index(strings.ToLower(path), ".zip"+zipEntrySeparator); i >= 0 {
+		return path[:i+len(".zip")], path[i+len(".zip"):]
+	}
+	return path, ""
+}
+
+// SearchZip はZIPアーカイブ内の各ファイルを展開せずに検索し、結果を統合します。
+// スニペットの出典とエントリごとの該当数(Files)は "アーカイブ名:エントリ名" で記録します。
+// 暗号化されたエントリがある場合のみ password を呼び出します(従来のZipCrypto方式に対応)。
+func SearchZip(r io.ReaderAt, size int64, path string, queries []string, opts SearchOptions, password func() (string, error)) (map[string]*SearchResult, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("invalid zip archive: %w", err)
+	}
+
+	merged := NewResults(queries)
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+
+		rc, err := openZipEntry(f, password)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", f.Name, err)
+		}
+		results, err := SearchStreamWithOptions(rc, queries, opts)
+		rc.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", f.Name, err)
+		}
+		entry := path + zipEntrySeparator + f.Name
+		MergeResults(merged, results, entry, opts.MaxSnippets)
+		for q, res := range results {
+			if d, ok := merged[q]; ok && res.Count > 0 {
+				d.Files = append(d.Files, FileCount{Path: entry, Count: res.Count})
+			}
+		}
+	}
+	return merged, nil
+}
+
+// openZipEntry はエントリを開きます。暗号化されている場合はメモリ上で復号します。
+func openZipEntry(f *zip.File, password func() (string, error)) (io.ReadCloser, error) {
+	if f.Flags&0x1 == 0 {
+		return f.Open()
+	}
+	if f.Method == zipMethodAES {
+		return nil, errors.New("AES-encrypted zip entries are not supported (use ZipCrypto)")
+	}
+	if password == nil {
+		return nil, errors.New("entry is encrypted; specify -zip-password")
+	}
+	pw, err := password()
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := f.OpenRaw()
+	if err != nil {
+		return nil, err
+	}
+	dec, err := newZipCryptoReader(raw, pw, zipCheckByte(f))
+	if err != nil {
+		return nil, err
+	}
+
+	var body io.ReadCloser
+	switch f.Method {
+	case zip.Store:
+		body = io.NopCloser(dec)
+	case zip.Deflate:
+		body = flate.NewReader(dec)
+	default:
+		return nil, fmt.Errorf("unsupported compression method: %d", f.Method)
+	}
+	return &crcCheckReader{ReadCloser: body, hash: crc32.NewIEEE(), want: f.CRC32}, nil
+}
+
+// zipCheckByte は暗号化ヘッダーの末尾と照合するパスワード確認用のバイトを返します。
+// データ記述子を使うエントリでは更新時刻の上位バイト、それ以外はCRC32の上位バイトです。
+func zipCheckByte(f *zip.File) byte {
+	if f.Flags&0x8 != 0 {
+		return byte(f.ModifiedTime >> 8)
+	}
+	return byte(f.CRC32 >> 24)
+}
+
+// zipCrypto は従来のPKWARE暗号(ZipCrypto)の鍵状態です
+type zipCrypto struct {
+	keys [3]uint32
+}
+
+func newZipCrypto(password string) *zipCrypto {
+	z := &zipCrypto{keys: [3]uint32{0x12345678, 0x23456789, 0x34567890}}
+	for i := 0; i < len(password); i++ {
+		z.update(password[i])
+	}
+	return z
+}
+
+func (z *zipCrypto) update(b byte) {
+	z.keys[0] = crc32Update(z.keys[0], b)
+	z.keys[1] = (z.keys[1]+z.keys[0]&0xff)*134775813 + 1
+	z.keys[2] = crc32Update(z.keys[2], byte(z.keys[1]>>24))
+}
+
+func (z *zipCrypto) decrypt(b byte) byte {
+	t := z.keys[2] | 2
+	p := b ^ byte((t*(t^1))>>8)
+	z.update(p)
+	return p
+}
+
+func crc32Update(crc uint32, b byte) uint32 {
+	return crc32.IEEETable[byte(crc)^b] ^ crc>>8
+}
+
+// zipCryptoReader は暗号化されたデータを読みながら復号します
+type zipCryptoReader struct {
+	r io.Reader
+	z *zipCrypto
+}
+
+// newZipCryptoReader は12バイトの暗号化ヘッダーを復号してパスワードを確認します
+func newZipCryptoReader(r io.Reader, password string, check byte) (io.Reader, error) {
+	z := newZipCrypto(password)
+	header := make([]byte, 12)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, fmt.Errorf("failed to read encryption header: %w", err)
+	}
+	for i, b := range header {
+		header[i] = z.decrypt(b)
+	}
+	if header[11] != check {
+		return nil, errors.New("incorrect zip password")
+	}
+	return &zipCryptoReader{r: r, z: z}, nil
+}
+
+func (d *zipCryptoReader) Read(p []byte) (int, error) {
+	n, err := d.r.Read(p)
+	for i := range p[:n] {
+		p[i] = d.z.decrypt(p[i])
+	}
+	return n, err
+}
+
+// crcCheckReader は読み終えた時点でCRC32を照合し、誤ったパスワードによる化けを検出します
+type crcCheckReader struct {
+	io.ReadCloser
+	hash hash.Hash32
+	want uint32
+}
+
+func (c *crcCheckReader) Read(p []byte) (int, error) {
+	n, err := c.ReadCloser.Read(p)
+	c.hash.Write(p[:n])
+	if err == io.EOF && c.hash.Sum32() != c.want {
+		return n, errors.New("checksum mismatch (incorrect zip password?)")
+	}
+	return n, err
+}
+
+// readZipInput はアーカイブを開くための io.ReaderAt とサイズを返します。
+// 通常のファイルは位置を指定して必要な部分だけを読み、標準入力等の順次読み込みしかできない入力だけを
+// メモリに読み込みます(展開したデータはディスクに書き出さない)。
+func readZipInput(r io.Reader) (io.ReaderAt, int64, error) {
+	if f, ok := r.(interface {
+		io.ReaderAt
+		Stat() (fs.FileInfo, error)
+	}); ok {
+		if info, err := f.Stat(); err == nil && info.Mode().IsRegular() {
+			return f, info.Size(), nil
+		}
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read zip archive: %w", err)
+	}
+	return bytes.NewReader(data), int64(len(data)), nil
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// encryptedZip はZipCryptoで暗号化した無圧縮エントリを1つ含むZIPを作成します
+func encryptedZip(t *testing.T, name, content, password string) []byte {
+	t.Helper()
+	sum := crc32.ChecksumIEEE([]byte(content))
+
+	z := newZipCrypto(password)
+	plain := append(make([]byte, 11), byte(sum>>24))
+	plain = append(plain, content...)
+	cipher := make([]byte, len(plain))
+	for i, p := range plain {
+		k := z.keys[2] | 2
+		cipher[i] = p ^ byte((k*(k^1))>>8)
+		z.update(p)
+	}
+
+	buf := new(bytes.Buffer)
+	zw := zip.NewWriter(buf)
+	w, err := zw.CreateRaw(&zip.FileHeader{
+		Name:               name,
+		Method:             zip.Store,
+		Flags:              0x1,
+		CRC32:              sum,
+		CompressedSize64:   uint64(len(cipher)),
+		UncompressedSize64: uint64(len(content)),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.Write(cipher)
+
+	// 暗号化していないエントリも混在させる
+	pw, _ := zw.Create("plain.log")
+	pw.Write([]byte("WARN plain\n"))
+	zw.Close()
+	return buf.Bytes()
+}
+
+// TestSearchZip は暗号化・非暗号化エントリが検索され、パスワード誤りが検出されるか確認します
+func TestSearchZip(t *testing.T) {
+	data := encryptedZip(t, "secret.log", "INFO\nWARN secret\n", "pass")
+	search := func(password string) (map[string]*SearchResult, error) {
+		return SearchZip(bytes.NewReader(data), int64(len(data)), "bundle.zip", []string{"WARN"}, SearchOptions{ContextSize: 3},
+			func() (string, error) { return password, nil })
+	}
+
+	results, err := search("pass")
+	if err != nil {
+		t.Fatalf("SearchZip() error = %v", err)
+	}
+	res := results["WARN"]
+	if res.Count != 2 {
+		t.Errorf("Count = %d, want 2", res.Count)
+	}
+	if res.Infos[0].Path != "bundle.zip:secret.log" || res.Snippets[0] != "WARN se" {
+		t.Errorf("First snippet = %q from %s, want decrypted secret.log", res.Snippets[0], res.Infos[0].Path)
+	}
+	if len(res.Files) != 2 || res.Files[0] != (FileCount{Path: "bundle.zip:secret.log", Count: 1}) {
+		t.Errorf("Files = %+v, want a count per entry", res.Files)
+	}
+
+	if _, err := search("wrong"); err == nil {
+		t.Error("SearchZip() should fail with an incorrect password")
+	}
+}
+
+// seekOnlyFile は Read を使うと失敗するファイルです(アーカイブ全体を読み込んでいないことの確認用)
+type seekOnlyFile struct {
+	*os.File
+}
+
+func (seekOnlyFile) Read([]byte) (int, error) {
+	return 0, errors.New("sequential read")
+}
+
+// TestReadZipInput はファイルを位置指定で読み、順次読み込みしかできない入力だけをメモリに読み込むか確認します
+func TestReadZipInput(t *testing.T) {
+	data := encryptedZip(t, "secret.log", "WARN secret\n", "pass")
+	path := filepath.Join(t.TempDir(), "bundle.zip")
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	for _, r := range []io.Reader{seekOnlyFile{f}, bytes.NewBuffer(data)} {
+		archive, size, err := readZipInput(r)
+		if err != nil {
+			t.Fatalf("readZipInput(%T) error = %v", r, err)
+		}
+		if size != int64(len(data)) {
+			t.Errorf("readZipInput(%T) size = %d, want %d", r, size, len(data))
+		}
+		results, err := SearchZip(archive, size, "bundle.zip", []string{"WARN"}, SearchOptions{ContextSize: 3},
+			func() (string, error) { return "pass", nil })
+		if err != nil {
+			t.Fatalf("SearchZip(%T) error = %v", r, err)
+		}
+		if got := results["WARN"].Count; got != 2 {
+			t.Errorf("SearchZip(%T) Count = %d, want 2", r, got)
+		}
+	}
+}